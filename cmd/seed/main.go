@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"log"
 
 	"github.com/Diegonr1791/GymBro/internal/config"
@@ -8,6 +9,9 @@ import (
 )
 
 func main() {
+	catalogOnly := flag.Bool("catalog-only", false, "Cargar solo el catálogo estándar de ejercicios")
+	flag.Parse()
+
 	// Cargar variables de entorno
 	if err := godotenv.Load(); err != nil {
 		log.Println("Advertencia: No se pudo cargar el archivo .env")
@@ -16,6 +20,15 @@ func main() {
 	// Inicializar contenedor de dependencias
 	container := config.NewContainer()
 
+	// Cargar solo el catálogo si se indicó --catalog-only
+	if *catalogOnly {
+		if err := container.Seeder.SeedCatalog(); err != nil {
+			log.Fatalf("❌ Error cargando el catálogo: %v", err)
+		}
+		log.Println("🎉 ¡Catálogo cargado!")
+		return
+	}
+
 	// Ejecutar seeding
 	log.Println("🌱 Iniciando proceso de seeding...")
 
@@ -33,6 +46,7 @@ func main() {
 	log.Println("")
 	log.Println("📋 Información:")
 	log.Println("   - Los roles del sistema han sido creados")
+	log.Println("   - El catálogo estándar de ejercicios ha sido cargado")
 	log.Println("   - Los usuarios se crean según las variables de entorno configuradas")
 	log.Println("")
 	log.Println("🔧 Variables de entorno para usuarios:")
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CoachAccessGormRepository struct {
+	db *gorm.DB
+}
+
+func NewCoachAccessGormRepository(db *gorm.DB) repositories.CoachAccessRepository {
+	return &CoachAccessGormRepository{db}
+}
+
+func (r *CoachAccessGormRepository) Grant(acceso *models.AccesoEntrenador) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "usuario_id"}, {Name: "entrenador_id"}},
+		DoNothing: true,
+	}).Create(acceso)
+	if result.Error != nil {
+		return false, errors.Wrap(result.Error, "CoachAccessGormRepository.Grant")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *CoachAccessGormRepository) Revoke(usuarioID, entrenadorID uint) (bool, error) {
+	result := r.db.Where("usuario_id = ? AND entrenador_id = ?", usuarioID, entrenadorID).Delete(&models.AccesoEntrenador{})
+	if result.Error != nil {
+		return false, errors.Wrapf(result.Error, "CoachAccessGormRepository.Revoke: usuarioID %d, entrenadorID %d", usuarioID, entrenadorID)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *CoachAccessGormRepository) IsAuthorized(usuarioID, entrenadorID uint) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.AccesoEntrenador{}).Where("usuario_id = ? AND entrenador_id = ?", usuarioID, entrenadorID).Count(&count).Error; err != nil {
+		return false, errors.Wrapf(err, "CoachAccessGormRepository.IsAuthorized: usuarioID %d, entrenadorID %d", usuarioID, entrenadorID)
+	}
+	return count > 0, nil
+}
+
+func (r *CoachAccessGormRepository) GetCoaches(usuarioID uint) ([]models.AccesoEntrenador, error) {
+	accesos, err := r.withContact("accesos_entrenador.entrenador_id", "accesos_entrenador.usuario_id = ?", usuarioID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "CoachAccessGormRepository.GetCoaches: usuarioID %d", usuarioID)
+	}
+	return accesos, nil
+}
+
+func (r *CoachAccessGormRepository) GetClients(entrenadorID uint) ([]models.AccesoEntrenador, error) {
+	accesos, err := r.withContact("accesos_entrenador.usuario_id", "accesos_entrenador.entrenador_id = ?", entrenadorID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "CoachAccessGormRepository.GetClients: entrenadorID %d", entrenadorID)
+	}
+	return accesos, nil
+}
+
+// withContact lists the accesses matching the condition with the name and email of the user joined on the given column.
+// Accesses of deleted users are left out.
+func (r *CoachAccessGormRepository) withContact(contactColumn, where string, id uint) ([]models.AccesoEntrenador, error) {
+	type row struct {
+		models.AccesoEntrenador
+		ContactoNombre string
+		ContactoEmail  string
+	}
+	var rows []row
+	err := r.db.Table("accesos_entrenador").
+		Select("accesos_entrenador.*, usuarios.name AS contacto_nombre, usuarios.email AS contacto_email").
+		Joins("JOIN usuarios ON usuarios.id = "+contactColumn+" AND usuarios.is_deleted = false").
+		Where(where, id).
+		Order("accesos_entrenador.created_at ASC, accesos_entrenador.id ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	accesos := make([]models.AccesoEntrenador, len(rows))
+	for i, r := range rows {
+		accesos[i] = r.AccesoEntrenador
+		accesos[i].Nombre, accesos[i].Email = r.ContactoNombre, r.ContactoEmail
+	}
+	return accesos, nil
+}
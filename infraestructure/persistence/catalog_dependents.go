@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// dependentQuery counts the rows of a model referencing an entity through a column
+type dependentQuery struct {
+	kind   string
+	model  interface{}
+	column string
+}
+
+// countDependents runs each dependent query for the given ID, skipping kinds without rows
+func countDependents(db *gorm.DB, id uint, queries ...dependentQuery) (repositories.DependentCounts, error) {
+	counts := repositories.DependentCounts{}
+	for _, q := range queries {
+		var total int64
+		if err := db.Model(q.model).Where(q.column+" = ?", id).Count(&total).Error; err != nil {
+			return nil, err
+		}
+		if total > 0 {
+			counts[q.kind] = total
+		}
+	}
+	return counts, nil
+}
+
+// countExerciseHistory counts the session entries and goals of users on the exercises whose column matches the ID
+func countExerciseHistory(db *gorm.DB, column string, id uint) (int64, error) {
+	var total int64
+	for _, model := range []interface{}{&models.SesionEjercicio{}, &models.Meta{}} {
+		var n int64
+		exercises := db.Model(&models.Ejercicio{}).Select("id").Where(column+" = ?", id)
+		if err := db.Model(model).Where("ejercicio_id IN (?)", exercises).Count(&n).Error; err != nil {
+			return 0, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// deleteExercises removes exercises together with their session entries, personal records, muscle group links and
+// alternatives, and returns the stored media keys of the removed exercises
+func deleteExercises(tx *gorm.DB, ids []uint) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var media []models.Ejercicio
+	if err := tx.Select("imagen_key", "miniatura_key", "video_key").Where("id IN ?", ids).Find(&media).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("ejercicio_id IN ?", ids).Delete(&models.SesionEjercicio{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("ejercicio_id IN ?", ids).Delete(&models.RecordPersonal{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("ejercicio_id IN ?", ids).Delete(&models.Meta{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.SesionEjercicio{}).Where("ejercicio_original_id IN ?", ids).
+		Update("ejercicio_original_id", nil).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("ejercicio_id IN ?", ids).Delete(&models.EjercicioGrupoMuscular{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("ejercicio_id IN ? OR alternativa_id IN ?", ids, ids).Delete(&models.EjercicioAlternativa{}).Error; err != nil {
+		return nil, err
+	}
+	if err := deleteTranslations(tx, models.EntidadEjercicio, ids...); err != nil {
+		return nil, err
+	}
+	if err := tx.Delete(&models.Ejercicio{}, ids).Error; err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, e := range media {
+		keys = append(keys, e.ImagenKey, e.MiniaturaKey, e.VideoKey)
+	}
+	return keys, nil
+}
+
+// deleteTranslations removes the translations of catalog entities
+func deleteTranslations(tx *gorm.DB, entidad string, ids ...uint) error {
+	return tx.Where("entidad = ? AND entidad_id IN ?", entidad, ids).Delete(&models.Traduccion{}).Error
+}
+
+// isForeignKeyViolation checks if the database rejected the statement because of a foreign key
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
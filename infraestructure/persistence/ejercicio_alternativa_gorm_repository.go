@@ -0,0 +1,54 @@
+package persistence
+
+import (
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExerciseAlternativeGormRepository struct {
+	db *gorm.DB
+}
+
+func NewExerciseAlternativeGormRepository(db *gorm.DB) repositories.ExerciseAlternativeRepository {
+	return &ExerciseAlternativeGormRepository{db}
+}
+
+// CreateIfNotExists inserts the alternative unless the pair already exists.
+// It reports whether a new row was created.
+func (r *ExerciseAlternativeGormRepository) CreateIfNotExists(alternativa *models.EjercicioAlternativa) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "ejercicio_id"}, {Name: "alternativa_id"}},
+		DoNothing: true,
+	}).Create(alternativa)
+	if result.Error != nil {
+		return false, errors.Wrap(result.Error, "ExerciseAlternativeGormRepository.CreateIfNotExists")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Delete removes the pair in either direction and reports whether it existed
+func (r *ExerciseAlternativeGormRepository) Delete(ejercicioID, alternativaID uint) (bool, error) {
+	result := r.db.
+		Where("(ejercicio_id = ? AND alternativa_id = ?) OR (ejercicio_id = ? AND alternativa_id = ?)",
+			ejercicioID, alternativaID, alternativaID, ejercicioID).
+		Delete(&models.EjercicioAlternativa{})
+	if result.Error != nil {
+		return false, errors.Wrapf(result.Error, "ExerciseAlternativeGormRepository.Delete: ejercicioID %d, alternativaID %d", ejercicioID, alternativaID)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetAlternativeIDs returns the IDs of the curated alternatives of an exercise
+func (r *ExerciseAlternativeGormRepository) GetAlternativeIDs(ejercicioID uint) ([]uint, error) {
+	var ids []uint
+	if err := r.db.Model(&models.EjercicioAlternativa{}).
+		Select("CASE WHEN ejercicio_id = ? THEN alternativa_id ELSE ejercicio_id END", ejercicioID).
+		Where("ejercicio_id = ? OR alternativa_id = ?", ejercicioID, ejercicioID).
+		Scan(&ids).Error; err != nil {
+		return nil, errors.Wrapf(err, "ExerciseAlternativeGormRepository.GetAlternativeIDs: ejercicioID %d", ejercicioID)
+	}
+	return ids, nil
+}
@@ -16,50 +16,245 @@ func NewExerciseGormRepository(db *gorm.DB) repositories.ExerciseRepository {
 	return &ExerciseGormRepository{db}
 }
 
-func (r *ExerciseGormRepository) GetAll() ([]*models.Ejercicio, error) {
+func (r *ExerciseGormRepository) GetById(id uint) (*models.Ejercicio, error) {
+	var ejercicio models.Ejercicio
+	if err := r.db.Preload("GruposMusculares").First(&ejercicio, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "ExerciseGormRepository.GetById: id %d", id)
+	}
+	return &ejercicio, nil
+}
+
+// GetByIDProjected reads the fields and relations of an exercise requested by the projection
+func (r *ExerciseGormRepository) GetByIDProjected(id uint, proj repositories.Projection) (*models.Ejercicio, error) {
+	ejercicio, err := first[models.Ejercicio](r.db.Preload("GruposMusculares"), id, repositories.ExerciseProjection, proj)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "ExerciseGormRepository.GetByIDProjected: id %d", id)
+	}
+	return ejercicio, nil
+}
+
+func (r *ExerciseGormRepository) GetByIDs(ids []uint) ([]*models.Ejercicio, error) {
 	var ejercicios []*models.Ejercicio
-	if err := r.db.Find(&ejercicios).Error; err != nil {
-		return nil, errors.Wrap(err, "ExerciseGormRepository.GetAll")
+	if len(ids) == 0 {
+		return ejercicios, nil
+	}
+	if err := r.db.Preload("GruposMusculares").Where("id IN ?", ids).Find(&ejercicios).Error; err != nil {
+		return nil, errors.Wrap(err, "ExerciseGormRepository.GetByIDs")
 	}
 	return ejercicios, nil
 }
 
-func (r *ExerciseGormRepository) GetById(id uint) (*models.Ejercicio, error) {
+// GetSharedByName finds a shared catalog exercise by name, ignoring case
+func (r *ExerciseGormRepository) GetSharedByName(nombre string) (*models.Ejercicio, error) {
 	var ejercicio models.Ejercicio
-	if err := r.db.First(&ejercicio, id).Error; err != nil {
+	if err := r.db.Preload("GruposMusculares").
+		Where("is_custom = ? AND LOWER(nombre) = LOWER(?)", false, nombre).
+		Order("id ASC").First(&ejercicio).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrNotFound
 		}
-		return nil, errors.Wrapf(err, "ExerciseGormRepository.GetById: id %d", id)
+		return nil, errors.Wrapf(err, "ExerciseGormRepository.GetSharedByName: %s", nombre)
 	}
 	return &ejercicio, nil
 }
 
 func (r *ExerciseGormRepository) Create(ejercicio *models.Ejercicio) error {
 	if err := r.db.Create(ejercicio).Error; err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrInvalidReference
+		}
 		return errors.Wrap(err, "ExerciseGormRepository.Create")
 	}
 	return nil
 }
 
+// Update saves the exercise and replaces its muscle group links
 func (r *ExerciseGormRepository) Update(ejercicio *models.Ejercicio) error {
-	if err := r.db.Save(ejercicio).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("GruposMusculares").Save(ejercicio).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("ejercicio_id = ?", ejercicio.ID).Delete(&models.EjercicioGrupoMuscular{}).Error; err != nil {
+			return err
+		}
+		for i := range ejercicio.GruposMusculares {
+			ejercicio.GruposMusculares[i].ID = 0
+			ejercicio.GruposMusculares[i].EjercicioID = ejercicio.ID
+		}
+		if len(ejercicio.GruposMusculares) > 0 {
+			if err := tx.Create(&ejercicio.GruposMusculares).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrInvalidReference
+		}
 		return errors.Wrapf(err, "ExerciseGormRepository.Update: id %d", ejercicio.ID)
 	}
 	return nil
 }
 
 func (r *ExerciseGormRepository) Delete(id uint) error {
-	if err := r.db.Delete(&models.Ejercicio{}, id).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("ejercicio_id = ?", id).Delete(&models.EjercicioGrupoMuscular{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("ejercicio_id = ? OR alternativa_id = ?", id, id).Delete(&models.EjercicioAlternativa{}).Error; err != nil {
+			return err
+		}
+		if err := deleteTranslations(tx, models.EntidadEjercicio, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.Ejercicio{}, id).Error
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrConflict
+		}
 		return errors.Wrapf(err, "ExerciseGormRepository.Delete: id %d", id)
 	}
 	return nil
 }
 
-func (r *ExerciseGormRepository) GetByMuscleGroup(muscleGroupID uint) ([]*models.Ejercicio, error) {
+// CountDependents counts the session entries that performed the exercise
+func (r *ExerciseGormRepository) CountDependents(id uint) (repositories.DependentCounts, error) {
+	counts, err := countDependents(r.db, id,
+		dependentQuery{"session_exercises", &models.SesionEjercicio{}, "ejercicio_id"},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ExerciseGormRepository.CountDependents: id %d", id)
+	}
+	return counts, nil
+}
+
+// DeleteWith deletes the exercise after deleting or reassigning the session entries that performed it
+func (r *ExerciseGormRepository) DeleteWith(id uint, opts repositories.DeleteOptions) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if opts.ReassignTo != 0 {
+			if err := tx.Model(&models.SesionEjercicio{}).Where("ejercicio_id = ?", id).
+				Update("ejercicio_id", opts.ReassignTo).Error; err != nil {
+				return err
+			}
+		}
+		// The usecase removes the media of the exercise it loaded
+		_, err := deleteExercises(tx, []uint{id})
+		return err
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrConflict
+		}
+		return errors.Wrapf(err, "ExerciseGormRepository.DeleteWith: id %d", id)
+	}
+	return nil
+}
+
+// exerciseList defines the fields exercises can be sorted and filtered by on top of the catalog filters
+var exerciseList = listQuery{
+	fields: listFields{
+		"id":                {"id", true},
+		"nombre":            {"nombre", true},
+		"tipo_ejercicio_id": {"tipo_ejercicio_id", true},
+		"grupo_muscular_id": {"grupo_muscular_id", true},
+		"equipamiento":      {"equipamiento", false},
+		"dificultad":        {"dificultad", false},
+		"unilateral":        {"unilateral", true},
+		"is_custom":         {"is_custom", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "nombre"}},
+	preloads:    []string{"GruposMusculares"},
+	projection:  repositories.ExerciseProjection,
+}
+
+// Search returns the exercises matching every non-zero filter; custom exercises are only
+// returned to their owner
+func (r *ExerciseGormRepository) Search(filter repositories.ExerciseFilter) ([]*models.Ejercicio, error) {
 	var ejercicios []*models.Ejercicio
-	if err := r.db.Where("grupo_muscular_id = ?", muscleGroupID).Find(&ejercicios).Error; err != nil {
-		return nil, errors.Wrapf(err, "ExerciseGormRepository.GetByMuscleGroup: muscleGroupID %d", muscleGroupID)
+	if err := r.searchQuery(filter).Preload("GruposMusculares").Order("ejercicios.nombre ASC").Find(&ejercicios).Error; err != nil {
+		return nil, errors.Wrap(err, "ExerciseGormRepository.Search")
 	}
 	return ejercicios, nil
 }
+
+// SearchPage returns a page of the exercises matching the filter and the query spec
+func (r *ExerciseGormRepository) SearchPage(filter repositories.ExerciseFilter, spec repositories.QuerySpec) (*repositories.Page[*models.Ejercicio], error) {
+	page, err := list[*models.Ejercicio](r.searchQuery(filter), spec, exerciseList)
+	if err != nil {
+		return nil, errors.Wrap(err, "ExerciseGormRepository.SearchPage")
+	}
+	return page, nil
+}
+
+// searchQuery builds the conditions of the catalog filter
+func (r *ExerciseGormRepository) searchQuery(filter repositories.ExerciseFilter) *gorm.DB {
+	query := r.db.Model(&models.Ejercicio{}).
+		Where("(ejercicios.is_custom = ? OR ejercicios.usuario_id = ?)", false, filter.ViewerID)
+
+	if filter.Custom != nil {
+		query = query.Where("ejercicios.is_custom = ?", *filter.Custom)
+	}
+
+	if filter.Search != "" {
+		query = query.Where("ejercicios.nombre ILIKE ?", containsPattern(filter.Search))
+	}
+	if filter.TipoEjercicioID != 0 {
+		query = query.Where("ejercicios.tipo_ejercicio_id = ?", filter.TipoEjercicioID)
+	}
+	if filter.GrupoMuscularID != 0 {
+		query = query.Where("(ejercicios.grupo_muscular_id = ? OR EXISTS (SELECT 1 FROM ejercicio_grupo_muscular egm WHERE egm.ejercicio_id = ejercicios.id AND egm.grupo_muscular_id = ?))",
+			filter.GrupoMuscularID, filter.GrupoMuscularID)
+	}
+	if filter.PrimaryGrupoMuscularID != 0 {
+		query = query.Where("(ejercicios.grupo_muscular_id = ? OR EXISTS (SELECT 1 FROM ejercicio_grupo_muscular egm WHERE egm.ejercicio_id = ejercicios.id AND egm.grupo_muscular_id = ? AND egm.rol = ?))",
+			filter.PrimaryGrupoMuscularID, filter.PrimaryGrupoMuscularID, models.RolGrupoPrimario)
+	}
+	if filter.SecondaryGrupoMuscularID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM ejercicio_grupo_muscular egm WHERE egm.ejercicio_id = ejercicios.id AND egm.grupo_muscular_id = ? AND egm.rol = ?)",
+			filter.SecondaryGrupoMuscularID, models.RolGrupoSecundario)
+	}
+	if filter.Equipamiento != "" {
+		query = query.Where("ejercicios.equipamiento = ?", filter.Equipamiento)
+	}
+	if filter.Dificultad != "" {
+		query = query.Where("ejercicios.dificultad = ?", filter.Dificultad)
+	}
+	if filter.Unilateral != nil {
+		query = query.Where("ejercicios.unilateral = ?", *filter.Unilateral)
+	}
+	if filter.HasMedia != nil {
+		if *filter.HasMedia {
+			query = query.Where("(COALESCE(ejercicios.imagen_url, '') <> '' OR COALESCE(ejercicios.video_url, '') <> '' OR COALESCE(ejercicios.imagen_key, '') <> '' OR COALESCE(ejercicios.video_key, '') <> '')")
+		} else {
+			query = query.Where("COALESCE(ejercicios.imagen_url, '') = '' AND COALESCE(ejercicios.video_url, '') = '' AND COALESCE(ejercicios.imagen_key, '') = '' AND COALESCE(ejercicios.video_key, '') = ''")
+		}
+	}
+	return query
+}
+
+// UpdateMedia updates only the uploaded media keys of an exercise
+func (r *ExerciseGormRepository) UpdateMedia(ejercicio *models.Ejercicio) error {
+	if err := r.db.Model(ejercicio).
+		Select("imagen_key", "miniatura_key", "video_key").
+		Updates(ejercicio).Error; err != nil {
+		return errors.Wrapf(err, "ExerciseGormRepository.UpdateMedia: id %d", ejercicio.ID)
+	}
+	return nil
+}
+
+// Promote moves a custom exercise into the shared catalog
+func (r *ExerciseGormRepository) Promote(id uint) error {
+	if err := r.db.Model(&models.Ejercicio{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_custom": false, "usuario_id": nil}).Error; err != nil {
+		return errors.Wrapf(err, "ExerciseGormRepository.Promote: id %d", id)
+	}
+	return nil
+}
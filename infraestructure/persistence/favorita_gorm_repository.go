@@ -6,6 +6,7 @@ import (
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type FavoritaGormRepository struct {
@@ -16,50 +17,92 @@ func NewFavoritaGormRepository(db *gorm.DB) repositories.FavoritaRepository {
 	return &FavoritaGormRepository{db}
 }
 
-func (r *FavoritaGormRepository) GetAll() ([]models.Favorita, error) {
-	var favoritas []models.Favorita
-	if err := r.db.Find(&favoritas).Error; err != nil {
-		return nil, errors.Wrap(err, "FavoritaGormRepository.GetAll")
+// favoriteList defines the fields favorites can be sorted and filtered by; the newest come first
+var favoriteList = listQuery{
+	fields: listFields{
+		"id":        {"id", true},
+		"rutina_id": {"rutina_id", true},
+		"fecha":     {"fecha", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "fecha", Desc: true}},
+}
+
+func (r *FavoritaGormRepository) GetFavoritasByUsuarioID(usuarioID uint, spec repositories.QuerySpec) (*repositories.Page[models.Favorita], error) {
+	page, err := list[models.Favorita](r.db.Where("favoritas.usuario_id = ?", usuarioID), spec, favoriteList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "FavoritaGormRepository.GetFavoritasByUsuarioID: usuarioID %d", usuarioID)
 	}
-	return favoritas, nil
+	return page, nil
 }
 
-func (r *FavoritaGormRepository) GetByID(id uint) (*models.Favorita, error) {
+func (r *FavoritaGormRepository) GetByUserAndRoutine(usuarioID, rutinaID uint) (*models.Favorita, error) {
 	var favorita models.Favorita
-	if err := r.db.First(&favorita, id).Error; err != nil {
+	if err := r.db.Where("usuario_id = ? AND rutina_id = ?", usuarioID, rutinaID).First(&favorita).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrNotFound
 		}
-		return nil, errors.Wrapf(err, "FavoritaGormRepository.GetByID: id %d", id)
+		return nil, errors.Wrapf(err, "FavoritaGormRepository.GetByUserAndRoutine: usuarioID %d, rutinaID %d", usuarioID, rutinaID)
 	}
 	return &favorita, nil
 }
 
-func (r *FavoritaGormRepository) Create(favorita *models.Favorita) error {
-	if err := r.db.Create(favorita).Error; err != nil {
-		return errors.Wrap(err, "FavoritaGormRepository.Create")
+// CreateIfNotExists inserts the favorite unless the (usuario, rutina) pair already exists, incrementing
+// the favorite count of the routine. It reports whether a new row was created.
+func (r *FavoritaGormRepository) CreateIfNotExists(favorita *models.Favorita) (bool, error) {
+	created := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "usuario_id"}, {Name: "rutina_id"}},
+			DoNothing: true,
+		}).Create(favorita)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		created = true
+		return addFavoriteCount(tx, favorita.RutinaID, 1)
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "FavoritaGormRepository.CreateIfNotExists")
 	}
-	return nil
+	return created, nil
 }
 
-func (r *FavoritaGormRepository) Update(favorita *models.Favorita) error {
-	if err := r.db.Save(favorita).Error; err != nil {
-		return errors.Wrapf(err, "FavoritaGormRepository.Update: id %d", favorita.ID)
+// DeleteByUserAndRoutine removes the favorite, decrementing the favorite count of the routine when it existed
+func (r *FavoritaGormRepository) DeleteByUserAndRoutine(usuarioID, rutinaID uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("usuario_id = ? AND rutina_id = ?", usuarioID, rutinaID).Delete(&models.Favorita{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return addFavoriteCount(tx, rutinaID, -1)
+	})
+	if err != nil {
+		return errors.Wrapf(err, "FavoritaGormRepository.DeleteByUserAndRoutine: usuarioID %d, rutinaID %d", usuarioID, rutinaID)
 	}
 	return nil
 }
 
-func (r *FavoritaGormRepository) Delete(id uint) error {
-	if err := r.db.Delete(&models.Favorita{}, id).Error; err != nil {
-		return errors.Wrapf(err, "FavoritaGormRepository.Delete: id %d", id)
-	}
-	return nil
+// addFavoriteCount adjusts the denormalized favorite count of a routine
+func addFavoriteCount(tx *gorm.DB, rutinaID uint, delta int) error {
+	return tx.Model(&models.Rutina{}).Where("id = ?", rutinaID).
+		UpdateColumn("favorite_count", gorm.Expr("favorite_count + ?", delta)).Error
 }
 
-func (r *FavoritaGormRepository) GetFavoritasByUsuarioID(usuarioID uint) ([]models.Favorita, error) {
-	var favoritas []models.Favorita
-	if err := r.db.Where("usuario_id = ?", usuarioID).Find(&favoritas).Error; err != nil {
-		return nil, errors.Wrapf(err, "FavoritaGormRepository.GetFavoritasByUsuarioID: usuarioID %d", usuarioID)
+// GetFavoritedRoutineIDs returns which of the given routines the user has favorited
+func (r *FavoritaGormRepository) GetFavoritedRoutineIDs(usuarioID uint, rutinaIDs []uint) (map[uint]bool, error) {
+	favorited := make(map[uint]bool, len(rutinaIDs))
+	if len(rutinaIDs) == 0 {
+		return favorited, nil
+	}
+
+	var ids []uint
+	if err := r.db.Model(&models.Favorita{}).
+		Where("usuario_id = ? AND rutina_id IN ?", usuarioID, rutinaIDs).
+		Pluck("rutina_id", &ids).Error; err != nil {
+		return nil, errors.Wrapf(err, "FavoritaGormRepository.GetFavoritedRoutineIDs: usuarioID %d", usuarioID)
+	}
+	for _, id := range ids {
+		favorited[id] = true
 	}
-	return favoritas, nil
+	return favorited, nil
 }
@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ProgressPhotoGormRepository struct {
+	db *gorm.DB
+}
+
+func NewProgressPhotoGormRepository(db *gorm.DB) repositories.ProgressPhotoRepository {
+	return &ProgressPhotoGormRepository{db}
+}
+
+func (r *ProgressPhotoGormRepository) Create(foto *models.FotoProgreso) error {
+	if err := r.db.Create(foto).Error; err != nil {
+		return errors.Wrap(err, "ProgressPhotoGormRepository.Create")
+	}
+	return nil
+}
+
+func (r *ProgressPhotoGormRepository) GetByID(id uint) (*models.FotoProgreso, error) {
+	var foto models.FotoProgreso
+	if err := r.db.First(&foto, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "ProgressPhotoGormRepository.GetByID: id %d", id)
+	}
+	return &foto, nil
+}
+
+func (r *ProgressPhotoGormRepository) GetByMeasurement(medicionID uint) ([]models.FotoProgreso, error) {
+	var fotos []models.FotoProgreso
+	if err := r.db.Where("medicion_id = ?", medicionID).Order("pose ASC, id ASC").Find(&fotos).Error; err != nil {
+		return nil, errors.Wrapf(err, "ProgressPhotoGormRepository.GetByMeasurement: medicionID %d", medicionID)
+	}
+	return fotos, nil
+}
+
+// progressPhotoList defines the fields progress photos can be sorted and filtered by; they are sorted by pose
+var progressPhotoList = listQuery{
+	fields: listFields{
+		"id":         {"id", true},
+		"pose":       {"pose", true},
+		"created_at": {"created_at", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "pose"}},
+}
+
+func (r *ProgressPhotoGormRepository) GetPageByMeasurement(medicionID uint, spec repositories.QuerySpec) (*repositories.Page[models.FotoProgreso], error) {
+	page, err := list[models.FotoProgreso](r.db.Where("fotos_progreso.medicion_id = ?", medicionID), spec, progressPhotoList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "ProgressPhotoGormRepository.GetPageByMeasurement: medicionID %d", medicionID)
+	}
+	return page, nil
+}
+
+func (r *ProgressPhotoGormRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.FotoProgreso{}, id).Error; err != nil {
+		return errors.Wrapf(err, "ProgressPhotoGormRepository.Delete: id %d", id)
+	}
+	return nil
+}
@@ -31,6 +31,23 @@ func (r *GrupoMuscularGormRepository) GetAll() ([]models.GrupoMuscular, error) {
 	return grupos, nil
 }
 
+// muscleGroupList defines the fields muscle groups can be sorted and filtered by
+var muscleGroupList = listQuery{
+	fields: listFields{
+		"id":     {"id", true},
+		"nombre": {"nombre", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "id"}},
+}
+
+func (r *GrupoMuscularGormRepository) List(spec repositories.QuerySpec) (*repositories.Page[models.GrupoMuscular], error) {
+	page, err := list[models.GrupoMuscular](r.db, spec, muscleGroupList)
+	if err != nil {
+		return nil, errors.Wrap(err, "GrupoMuscularGormRepository.List")
+	}
+	return page, nil
+}
+
 func (r *GrupoMuscularGormRepository) GetByID(id uint) (*models.GrupoMuscular, error) {
 	var g models.GrupoMuscular
 	if err := r.db.First(&g, id).Error; err != nil {
@@ -42,6 +59,18 @@ func (r *GrupoMuscularGormRepository) GetByID(id uint) (*models.GrupoMuscular, e
 	return &g, nil
 }
 
+// GetByName finds a muscle group by name, ignoring case
+func (r *GrupoMuscularGormRepository) GetByName(nombre string) (*models.GrupoMuscular, error) {
+	var g models.GrupoMuscular
+	if err := r.db.Where("LOWER(nombre) = LOWER(?)", nombre).Order("id ASC").First(&g).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "GrupoMuscularGormRepository.GetByName: %s", nombre)
+	}
+	return &g, nil
+}
+
 func (r *GrupoMuscularGormRepository) Update(g *models.GrupoMuscular) error {
 	if err := r.db.Save(g).Error; err != nil {
 		return errors.Wrapf(err, "GrupoMuscularGormRepository.Update: id %d", g.ID)
@@ -50,8 +79,96 @@ func (r *GrupoMuscularGormRepository) Update(g *models.GrupoMuscular) error {
 }
 
 func (r *GrupoMuscularGormRepository) Delete(id uint) error {
-	if err := r.db.Delete(&models.GrupoMuscular{}, id).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteTranslations(tx, models.EntidadGrupoMuscular, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.GrupoMuscular{}, id).Error
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrConflict
+		}
 		return errors.Wrapf(err, "GrupoMuscularGormRepository.Delete: id %d", id)
 	}
 	return nil
 }
+
+// CountDependents counts the exercises and routines referencing the muscle group
+func (r *GrupoMuscularGormRepository) CountDependents(id uint) (repositories.DependentCounts, error) {
+	counts, err := countDependents(r.db, id,
+		dependentQuery{"exercises", &models.Ejercicio{}, "grupo_muscular_id"},
+		dependentQuery{"exercise_muscle_groups", &models.EjercicioGrupoMuscular{}, "grupo_muscular_id"},
+		dependentQuery{"routine_muscle_groups", &models.RutinaGrupoMuscular{}, "grupo_muscular_id"},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GrupoMuscularGormRepository.CountDependents: id %d", id)
+	}
+	history, err := countExerciseHistory(r.db, "grupo_muscular_id", id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GrupoMuscularGormRepository.CountDependents: id %d", id)
+	}
+	if history > 0 {
+		counts[repositories.DependentExerciseHistory] = history
+	}
+	return counts, nil
+}
+
+// DeleteWith deletes the muscle group after deleting or reassigning its dependents
+func (r *GrupoMuscularGormRepository) DeleteWith(id uint, opts repositories.DeleteOptions) ([]string, error) {
+	var mediaKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if opts.ReassignTo != 0 {
+			if err := tx.Model(&models.Ejercicio{}).Where("grupo_muscular_id = ?", id).
+				Update("grupo_muscular_id", opts.ReassignTo).Error; err != nil {
+				return err
+			}
+			// Drop links that would duplicate an existing link to the target group
+			if err := tx.Exec(`DELETE FROM ejercicio_grupo_muscular a WHERE a.grupo_muscular_id = ?
+				AND EXISTS (SELECT 1 FROM ejercicio_grupo_muscular b WHERE b.ejercicio_id = a.ejercicio_id AND b.grupo_muscular_id = ?)`,
+				id, opts.ReassignTo).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.EjercicioGrupoMuscular{}).Where("grupo_muscular_id = ?", id).
+				Update("grupo_muscular_id", opts.ReassignTo).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DELETE FROM rutina_grupo_muscular a WHERE a.grupo_muscular_id = ?
+				AND EXISTS (SELECT 1 FROM rutina_grupo_muscular b WHERE b.rutina_id = a.rutina_id AND b.grupo_muscular_id = ?)`,
+				id, opts.ReassignTo).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.RutinaGrupoMuscular{}).Where("grupo_muscular_id = ?", id).
+				Update("grupo_muscular_id", opts.ReassignTo).Error; err != nil {
+				return err
+			}
+		} else if opts.Cascade {
+			var exerciseIDs []uint
+			if err := tx.Model(&models.Ejercicio{}).Where("grupo_muscular_id = ?", id).Pluck("id", &exerciseIDs).Error; err != nil {
+				return err
+			}
+			keys, err := deleteExercises(tx, exerciseIDs)
+			if err != nil {
+				return err
+			}
+			mediaKeys = keys
+			if err := tx.Where("grupo_muscular_id = ?", id).Delete(&models.EjercicioGrupoMuscular{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("grupo_muscular_id = ?", id).Delete(&models.RutinaGrupoMuscular{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := deleteTranslations(tx, models.EntidadGrupoMuscular, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.GrupoMuscular{}, id).Error
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, domainErrors.ErrConflict
+		}
+		return nil, errors.Wrapf(err, "GrupoMuscularGormRepository.DeleteWith: id %d", id)
+	}
+	return mediaKeys, nil
+}
@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
@@ -8,6 +10,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// preloadValores loads the metric values of the measurements with their definitions
+const preloadValores = "Valores.Metrica"
+
 type MedicionGormRepository struct {
 	db *gorm.DB
 }
@@ -16,17 +21,31 @@ func NewMedicionGormRepository(db *gorm.DB) repositories.MedicionRepository {
 	return &MedicionGormRepository{db}
 }
 
-func (r *MedicionGormRepository) GetAll() ([]models.Medicion, error) {
-	var mediciones []models.Medicion
-	if err := r.db.Find(&mediciones).Error; err != nil {
+// measurementList defines the fields measurements can be sorted and filtered by; the newest come first
+var measurementList = listQuery{
+	fields: listFields{
+		"id":             {"id", true},
+		"usuario_id":     {"usuario_id", true},
+		"fecha":          {"fecha", true},
+		"peso_corporal":  {"peso_corporal", true},
+		"grasa_corporal": {"grasa_corporal", true},
+		"musculo":        {"musculo", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "fecha", Desc: true}},
+	preloads:    []string{preloadValores},
+}
+
+func (r *MedicionGormRepository) GetAll(spec repositories.QuerySpec) (*repositories.Page[models.Medicion], error) {
+	page, err := list[models.Medicion](r.db, spec, measurementList)
+	if err != nil {
 		return nil, errors.Wrap(err, "MedicionGormRepository.GetAll")
 	}
-	return mediciones, nil
+	return page, nil
 }
 
 func (r *MedicionGormRepository) GetByID(id uint) (*models.Medicion, error) {
 	var medicion models.Medicion
-	if err := r.db.First(&medicion, id).Error; err != nil {
+	if err := r.db.Preload(preloadValores).First(&medicion, id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domainErrors.ErrNotFound
 		}
@@ -43,14 +62,66 @@ func (r *MedicionGormRepository) Create(medicion *models.Medicion) error {
 }
 
 func (r *MedicionGormRepository) Update(medicion *models.Medicion) error {
-	if err := r.db.Save(medicion).Error; err != nil {
+	// The values are replaced as a whole so removed ones do not linger
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("medicion_id = ?", medicion.ID).Delete(&models.ValorMedicion{}).Error; err != nil {
+			return err
+		}
+		for i := range medicion.Valores {
+			medicion.Valores[i].ID = 0
+			medicion.Valores[i].MedicionID = medicion.ID
+		}
+		return tx.Save(medicion).Error
+	})
+	if err != nil {
 		return errors.Wrapf(err, "MedicionGormRepository.Update: id %d", medicion.ID)
 	}
 	return nil
 }
 
+func (r *MedicionGormRepository) Patch(medicion *models.Medicion, columns []string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var fields []string
+		for _, column := range columns {
+			if column != "valores" {
+				fields = append(fields, column)
+				continue
+			}
+			if err := tx.Where("medicion_id = ?", medicion.ID).Delete(&models.ValorMedicion{}).Error; err != nil {
+				return err
+			}
+			for i := range medicion.Valores {
+				medicion.Valores[i].ID = 0
+				medicion.Valores[i].MedicionID = medicion.ID
+			}
+			if len(medicion.Valores) > 0 {
+				if err := tx.Create(&medicion.Valores).Error; err != nil {
+					return err
+				}
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		return tx.Model(medicion).Select(fields).Updates(medicion).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "MedicionGormRepository.Patch: id %d", medicion.ID)
+	}
+	return nil
+}
+
 func (r *MedicionGormRepository) Delete(id uint) error {
-	if err := r.db.Delete(&models.Medicion{}, id).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("medicion_id = ?", id).Delete(&models.ValorMedicion{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("medicion_id = ?", id).Delete(&models.FotoProgreso{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Medicion{}, id).Error
+	})
+	if err != nil {
 		return errors.Wrapf(err, "MedicionGormRepository.Delete: id %d", id)
 	}
 	return nil
@@ -58,8 +129,26 @@ func (r *MedicionGormRepository) Delete(id uint) error {
 
 func (r *MedicionGormRepository) GetMesurementsByUserID(usuarioID uint) ([]models.Medicion, error) {
 	var mediciones []models.Medicion
-	if err := r.db.Where("usuario_id = ?", usuarioID).Find(&mediciones).Error; err != nil {
+	if err := r.db.Preload(preloadValores).Where("usuario_id = ?", usuarioID).Find(&mediciones).Error; err != nil {
 		return nil, errors.Wrapf(err, "MedicionGormRepository.GetMesurementsByUserID: usuarioID %d", usuarioID)
 	}
 	return mediciones, nil
 }
+
+func (r *MedicionGormRepository) GetPageByUser(usuarioID uint, spec repositories.QuerySpec) (*repositories.Page[models.Medicion], error) {
+	page, err := list[models.Medicion](r.db.Where("mediciones.usuario_id = ?", usuarioID), spec, measurementList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "MedicionGormRepository.GetPageByUser: usuarioID %d", usuarioID)
+	}
+	return page, nil
+}
+
+func (r *MedicionGormRepository) GetByUserAndRange(usuarioID uint, from, to time.Time) ([]models.Medicion, error) {
+	var mediciones []models.Medicion
+	if err := r.db.Preload(preloadValores).Where("usuario_id = ? AND fecha >= ? AND fecha < ?", usuarioID, from, to).
+		Order("fecha ASC, id ASC").
+		Find(&mediciones).Error; err != nil {
+		return nil, errors.Wrapf(err, "MedicionGormRepository.GetByUserAndRange: usuarioID %d", usuarioID)
+	}
+	return mediciones, nil
+}
@@ -0,0 +1,70 @@
+package persistence
+
+import (
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type GoalGormRepository struct {
+	db *gorm.DB
+}
+
+func NewGoalGormRepository(db *gorm.DB) repositories.GoalRepository {
+	return &GoalGormRepository{db}
+}
+
+func (r *GoalGormRepository) Create(meta *models.Meta) error {
+	if err := r.db.Create(meta).Error; err != nil {
+		return errors.Wrap(err, "GoalGormRepository.Create")
+	}
+	return nil
+}
+
+func (r *GoalGormRepository) GetByID(id uint) (*models.Meta, error) {
+	var meta models.Meta
+	if err := r.db.First(&meta, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "GoalGormRepository.GetByID: id %d", id)
+	}
+	return &meta, nil
+}
+
+// goalList defines the fields goals can be sorted and filtered by; the newest come first
+var goalList = listQuery{
+	fields: listFields{
+		"id":           {"id", true},
+		"tipo":         {"tipo", true},
+		"ejercicio_id": {"ejercicio_id", false},
+		"objetivo":     {"objetivo", true},
+		"fecha_limite": {"fecha_limite", false},
+		"created_at":   {"created_at", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "created_at", Desc: true}},
+}
+
+func (r *GoalGormRepository) GetByUser(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.Meta], error) {
+	page, err := list[models.Meta](r.db.Where("metas.usuario_id = ?", userID), spec, goalList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GoalGormRepository.GetByUser: userID %d", userID)
+	}
+	return page, nil
+}
+
+func (r *GoalGormRepository) Update(meta *models.Meta) error {
+	if err := r.db.Save(meta).Error; err != nil {
+		return errors.Wrapf(err, "GoalGormRepository.Update: id %d", meta.ID)
+	}
+	return nil
+}
+
+func (r *GoalGormRepository) Delete(id uint) error {
+	if err := r.db.Delete(&models.Meta{}, id).Error; err != nil {
+		return errors.Wrapf(err, "GoalGormRepository.Delete: id %d", id)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MeasurementMetricGormRepository struct {
+	db *gorm.DB
+}
+
+func NewMeasurementMetricGormRepository(db *gorm.DB) repositories.MeasurementMetricRepository {
+	return &MeasurementMetricGormRepository{db}
+}
+
+// metricList defines the fields measurement metrics can be sorted and filtered by; the oldest come first
+var metricList = listQuery{
+	fields: listFields{
+		"id":     {"id", true},
+		"clave":  {"clave", true},
+		"nombre": {"nombre", true},
+		"unidad": {"unidad", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "id"}},
+}
+
+func (r *MeasurementMetricGormRepository) GetAvailable(userID uint) ([]models.MetricaMedicion, error) {
+	var metricas []models.MetricaMedicion
+	if err := r.db.Where("usuario_id IS NULL OR usuario_id = ?", userID).
+		Order("usuario_id NULLS FIRST, id ASC").
+		Find(&metricas).Error; err != nil {
+		return nil, errors.Wrapf(err, "MeasurementMetricGormRepository.GetAvailable: userID %d", userID)
+	}
+	return metricas, nil
+}
+
+func (r *MeasurementMetricGormRepository) GetPageAvailable(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.MetricaMedicion], error) {
+	query := r.db.Where("(metricas_medicion.usuario_id IS NULL OR metricas_medicion.usuario_id = ?)", userID)
+	page, err := list[models.MetricaMedicion](query, spec, metricList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "MeasurementMetricGormRepository.GetPageAvailable: userID %d", userID)
+	}
+	return page, nil
+}
+
+func (r *MeasurementMetricGormRepository) GetStandard() ([]models.MetricaMedicion, error) {
+	var metricas []models.MetricaMedicion
+	if err := r.db.Where("usuario_id IS NULL").Order("id ASC").Find(&metricas).Error; err != nil {
+		return nil, errors.Wrap(err, "MeasurementMetricGormRepository.GetStandard")
+	}
+	return metricas, nil
+}
+
+func (r *MeasurementMetricGormRepository) GetByID(id uint) (*models.MetricaMedicion, error) {
+	var metrica models.MetricaMedicion
+	if err := r.db.First(&metrica, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "MeasurementMetricGormRepository.GetByID: id %d", id)
+	}
+	return &metrica, nil
+}
+
+func (r *MeasurementMetricGormRepository) GetByKey(userID uint, clave string) (*models.MetricaMedicion, error) {
+	var metrica models.MetricaMedicion
+	// The standard metric wins over a custom one with the same key
+	if err := r.db.Where("clave = ? AND (usuario_id IS NULL OR usuario_id = ?)", clave, userID).
+		Order("usuario_id NULLS FIRST").
+		First(&metrica).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "MeasurementMetricGormRepository.GetByKey: clave %s", clave)
+	}
+	return &metrica, nil
+}
+
+func (r *MeasurementMetricGormRepository) Create(metrica *models.MetricaMedicion) error {
+	if err := r.db.Create(metrica).Error; err != nil {
+		return errors.Wrap(err, "MeasurementMetricGormRepository.Create")
+	}
+	return nil
+}
+
+func (r *MeasurementMetricGormRepository) Delete(id uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("metrica_id = ?", id).Delete(&models.ValorMedicion{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.MetricaMedicion{}, id).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "MeasurementMetricGormRepository.Delete: id %d", id)
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package persistence
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// listField is a field of a resource that can be used in a query spec
+type listField struct {
+	column   string
+	sortable bool // only non-null columns can be sorted, since the cursor compares their values
+}
+
+// listFields maps the public field names of a resource to its columns; other fields are rejected
+type listFields map[string]listField
+
+// listQuery configures how a resource is listed
+type listQuery struct {
+	fields      listFields
+	defaultSort []repositories.SortField // applied when the spec has no sort; id is always the last tiebreaker
+	preloads    []string
+	projection  repositories.ProjectionSchema // fields and includes the spec can request; empty allows none
+}
+
+// list reads a page of T from the query, applying the filters, sort and cursor of the spec. Pages are
+// keyset-paginated: the cursor holds the sort values of the last item, so pages stay consistent while
+// rows are inserted and do not get slower as the client moves forward.
+func list[T any](query *gorm.DB, spec repositories.QuerySpec, lq listQuery) (*repositories.Page[T], error) {
+	sch, err := parseSchema[T](query)
+	if err != nil {
+		return nil, errors.Wrap(err, "list")
+	}
+	query = query.Model(reflect.New(sch.ModelType).Interface())
+
+	for _, f := range spec.Filters {
+		var err error
+		if query, err = applyFilter(query, sch, lq.fields, f); err != nil {
+			return nil, err
+		}
+	}
+
+	order, err := sortColumns(sch, lq, spec.Sort)
+	if err != nil {
+		return nil, err
+	}
+	var after string
+	var afterValues []interface{}
+	if spec.Cursor != "" {
+		if after, afterValues, err = cursorCondition(order, spec.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	// The total ignores the cursor: it counts every item matching the filters
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, errors.Wrap(err, "list: count")
+	}
+
+	page := query.Session(&gorm.Session{})
+	if after != "" {
+		page = page.Where(after, afterValues...)
+	}
+	for _, preload := range lq.preloads {
+		page = page.Preload(preload)
+	}
+	for _, o := range order {
+		page = page.Order(o.String())
+	}
+	if page, err = project(page, sch, lq.projection, spec.Projection, order); err != nil {
+		return nil, err
+	}
+
+	limit := spec.PageLimit()
+	var items []T
+	if err := page.Limit(limit + 1).Find(&items).Error; err != nil {
+		return nil, errors.Wrap(err, "list: find")
+	}
+
+	result := &repositories.Page[T]{Items: items, Total: total}
+	if len(items) > limit {
+		result.Items = items[:limit]
+		if result.NextCursor, err = encodeCursor(order, result.Items[limit-1]); err != nil {
+			return nil, err
+		}
+	}
+	if result.Items == nil {
+		result.Items = []T{}
+	}
+	return result, nil
+}
+
+// first reads the item with the primary key, applying the fields and includes of the projection
+func first[T any](query *gorm.DB, id uint, ps repositories.ProjectionSchema, proj repositories.Projection) (*T, error) {
+	sch, err := parseSchema[T](query)
+	if err != nil {
+		return nil, errors.Wrap(err, "first")
+	}
+	if query, err = project(query, sch, ps, proj, nil); err != nil {
+		return nil, err
+	}
+	item := new(T)
+	if err := query.Where(sch.Table+"."+sch.PrioritizedPrimaryField.DBName+" = ?", id).First(item).Error; err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// project preloads the relations of the projection and selects its columns. The primary key, the required
+// columns, the sort columns and the foreign keys of the included relations are always read.
+func project(query *gorm.DB, sch *schema.Schema, ps repositories.ProjectionSchema, proj repositories.Projection, order []sortColumn) (*gorm.DB, error) {
+	if err := ps.Validate(proj); err != nil {
+		return nil, err
+	}
+
+	var columns []*schema.Field
+	for _, include := range proj.Include {
+		path := ps.Includes[include]
+		query = query.Preload(path)
+
+		rel, ok := sch.Relationships.Relations[strings.Split(path, ".")[0]]
+		if !ok {
+			return nil, errors.Errorf("project: unknown relation %s", path)
+		}
+		for _, ref := range rel.References {
+			if ref.ForeignKey.Schema == sch {
+				columns = append(columns, ref.ForeignKey)
+			}
+		}
+	}
+	if len(proj.Fields) == 0 {
+		return query, nil
+	}
+
+	columns = append(columns, sch.PrioritizedPrimaryField)
+	for _, o := range order {
+		columns = append(columns, o.field)
+	}
+	for _, name := range append(append([]string{}, ps.Required...), proj.Fields...) {
+		field := sch.LookUpField(name)
+		if field == nil || field.DBName == "" {
+			return nil, errors.Errorf("project: unknown column %s", name)
+		}
+		columns = append(columns, field)
+	}
+
+	var selects []string
+	for _, field := range columns {
+		column := sch.Table + "." + field.DBName
+		if !slices.Contains(selects, column) {
+			selects = append(selects, column)
+		}
+	}
+	return query.Select(selects), nil
+}
+
+// parseSchema parses the GORM schema of T
+func parseSchema[T any](query *gorm.DB) (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: query}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, errors.Wrap(err, "parse schema")
+	}
+	return stmt.Schema, nil
+}
+
+// sortColumn is a resolved sort field
+type sortColumn struct {
+	field *schema.Field
+	desc  bool
+}
+
+func (s sortColumn) String() string {
+	column := s.field.Schema.Table + "." + s.field.DBName
+	if s.desc {
+		return column + " DESC"
+	}
+	return column + " ASC"
+}
+
+// sortColumns resolves the sort of the spec, appending the primary key so the order is total
+func sortColumns(sch *schema.Schema, lq listQuery, sort []repositories.SortField) ([]sortColumn, error) {
+	if len(sort) == 0 {
+		sort = lq.defaultSort
+	}
+
+	var order []sortColumn
+	hasID := false
+	for _, s := range sort {
+		lf, ok := lq.fields[s.Field]
+		if !ok || !lf.sortable {
+			return nil, &repositories.InvalidQueryError{Param: "sort", Message: fmt.Sprintf("cannot sort by '%s'", s.Field)}
+		}
+		field := sch.LookUpField(lf.column)
+		if field == nil {
+			return nil, errors.Errorf("list: unknown column %s", lf.column)
+		}
+		order = append(order, sortColumn{field, s.Desc})
+		hasID = hasID || field == sch.PrioritizedPrimaryField
+	}
+	if !hasID {
+		order = append(order, sortColumn{field: sch.PrioritizedPrimaryField})
+	}
+	return order, nil
+}
+
+// applyFilter adds the condition of a filter, converting its value to the type of the column
+func applyFilter(query *gorm.DB, sch *schema.Schema, fields listFields, f repositories.Filter) (*gorm.DB, error) {
+	lf, ok := fields[f.Field]
+	if !ok {
+		return nil, &repositories.InvalidQueryError{Param: "filter", Message: fmt.Sprintf("cannot filter by '%s'", f.Field)}
+	}
+	field := sch.LookUpField(lf.column)
+	if field == nil {
+		return nil, errors.Errorf("list: unknown column %s", lf.column)
+	}
+	column := sch.Table + "." + field.DBName
+
+	if f.Op == repositories.FilterIn {
+		var values []interface{}
+		for _, raw := range strings.Split(f.Value, ",") {
+			value, err := filterValue(field, f.Field, strings.TrimSpace(raw))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return query.Where(column+" IN ?", values), nil
+	}
+
+	if f.Op == repositories.FilterLike {
+		if indirectType(field.FieldType).Kind() != reflect.String {
+			return nil, &repositories.InvalidQueryError{Param: "filter", Message: fmt.Sprintf("'%s' does not support like", f.Field)}
+		}
+		return query.Where(column+" ILIKE ?", containsPattern(f.Value)), nil
+	}
+
+	operators := map[string]string{
+		repositories.FilterEq:  "=",
+		repositories.FilterNe:  "<>",
+		repositories.FilterGt:  ">",
+		repositories.FilterGte: ">=",
+		repositories.FilterLt:  "<",
+		repositories.FilterLte: "<=",
+	}
+	operator, ok := operators[f.Op]
+	if !ok {
+		return nil, &repositories.InvalidQueryError{Param: "filter", Message: fmt.Sprintf("unknown operator '%s'", f.Op)}
+	}
+	value, err := filterValue(field, f.Field, f.Value)
+	if err != nil {
+		return nil, err
+	}
+	return query.Where(column+" "+operator+" ?", value), nil
+}
+
+// likeEscaper escapes the wildcards of LIKE patterns, so user input only matches literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// containsPattern returns the LIKE pattern matching the text anywhere in a value
+func containsPattern(text string) string {
+	return "%" + likeEscaper.Replace(text) + "%"
+}
+
+// filterValue parses the text of a filter as the type of the field
+func filterValue(field *schema.Field, name, raw string) (interface{}, error) {
+	invalid := func(kind string) error {
+		return &repositories.InvalidQueryError{Param: "filter", Message: fmt.Sprintf("'%s' must be %s", name, kind)}
+	}
+
+	t := indirectType(field.FieldType)
+	if t == reflect.TypeOf(time.Time{}) {
+		for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+			if value, err := time.Parse(layout, raw); err == nil {
+				return value, nil
+			}
+		}
+		return nil, invalid("a date (YYYY-MM-DD) or RFC 3339 timestamp")
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return raw, nil
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalid("true or false")
+		}
+		return value, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, invalid("an integer")
+		}
+		return value, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, invalid("a positive integer")
+		}
+		return value, nil
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, invalid("a number")
+		}
+		return value, nil
+	default:
+		return nil, &repositories.InvalidQueryError{Param: "filter", Message: fmt.Sprintf("cannot filter by '%s'", name)}
+	}
+}
+
+// cursorCondition builds the keyset condition selecting the rows after the cursor:
+// (a > x) OR (a = x AND b > y) OR ...
+func cursorCondition(order []sortColumn, cursor string) (string, []interface{}, error) {
+	invalid := &repositories.InvalidQueryError{Param: "cursor", Message: "the cursor is malformed or belongs to another sort order"}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", nil, invalid
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil || len(raw) != len(order) {
+		return "", nil, invalid
+	}
+
+	values := make([]interface{}, len(order))
+	for i, o := range order {
+		value := reflect.New(indirectType(o.field.FieldType))
+		if err := json.Unmarshal(raw[i], value.Interface()); err != nil {
+			return "", nil, invalid
+		}
+		values[i] = value.Elem().Interface()
+	}
+
+	var clauses []string
+	var args []interface{}
+	for i, o := range order {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, order[j].field.Schema.Table+"."+order[j].field.DBName+" = ?")
+			args = append(args, values[j])
+		}
+		operator := ">"
+		if o.desc {
+			operator = "<"
+		}
+		parts = append(parts, o.field.Schema.Table+"."+o.field.DBName+" "+operator+" ?")
+		args = append(args, values[i])
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args, nil
+}
+
+// encodeCursor stores the sort values of the item in an opaque cursor
+func encodeCursor(order []sortColumn, item interface{}) (string, error) {
+	value := reflect.Indirect(reflect.ValueOf(item))
+	values := make([]interface{}, len(order))
+	for i, o := range order {
+		values[i], _ = o.field.ValueOf(context.Background(), value)
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", errors.Wrap(err, "list: encode cursor")
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
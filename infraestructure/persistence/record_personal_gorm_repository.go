@@ -0,0 +1,75 @@
+package persistence
+
+import (
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// recordKey groups max_reps records by weight; every other type has a single standing record per exercise
+const recordKey = "ejercicio_id, tipo, CASE WHEN tipo = '" + models.RecordRepeticiones + "' THEN peso ELSE 0 END"
+
+type PersonalRecordGormRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalRecordGormRepository(db *gorm.DB) repositories.PersonalRecordRepository {
+	return &PersonalRecordGormRepository{db}
+}
+
+func (r *PersonalRecordGormRepository) ReplaceForExercise(userID, exerciseID uint, records []models.RecordPersonal) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("usuario_id = ? AND ejercicio_id = ?", userID, exerciseID).Delete(&models.RecordPersonal{}).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		return tx.Create(&records).Error
+	})
+	if err != nil {
+		return errors.Wrapf(err, "PersonalRecordGormRepository.ReplaceForExercise: userID %d, exerciseID %d", userID, exerciseID)
+	}
+	return nil
+}
+
+// recordList defines the fields personal records can be sorted and filtered by
+var recordList = listQuery{
+	fields: listFields{
+		"id":           {"id", true},
+		"ejercicio_id": {"ejercicio_id", true},
+		"tipo":         {"tipo", true},
+		"valor":        {"valor", true},
+		"peso":         {"peso", true},
+		"repeticiones": {"repeticiones", true},
+		"sesion_id":    {"sesion_id", true},
+		"fecha":        {"fecha", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "fecha"}},
+}
+
+func (r *PersonalRecordGormRepository) GetCurrent(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.RecordPersonal], error) {
+	latest := r.db.Model(&models.RecordPersonal{}).
+		Select("DISTINCT ON ("+recordKey+") *").
+		Where("usuario_id = ?", userID).
+		Order(recordKey + ", fecha DESC, id DESC")
+
+	// The subquery takes the name of the table so the columns of the list resolve against it
+	current := recordList
+	current.defaultSort = []repositories.SortField{{Field: "ejercicio_id"}, {Field: "tipo"}, {Field: "peso", Desc: true}}
+	page, err := list[models.RecordPersonal](r.db.Table("(?) AS records_personales", latest), spec, current)
+	if err != nil {
+		return nil, errors.Wrapf(err, "PersonalRecordGormRepository.GetCurrent: userID %d", userID)
+	}
+	return page, nil
+}
+
+func (r *PersonalRecordGormRepository) GetHistory(userID, exerciseID uint, spec repositories.QuerySpec) (*repositories.Page[models.RecordPersonal], error) {
+	query := r.db.Where("records_personales.usuario_id = ? AND records_personales.ejercicio_id = ?", userID, exerciseID)
+	page, err := list[models.RecordPersonal](query, spec, recordList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "PersonalRecordGormRepository.GetHistory: userID %d, exerciseID %d", userID, exerciseID)
+	}
+	return page, nil
+}
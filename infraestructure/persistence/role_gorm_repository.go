@@ -54,22 +54,36 @@ func (r *RoleGormRepository) GetByName(ctx context.Context, name string) (*model
 	return &role, nil
 }
 
-// GetAll retrieves all active roles
-func (r *RoleGormRepository) GetAll(ctx context.Context) ([]models.Role, error) {
-	var roles []models.Role
-	if err := r.db.WithContext(ctx).Where("is_deleted = ?", false).Find(&roles).Error; err != nil {
+// roleList defines the fields roles can be sorted and filtered by; the highest priority comes first
+var roleList = listQuery{
+	fields: listFields{
+		"id":         {"id", true},
+		"name":       {"name", true},
+		"priority":   {"priority", true},
+		"is_active":  {"is_active", true},
+		"is_system":  {"is_system", true},
+		"is_deleted": {"is_deleted", true},
+		"created_at": {"created_at", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "priority", Desc: true}},
+}
+
+// GetAll retrieves a page of active roles
+func (r *RoleGormRepository) GetAll(ctx context.Context, spec repositories.QuerySpec) (*repositories.Page[models.Role], error) {
+	page, err := list[models.Role](r.db.WithContext(ctx).Where("is_deleted = ?", false), spec, roleList)
+	if err != nil {
 		return nil, errors.Wrap(err, "RoleGormRepository.GetAll")
 	}
-	return roles, nil
+	return page, nil
 }
 
-// GetAllWithDeleted retrieves all roles including deleted ones
-func (r *RoleGormRepository) GetAllWithDeleted(ctx context.Context) ([]models.Role, error) {
-	var roles []models.Role
-	if err := r.db.WithContext(ctx).Find(&roles).Error; err != nil {
+// GetAllWithDeleted retrieves a page of roles including deleted ones
+func (r *RoleGormRepository) GetAllWithDeleted(ctx context.Context, spec repositories.QuerySpec) (*repositories.Page[models.Role], error) {
+	page, err := list[models.Role](r.db.WithContext(ctx), spec, roleList)
+	if err != nil {
 		return nil, errors.Wrap(err, "RoleGormRepository.GetAllWithDeleted")
 	}
-	return roles, nil
+	return page, nil
 }
 
 // Update updates an existing role
@@ -18,17 +18,30 @@ func NewRutinaGrupoMuscularGormRepository(db *gorm.DB) repositories.RutinaGrupoM
 
 func (r *RutinaGrupoMuscularGormRepository) Create(rutinaGM *models.RutinaGrupoMuscular) error {
 	if err := r.db.Create(rutinaGM).Error; err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrInvalidReference
+		}
 		return errors.Wrap(err, "RutinaGrupoMuscularGormRepository.Create")
 	}
 	return nil
 }
 
-func (r *RutinaGrupoMuscularGormRepository) GetAll() ([]models.RutinaGrupoMuscular, error) {
-	var rutinasGM []models.RutinaGrupoMuscular
-	if err := r.db.Find(&rutinasGM).Error; err != nil {
+// routineMuscleGroupList defines the fields routine muscle groups can be sorted and filtered by
+var routineMuscleGroupList = listQuery{
+	fields: listFields{
+		"id":                {"id", true},
+		"rutina_id":         {"rutina_id", true},
+		"grupo_muscular_id": {"grupo_muscular_id", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "id"}},
+}
+
+func (r *RutinaGrupoMuscularGormRepository) GetAll(spec repositories.QuerySpec) (*repositories.Page[models.RutinaGrupoMuscular], error) {
+	page, err := list[models.RutinaGrupoMuscular](r.db, spec, routineMuscleGroupList)
+	if err != nil {
 		return nil, errors.Wrap(err, "RutinaGrupoMuscularGormRepository.GetAll")
 	}
-	return rutinasGM, nil
+	return page, nil
 }
 
 func (r *RutinaGrupoMuscularGormRepository) GetByID(id uint) (*models.RutinaGrupoMuscular, error) {
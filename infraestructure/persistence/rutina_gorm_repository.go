@@ -16,12 +16,21 @@ func NewRutinaGormRepository(db *gorm.DB) repositories.RutinaRepository {
 	return &RutinaGormRepository{db}
 }
 
-func (r *RutinaGormRepository) GetAll() ([]models.Rutina, error) {
-	var rutinas []models.Rutina
-	if err := r.db.Find(&rutinas).Error; err != nil {
-		return nil, errors.Wrap(err, "RutinaGormRepository.GetAll")
-	}
-	return rutinas, nil
+// routineList defines the fields routines can be sorted and filtered by; the newest come first
+var routineList = listQuery{
+	fields: listFields{
+		"id":               {"id", true},
+		"nombre":           {"nombre", true},
+		"objetivo":         {"objetivo", false},
+		"fecha_creacion":   {"fecha_creacion", true},
+		"publica":          {"publica", true},
+		"usuario_id":       {"usuario_id", true},
+		"version_actual":   {"version_actual", true},
+		"origen_rutina_id": {"origen_rutina_id", false},
+		"favorite_count":   {"favorite_count", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "fecha_creacion", Desc: true}},
+	projection:  repositories.RoutineProjection,
 }
 
 func (r *RutinaGormRepository) GetByID(id uint) (*models.Rutina, error) {
@@ -35,6 +44,18 @@ func (r *RutinaGormRepository) GetByID(id uint) (*models.Rutina, error) {
 	return &rutina, nil
 }
 
+// GetByIDProjected reads the fields and relations of a routine requested by the projection
+func (r *RutinaGormRepository) GetByIDProjected(id uint, proj repositories.Projection) (*models.Rutina, error) {
+	rutina, err := first[models.Rutina](r.db, id, repositories.RoutineProjection, proj)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "RutinaGormRepository.GetByIDProjected: id %d", id)
+	}
+	return rutina, nil
+}
+
 func (r *RutinaGormRepository) Create(rutina *models.Rutina) error {
 	if err := r.db.Create(rutina).Error; err != nil {
 		return errors.Wrap(err, "RutinaGormRepository.Create")
@@ -49,9 +70,67 @@ func (r *RutinaGormRepository) Update(rutina *models.Rutina) error {
 	return nil
 }
 
+func (r *RutinaGormRepository) Patch(rutina *models.Rutina, columns []string) error {
+	if err := r.db.Model(rutina).Select(columns).Updates(rutina).Error; err != nil {
+		return errors.Wrapf(err, "RutinaGormRepository.Patch: id %d", rutina.ID)
+	}
+	return nil
+}
+
 func (r *RutinaGormRepository) Delete(id uint) error {
 	if err := r.db.Delete(&models.Rutina{}, id).Error; err != nil {
 		return errors.Wrapf(err, "RutinaGormRepository.Delete: id %d", id)
 	}
 	return nil
 }
+
+// CreateWithMuscleGroups creates a routine and its muscle group links in a single transaction
+func (r *RutinaGormRepository) CreateWithMuscleGroups(rutina *models.Rutina, grupoMuscularIDs []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rutina).Error; err != nil {
+			return err
+		}
+		for _, grupoID := range grupoMuscularIDs {
+			link := models.RutinaGrupoMuscular{RutinaID: rutina.ID, GrupoMuscularID: grupoID}
+			if err := tx.Create(&link).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "RutinaGormRepository.CreateWithMuscleGroups")
+	}
+	return nil
+}
+
+func (r *RutinaGormRepository) GetByUserID(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.Rutina], error) {
+	page, err := list[models.Rutina](r.db.Where("usuario_id = ?", userID), spec, routineList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "RutinaGormRepository.GetByUserID: userID %d", userID)
+	}
+	return page, nil
+}
+
+// SearchPublic reads a page of the public routine catalog matching the full-text search and the filters
+func (r *RutinaGormRepository) SearchPublic(filter repositories.PublicRoutineFilter, spec repositories.QuerySpec) (*repositories.Page[models.Rutina], error) {
+	query := r.db.Where("rutinas.publica = ?", true)
+	if filter.Search != "" {
+		query = query.Where(rutinaSearchVector+" @@ plainto_tsquery('spanish', ?)", filter.Search)
+	}
+	if filter.GrupoMuscularID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM rutina_grupo_muscular rgm WHERE rgm.rutina_id = rutinas.id AND rgm.grupo_muscular_id = ?)", filter.GrupoMuscularID)
+	}
+	if filter.UsuarioID != 0 {
+		query = query.Where("rutinas.usuario_id = ?", filter.UsuarioID)
+	}
+
+	page, err := list[models.Rutina](query, spec, routineList)
+	if err != nil {
+		return nil, errors.Wrap(err, "RutinaGormRepository.SearchPublic")
+	}
+	return page, nil
+}
+
+// rutinaSearchVector is the full-text document used to search routines; it matches idx_rutinas_busqueda
+const rutinaSearchVector = "to_tsvector('spanish', coalesce(rutinas.nombre, '') || ' ' || coalesce(rutinas.objetivo, ''))"
@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type RutinaVersionGormRepository struct {
+	db *gorm.DB
+}
+
+func NewRutinaVersionGormRepository(db *gorm.DB) repositories.RutinaVersionRepository {
+	return &RutinaVersionGormRepository{db}
+}
+
+func (r *RutinaVersionGormRepository) Create(version *models.RutinaVersion) error {
+	if err := r.db.Create(version).Error; err != nil {
+		return errors.Wrap(err, "RutinaVersionGormRepository.Create")
+	}
+	return nil
+}
+
+func (r *RutinaVersionGormRepository) GetByID(id uint) (*models.RutinaVersion, error) {
+	var version models.RutinaVersion
+	if err := r.db.First(&version, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "RutinaVersionGormRepository.GetByID: id %d", id)
+	}
+	return &version, nil
+}
+
+func (r *RutinaVersionGormRepository) GetLatest(rutinaID uint) (*models.RutinaVersion, error) {
+	var version models.RutinaVersion
+	if err := r.db.Where("rutina_id = ?", rutinaID).Order("version DESC").First(&version).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "RutinaVersionGormRepository.GetLatest: rutinaID %d", rutinaID)
+	}
+	return &version, nil
+}
+
+func (r *RutinaVersionGormRepository) GetByRutinaID(rutinaID uint) ([]models.RutinaVersion, error) {
+	var versions []models.RutinaVersion
+	if err := r.db.Where("rutina_id = ?", rutinaID).Order("version ASC").Find(&versions).Error; err != nil {
+		return nil, errors.Wrapf(err, "RutinaVersionGormRepository.GetByRutinaID: rutinaID %d", rutinaID)
+	}
+	return versions, nil
+}
+
+func (r *RutinaVersionGormRepository) GetByVersion(rutinaID uint, version int) (*models.RutinaVersion, error) {
+	var v models.RutinaVersion
+	if err := r.db.Where("rutina_id = ? AND version = ?", rutinaID, version).First(&v).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "RutinaVersionGormRepository.GetByVersion: rutinaID %d, version %d", rutinaID, version)
+	}
+	return &v, nil
+}
@@ -25,12 +25,29 @@ func (r *SessionExerciseGormRepository) Create(sesionEjercicio *models.SesionEje
 	return nil
 }
 
-func (r *SessionExerciseGormRepository) GetAll() ([]*models.SesionEjercicio, error) {
-	var sesionesEjercicios []*models.SesionEjercicio
-	if err := r.db.Find(&sesionesEjercicios).Error; err != nil {
+// sessionExerciseList defines the fields session exercises can be sorted and filtered by; weights are in kg
+var sessionExerciseList = listQuery{
+	fields: listFields{
+		"id":                    {"id", true},
+		"sesion_id":             {"sesion_id", true},
+		"ejercicio_id":          {"ejercicio_id", true},
+		"fecha":                 {"fecha", true},
+		"series":                {"series", true},
+		"repeticiones":          {"repeticiones", true},
+		"orden":                 {"orden", true},
+		"peso":                  {"peso", true},
+		"observacion":           {"observacion", false},
+		"ejercicio_original_id": {"ejercicio_original_id", false},
+	},
+	defaultSort: []repositories.SortField{{Field: "orden"}},
+}
+
+func (r *SessionExerciseGormRepository) GetAll(spec repositories.QuerySpec) (*repositories.Page[*models.SesionEjercicio], error) {
+	page, err := list[*models.SesionEjercicio](r.db, spec, sessionExerciseList)
+	if err != nil {
 		return nil, errors.Wrap(err, "SessionExerciseGormRepository.GetAll")
 	}
-	return sesionesEjercicios, nil
+	return page, nil
 }
 
 func (r *SessionExerciseGormRepository) GetById(id uint) (*models.SesionEjercicio, error) {
@@ -51,6 +68,13 @@ func (r *SessionExerciseGormRepository) Update(sesionEjercicio *models.SesionEje
 	return nil
 }
 
+func (r *SessionExerciseGormRepository) Patch(sesionEjercicio *models.SesionEjercicio, columns []string) error {
+	if err := r.db.Model(sesionEjercicio).Select(columns).Updates(sesionEjercicio).Error; err != nil {
+		return errors.Wrapf(err, "SessionExerciseGormRepository.Patch: id %d", sesionEjercicio.ID)
+	}
+	return nil
+}
+
 func (r *SessionExerciseGormRepository) Delete(id uint) error {
 	if err := r.db.Delete(&models.SesionEjercicio{}, id).Error; err != nil {
 		return errors.Wrapf(err, "SessionExerciseGormRepository.Delete: id %d", id)
@@ -58,13 +82,78 @@ func (r *SessionExerciseGormRepository) Delete(id uint) error {
 	return nil
 }
 
-func (r *SessionExerciseGormRepository) GetBySessionID(sessionID uint, fechaDesde, fechaHasta time.Time) ([]*models.SesionEjercicio, error) {
+// GetBySessionID returns a page of the entries of a session; zero dates leave that end of the range open
+func (r *SessionExerciseGormRepository) GetBySessionID(sessionID uint, fechaDesde, fechaHasta time.Time, spec repositories.QuerySpec) (*repositories.Page[*models.SesionEjercicio], error) {
+	query := r.db.
+		Joins("JOIN sesiones ON sesion_ejercicios.sesion_id = sesiones.id").
+		Where("sesion_ejercicios.sesion_id = ?", sessionID)
+	if !fechaDesde.IsZero() {
+		query = query.Where("sesiones.fecha >= ?", fechaDesde)
+	}
+	if !fechaHasta.IsZero() {
+		query = query.Where("sesiones.fecha <= ?", fechaHasta)
+	}
+
+	page, err := list[*models.SesionEjercicio](query, spec, sessionExerciseList)
+	if err != nil {
+		return nil, errors.Wrapf(err, "SessionExerciseGormRepository.GetBySessionID: sessionID %d, fechaDesde %v, fechaHasta %v", sessionID, fechaDesde, fechaHasta)
+	}
+	return page, nil
+}
+
+// GetByUserAndExercise returns the entries of an exercise across the user's sessions in chronological order.
+// Entries are ordered by the date of their session, since older entries may have no date of their own.
+func (r *SessionExerciseGormRepository) GetByUserAndExercise(userID, exerciseID uint) ([]*models.SesionEjercicio, error) {
 	var sesionesEjercicios []*models.SesionEjercicio
-	if err := r.db.
-		Joins("JOIN sesiones ON sesion_ejercicio.sesion_id = sesiones.id").
-		Where("sesion_ejercicio.sesion_id = ? AND sesiones.fecha BETWEEN ? AND ?", sessionID, fechaDesde, fechaHasta).
+	if err := r.db.Joins("JOIN sesiones ON sesiones.id = sesion_ejercicios.sesion_id").
+		Where("sesiones.usuario_id = ? AND sesion_ejercicios.ejercicio_id = ?", userID, exerciseID).
+		Order("sesiones.fecha ASC, sesion_ejercicios.orden ASC, sesion_ejercicios.id ASC").
 		Find(&sesionesEjercicios).Error; err != nil {
-		return nil, errors.Wrapf(err, "SessionExerciseGormRepository.GetBySessionID: sessionID %d, fechaDesde %v, fechaHasta %v", sessionID, fechaDesde, fechaHasta)
+		return nil, errors.Wrapf(err, "SessionExerciseGormRepository.GetByUserAndExercise: userID %d, exerciseID %d", userID, exerciseID)
+	}
+	return sesionesEjercicios, nil
+}
+
+// GetTrainedByUserAndExercise returns the entries of an exercise across the sessions the user trained, as
+// defined by trainedSessions, in chronological order
+func (r *SessionExerciseGormRepository) GetTrainedByUserAndExercise(userID, exerciseID uint) ([]*models.SesionEjercicio, error) {
+	var sesionesEjercicios []*models.SesionEjercicio
+	if err := r.db.Joins("JOIN sesiones ON sesiones.id = sesion_ejercicios.sesion_id").
+		Where("sesiones.id IN (SELECT id FROM sesiones WHERE "+trainedSessions+")", userID).
+		Where("sesion_ejercicios.ejercicio_id = ?", exerciseID).
+		Order("sesiones.fecha ASC, sesion_ejercicios.orden ASC, sesion_ejercicios.id ASC").
+		Find(&sesionesEjercicios).Error; err != nil {
+		return nil, errors.Wrapf(err, "SessionExerciseGormRepository.GetTrainedByUserAndExercise: userID %d, exerciseID %d", userID, exerciseID)
 	}
 	return sesionesEjercicios, nil
 }
+
+// GetMuscleGroupLoad aggregates the tonnage and working sets per muscle group of the sessions the user
+// trained in [from, to), as defined by trainedSessions. Every set with at least one rep is a working set, since the effort of a set is not recorded.
+// Exercises without muscle group links fall back to their main muscle group.
+func (r *SessionExerciseGormRepository) GetMuscleGroupLoad(userID uint, from, to time.Time) ([]models.CargaGrupoMuscular, error) {
+	var cargas []models.CargaGrupoMuscular
+	err := r.db.Raw(`
+		SELECT g.grupo_muscular_id,
+			ROUND(SUM(GREATEST(se.series, 1) * se.repeticiones * se.peso * g.factor)::numeric, 2) AS tonelaje,
+			ROUND(SUM(GREATEST(se.series, 1) * g.factor)::numeric, 2) AS series
+		FROM sesion_ejercicios se
+		JOIN sesiones s ON s.id = se.sesion_id
+		JOIN (
+			SELECT ejercicio_id, grupo_muscular_id, CASE WHEN rol = ? THEN 1.0 ELSE 0.5 END AS factor
+			FROM ejercicio_grupo_muscular
+			UNION ALL
+			SELECT e.id, e.grupo_muscular_id, 1.0
+			FROM ejercicios e
+			WHERE e.grupo_muscular_id <> 0
+				AND NOT EXISTS (SELECT 1 FROM ejercicio_grupo_muscular egm WHERE egm.ejercicio_id = e.id)
+		) g ON g.ejercicio_id = se.ejercicio_id
+		WHERE s.id IN (SELECT id FROM sesiones WHERE `+trainedSessions+`)
+			AND s.fecha >= ? AND s.fecha < ? AND se.repeticiones > 0
+		GROUP BY g.grupo_muscular_id`,
+		models.RolGrupoPrimario, userID, from, to).Scan(&cargas).Error
+	if err != nil {
+		return nil, errors.Wrapf(err, "SessionExerciseGormRepository.GetMuscleGroupLoad: userID %d", userID)
+	}
+	return cargas, nil
+}
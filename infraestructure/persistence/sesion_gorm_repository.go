@@ -25,12 +25,27 @@ func (r *SessionGormRepository) Create(sesion *models.Sesion) error {
 	return nil
 }
 
-func (r *SessionGormRepository) GetAll() ([]*models.Sesion, error) {
-	var sesiones []*models.Sesion
-	if err := r.db.Find(&sesiones).Error; err != nil {
+// sessionList defines the fields sessions can be sorted and filtered by; the newest sessions come first
+var sessionList = listQuery{
+	fields: listFields{
+		"id":           {"id", true},
+		"usuario_id":   {"usuario_id", true},
+		"fecha":        {"fecha", true},
+		"duracion_min": {"duracion_min", true},
+		"estado":       {"estado", true},
+		"rutina_id":    {"rutina_id", false},
+		"comentarios":  {"comentarios", false},
+	},
+	defaultSort: []repositories.SortField{{Field: "fecha", Desc: true}},
+	projection:  repositories.SessionProjection,
+}
+
+func (r *SessionGormRepository) GetAll(spec repositories.QuerySpec) (*repositories.Page[*models.Sesion], error) {
+	page, err := list[*models.Sesion](r.db, spec, sessionList)
+	if err != nil {
 		return nil, errors.Wrap(err, "SessionGormRepository.GetAll")
 	}
-	return sesiones, nil
+	return page, nil
 }
 
 func (r *SessionGormRepository) GetById(id uint) (*models.Sesion, error) {
@@ -44,6 +59,18 @@ func (r *SessionGormRepository) GetById(id uint) (*models.Sesion, error) {
 	return &sesion, nil
 }
 
+// GetByIDProjected reads the fields and relations of a session requested by the projection
+func (r *SessionGormRepository) GetByIDProjected(id uint, proj repositories.Projection) (*models.Sesion, error) {
+	sesion, err := first[models.Sesion](r.db, id, repositories.SessionProjection, proj)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "SessionGormRepository.GetByIDProjected: id %d", id)
+	}
+	return sesion, nil
+}
+
 func (r *SessionGormRepository) Update(sesion *models.Sesion) error {
 	if err := r.db.Save(sesion).Error; err != nil {
 		return errors.Wrapf(err, "SessionGormRepository.Update: id %d", sesion.ID)
@@ -51,6 +78,13 @@ func (r *SessionGormRepository) Update(sesion *models.Sesion) error {
 	return nil
 }
 
+func (r *SessionGormRepository) Patch(sesion *models.Sesion, columns []string) error {
+	if err := r.db.Model(sesion).Select(columns).Updates(sesion).Error; err != nil {
+		return errors.Wrapf(err, "SessionGormRepository.Patch: id %d", sesion.ID)
+	}
+	return nil
+}
+
 func (r *SessionGormRepository) Delete(id uint) error {
 	if err := r.db.Delete(&models.Sesion{}, id).Error; err != nil {
 		return errors.Wrapf(err, "SessionGormRepository.Delete: id %d", id)
@@ -58,18 +92,68 @@ func (r *SessionGormRepository) Delete(id uint) error {
 	return nil
 }
 
-func (r *SessionGormRepository) GetByUserID(userID uint) ([]*models.Sesion, error) {
-	var sesiones []*models.Sesion
-	if err := r.db.Where("usuario_id = ?", userID).Find(&sesiones).Error; err != nil {
+func (r *SessionGormRepository) GetByUserID(userID uint, spec repositories.QuerySpec) (*repositories.Page[*models.Sesion], error) {
+	page, err := list[*models.Sesion](r.db.Where("sesiones.usuario_id = ?", userID), spec, sessionList)
+	if err != nil {
 		return nil, errors.Wrapf(err, "SessionGormRepository.GetByUserID: userID %d", userID)
 	}
-	return sesiones, nil
+	return page, nil
 }
 
-func (r *SessionGormRepository) GetByDateRange(startDate, endDate time.Time) ([]*models.Sesion, error) {
-	var sesiones []*models.Sesion
-	if err := r.db.Where("fecha BETWEEN ? AND ?", startDate, endDate).Find(&sesiones).Error; err != nil {
+func (r *SessionGormRepository) GetByDateRange(startDate, endDate time.Time, spec repositories.QuerySpec) (*repositories.Page[*models.Sesion], error) {
+	page, err := list[*models.Sesion](r.db.Where("sesiones.fecha BETWEEN ? AND ?", startDate, endDate), spec, sessionList)
+	if err != nil {
 		return nil, errors.Wrapf(err, "SessionGormRepository.GetByDateRange: startDate %v, endDate %v", startDate, endDate)
 	}
-	return sesiones, nil
+	return page, nil
+}
+
+// trainedSessions restricts queries to the sessions a user actually trained: completed ones, and planned ones
+// logged without the lifecycle, as before it existed, which have exercises recorded. Abandoned, in progress
+// and empty planned sessions are left out.
+const trainedSessions = "usuario_id = ? AND (estado = '" + models.SesionCompletada + "' OR (estado = '" + models.SesionPlanificada +
+	"' AND EXISTS (SELECT 1 FROM sesion_ejercicios se WHERE se.sesion_id = sesiones.id)))"
+
+func (r *SessionGormRepository) GetActivityByDay(userID uint, from, to time.Time) ([]models.DiaActividad, error) {
+	var dias []models.DiaActividad
+	if err := r.db.Model(&models.Sesion{}).
+		Select("DATE(fecha) AS fecha, COUNT(*) AS sesiones, COALESCE(SUM(duracion_min), 0) AS duracion_min").
+		Where(trainedSessions, userID).
+		Where("fecha >= ? AND fecha < ?", from, to).
+		Group("DATE(fecha)").
+		Order("DATE(fecha) ASC").
+		Scan(&dias).Error; err != nil {
+		return nil, errors.Wrapf(err, "SessionGormRepository.GetActivityByDay: userID %d", userID)
+	}
+	return dias, nil
+}
+
+// GetStreaks groups the distinct training days and weeks into runs of consecutive values
+// (gaps and islands) and measures the longest run and the one reaching today or the current week
+func (r *SessionGormRepository) GetStreaks(userID uint, today time.Time) (models.RachasEntrenamiento, error) {
+	var rachas models.RachasEntrenamiento
+	err := r.db.Raw(`
+		WITH dias AS (
+			SELECT DISTINCT DATE(fecha) AS dia FROM sesiones WHERE `+trainedSessions+` AND DATE(fecha) <= ?::date
+		), rachas_dias AS (
+			SELECT MAX(dia) AS fin, COUNT(*) AS longitud
+			FROM (SELECT dia, dia - (ROW_NUMBER() OVER (ORDER BY dia))::int AS grupo FROM dias) d
+			GROUP BY grupo
+		), semanas AS (
+			SELECT DISTINCT DATE_TRUNC('week', dia)::date AS semana FROM dias
+		), rachas_semanas AS (
+			SELECT MAX(semana) AS fin, COUNT(*) AS longitud
+			FROM (SELECT semana, semana - (ROW_NUMBER() OVER (ORDER BY semana))::int * 7 AS grupo FROM semanas) s
+			GROUP BY grupo
+		)
+		SELECT
+			COALESCE((SELECT longitud FROM rachas_dias WHERE fin >= ?::date - 1), 0) AS diaria_actual,
+			COALESCE((SELECT MAX(longitud) FROM rachas_dias), 0) AS diaria_maxima,
+			COALESCE((SELECT longitud FROM rachas_semanas WHERE fin >= DATE_TRUNC('week', ?::date)::date - 7), 0) AS semanal_actual,
+			COALESCE((SELECT MAX(longitud) FROM rachas_semanas), 0) AS semanal_maxima`,
+		userID, today, today, today).Scan(&rachas).Error
+	if err != nil {
+		return rachas, errors.Wrapf(err, "SessionGormRepository.GetStreaks: userID %d", userID)
+	}
+	return rachas, nil
 }
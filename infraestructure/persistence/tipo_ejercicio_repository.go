@@ -16,12 +16,21 @@ func NewTypeExerciseGormRepository(db *gorm.DB) repositories.TypeExerciseReposit
 	return &TypeExerciseGormRepository{db}
 }
 
-func (r *TypeExerciseGormRepository) GetAll() ([]*models.TipoEjercicio, error) {
-	var tiposEjercicio []*models.TipoEjercicio
-	if err := r.db.Find(&tiposEjercicio).Error; err != nil {
+// exerciseTypeList defines the fields exercise types can be sorted and filtered by
+var exerciseTypeList = listQuery{
+	fields: listFields{
+		"id":     {"id", true},
+		"nombre": {"nombre", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "id"}},
+}
+
+func (r *TypeExerciseGormRepository) GetAll(spec repositories.QuerySpec) (*repositories.Page[*models.TipoEjercicio], error) {
+	page, err := list[*models.TipoEjercicio](r.db, spec, exerciseTypeList)
+	if err != nil {
 		return nil, errors.Wrap(err, "TypeExerciseGormRepository.GetAll")
 	}
-	return tiposEjercicio, nil
+	return page, nil
 }
 
 func (r *TypeExerciseGormRepository) GetById(id uint) (*models.TipoEjercicio, error) {
@@ -35,6 +44,18 @@ func (r *TypeExerciseGormRepository) GetById(id uint) (*models.TipoEjercicio, er
 	return &tipoEjercicio, nil
 }
 
+// GetByName finds an exercise type by name, ignoring case
+func (r *TypeExerciseGormRepository) GetByName(nombre string) (*models.TipoEjercicio, error) {
+	var tipoEjercicio models.TipoEjercicio
+	if err := r.db.Where("LOWER(nombre) = LOWER(?)", nombre).Order("id ASC").First(&tipoEjercicio).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, errors.Wrapf(err, "TypeExerciseGormRepository.GetByName: %s", nombre)
+	}
+	return &tipoEjercicio, nil
+}
+
 func (r *TypeExerciseGormRepository) Create(tipoEjercicio *models.TipoEjercicio) error {
 	if err := r.db.Create(tipoEjercicio).Error; err != nil {
 		return errors.Wrap(err, "TypeExerciseGormRepository.Create")
@@ -50,8 +71,69 @@ func (r *TypeExerciseGormRepository) Update(tipoEjercicio *models.TipoEjercicio)
 }
 
 func (r *TypeExerciseGormRepository) Delete(id uint) error {
-	if err := r.db.Delete(&models.TipoEjercicio{}, id).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := deleteTranslations(tx, models.EntidadTipoEjercicio, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.TipoEjercicio{}, id).Error
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domainErrors.ErrConflict
+		}
 		return errors.Wrapf(err, "TypeExerciseGormRepository.Delete: id %d", id)
 	}
 	return nil
 }
+
+// CountDependents counts the exercises of the exercise type
+func (r *TypeExerciseGormRepository) CountDependents(id uint) (repositories.DependentCounts, error) {
+	counts, err := countDependents(r.db, id,
+		dependentQuery{"exercises", &models.Ejercicio{}, "tipo_ejercicio_id"},
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "TypeExerciseGormRepository.CountDependents: id %d", id)
+	}
+	history, err := countExerciseHistory(r.db, "tipo_ejercicio_id", id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "TypeExerciseGormRepository.CountDependents: id %d", id)
+	}
+	if history > 0 {
+		counts[repositories.DependentExerciseHistory] = history
+	}
+	return counts, nil
+}
+
+// DeleteWith deletes the exercise type after deleting or reassigning its exercises
+func (r *TypeExerciseGormRepository) DeleteWith(id uint, opts repositories.DeleteOptions) ([]string, error) {
+	var mediaKeys []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if opts.ReassignTo != 0 {
+			if err := tx.Model(&models.Ejercicio{}).Where("tipo_ejercicio_id = ?", id).
+				Update("tipo_ejercicio_id", opts.ReassignTo).Error; err != nil {
+				return err
+			}
+		} else if opts.Cascade {
+			var exerciseIDs []uint
+			if err := tx.Model(&models.Ejercicio{}).Where("tipo_ejercicio_id = ?", id).Pluck("id", &exerciseIDs).Error; err != nil {
+				return err
+			}
+			keys, err := deleteExercises(tx, exerciseIDs)
+			if err != nil {
+				return err
+			}
+			mediaKeys = keys
+		}
+		if err := deleteTranslations(tx, models.EntidadTipoEjercicio, id); err != nil {
+			return err
+		}
+		return tx.Delete(&models.TipoEjercicio{}, id).Error
+	})
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, domainErrors.ErrConflict
+		}
+		return nil, errors.Wrapf(err, "TypeExerciseGormRepository.DeleteWith: id %d", id)
+	}
+	return mediaKeys, nil
+}
@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TranslationGormRepository struct {
+	db *gorm.DB
+}
+
+func NewTranslationGormRepository(db *gorm.DB) repositories.TranslationRepository {
+	return &TranslationGormRepository{db}
+}
+
+// Upsert creates the translation or replaces the texts of the existing one for the same entity and locale
+func (r *TranslationGormRepository) Upsert(traduccion *models.Traduccion) error {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entidad"}, {Name: "entidad_id"}, {Name: "locale"}},
+		DoUpdates: clause.AssignmentColumns([]string{"nombre", "descripcion", "instrucciones"}),
+	}).Create(traduccion).Error; err != nil {
+		return errors.Wrapf(err, "TranslationGormRepository.Upsert: %s %d %s", traduccion.Entidad, traduccion.EntidadID, traduccion.Locale)
+	}
+	return nil
+}
+
+func (r *TranslationGormRepository) GetByEntity(entidad string, entidadID uint) ([]models.Traduccion, error) {
+	var traducciones []models.Traduccion
+	if err := r.db.Where("entidad = ? AND entidad_id = ?", entidad, entidadID).Order("locale ASC").Find(&traducciones).Error; err != nil {
+		return nil, errors.Wrapf(err, "TranslationGormRepository.GetByEntity: %s %d", entidad, entidadID)
+	}
+	return traducciones, nil
+}
+
+// GetForEntities returns the translations of several entities in one locale, by entity ID
+func (r *TranslationGormRepository) GetForEntities(entidad string, entidadIDs []uint, locale string) (map[uint]models.Traduccion, error) {
+	result := make(map[uint]models.Traduccion, len(entidadIDs))
+	if len(entidadIDs) == 0 {
+		return result, nil
+	}
+
+	var traducciones []models.Traduccion
+	if err := r.db.Where("entidad = ? AND entidad_id IN ? AND locale = ?", entidad, entidadIDs, locale).Find(&traducciones).Error; err != nil {
+		return nil, errors.Wrapf(err, "TranslationGormRepository.GetForEntities: %s %s", entidad, locale)
+	}
+	for _, t := range traducciones {
+		result[t.EntidadID] = t
+	}
+	return result, nil
+}
+
+func (r *TranslationGormRepository) Delete(entidad string, entidadID uint, locale string) (bool, error) {
+	result := r.db.Where("entidad = ? AND entidad_id = ? AND locale = ?", entidad, entidadID, locale).Delete(&models.Traduccion{})
+	if result.Error != nil {
+		return false, errors.Wrapf(result.Error, "TranslationGormRepository.Delete: %s %d %s", entidad, entidadID, locale)
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -3,6 +3,7 @@ package persistence
 import (
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	"github.com/jackc/pgconn"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
@@ -16,20 +17,34 @@ func NewUsuarioGormRepository(db *gorm.DB) *UsuarioGormRepository {
 	return &UsuarioGormRepository{DB: db}
 }
 
-func (r *UsuarioGormRepository) GetAll() ([]models.User, error) {
-	var usuarios []models.User
-	if err := r.DB.Where("is_deleted = ? AND is_active = ?", false, true).Find(&usuarios).Error; err != nil {
+// userList defines the fields users can be sorted and filtered by; the oldest accounts come first
+var userList = listQuery{
+	fields: listFields{
+		"id":         {"id", true},
+		"name":       {"name", true},
+		"email":      {"email", true},
+		"role_id":    {"role_id", true},
+		"is_active":  {"is_active", true},
+		"idioma":     {"idioma", false},
+		"created_at": {"created_at", true},
+	},
+	defaultSort: []repositories.SortField{{Field: "id"}},
+}
+
+func (r *UsuarioGormRepository) GetAll(spec repositories.QuerySpec) (*repositories.Page[models.User], error) {
+	page, err := list[models.User](r.DB.Where("is_deleted = ? AND is_active = ?", false, true), spec, userList)
+	if err != nil {
 		return nil, errors.Wrap(err, "UsuarioGormRepository.GetAll")
 	}
-	return usuarios, nil
+	return page, nil
 }
 
-func (r *UsuarioGormRepository) GetAllIncludingDeleted() ([]models.User, error) {
-	var usuarios []models.User
-	if err := r.DB.Find(&usuarios).Error; err != nil {
+func (r *UsuarioGormRepository) GetAllIncludingDeleted(spec repositories.QuerySpec) (*repositories.Page[models.User], error) {
+	page, err := list[models.User](r.DB, spec, userList)
+	if err != nil {
 		return nil, errors.Wrap(err, "UsuarioGormRepository.GetAllIncludingDeleted")
 	}
-	return usuarios, nil
+	return page, nil
 }
 
 func (r *UsuarioGormRepository) GetByID(id uint) (*models.User, error) {
@@ -109,6 +124,17 @@ func (r *UsuarioGormRepository) Update(usuario *models.User) error {
 	return nil
 }
 
+func (r *UsuarioGormRepository) Patch(usuario *models.User, columns []string) error {
+	if err := r.DB.Model(usuario).Select(columns).Updates(usuario).Error; err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domainErrors.ErrConflict
+		}
+		return errors.Wrapf(err, "UsuarioGormRepository.Patch: id %d", usuario.ID)
+	}
+	return nil
+}
+
 func (r *UsuarioGormRepository) Delete(id uint) error {
 	usuario, err := r.GetByIDIncludingDeleted(id)
 	if err != nil {
@@ -154,10 +180,10 @@ func (r *UsuarioGormRepository) HardDelete(id uint) error {
 	return nil
 }
 
-func (r *UsuarioGormRepository) GetDeletedUsers() ([]models.User, error) {
-	var usuarios []models.User
-	if err := r.DB.Where("is_deleted = ?", true).Find(&usuarios).Error; err != nil {
+func (r *UsuarioGormRepository) GetDeletedUsers(spec repositories.QuerySpec) (*repositories.Page[models.User], error) {
+	page, err := list[models.User](r.DB.Where("is_deleted = ?", true), spec, userList)
+	if err != nil {
 		return nil, errors.Wrap(err, "UsuarioGormRepository.GetDeletedUsers")
 	}
-	return usuarios, nil
+	return page, nil
 }
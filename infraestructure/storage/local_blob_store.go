@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// LocalBlobStore implements BlobStore on the local filesystem
+type LocalBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore creates a blob store rooted at the given directory
+func NewLocalBlobStore(root string) (repositories.BlobStore, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, errors.Wrapf(err, "NewLocalBlobStore: root %s", root)
+	}
+	if err := os.MkdirAll(abs, 0o750); err != nil {
+		return nil, errors.Wrapf(err, "NewLocalBlobStore: root %s", abs)
+	}
+	return &LocalBlobStore{root: abs}, nil
+}
+
+// Put writes the object to a temporary file and renames it into place
+func (s *LocalBlobStore) Put(key string, content io.Reader, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return errors.Wrapf(err, "LocalBlobStore.Put: key %s", key)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return errors.Wrapf(err, "LocalBlobStore.Put: key %s", key)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "LocalBlobStore.Put: key %s", key)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "LocalBlobStore.Put: key %s", key)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "LocalBlobStore.Put: key %s", key)
+	}
+	return nil
+}
+
+func (s *LocalBlobStore) Get(key string) (io.ReadCloser, string, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", domainErrors.ErrNotFound
+		}
+		return nil, "", errors.Wrapf(err, "LocalBlobStore.Get: key %s", key)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return f, contentType, nil
+}
+
+func (s *LocalBlobStore) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(err, "LocalBlobStore.Delete: key %s", key)
+	}
+	return nil
+}
+
+// path resolves a key inside the root directory, rejecting traversal outside of it
+func (s *LocalBlobStore) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	path := filepath.Join(s.root, clean)
+	if clean == "/" || !strings.HasPrefix(path, s.root+string(os.PathSeparator)) {
+		return "", domainErrors.NewAppError(400, "INVALID_BLOB_KEY", "Invalid media key", nil)
+	}
+	return path, nil
+}
@@ -0,0 +1,6 @@
+package dto
+
+// GrantCoachRequest identifies the user authorized as a coach
+type GrantCoachRequest struct {
+	Email string `json:"email" binding:"required" example:"coach@example.com"`
+}
@@ -0,0 +1,71 @@
+package dto
+
+import models "github.com/Diegonr1791/GymBro/internal/domain/models"
+
+// CreateExerciseAlternativeRequest curates an alternative for an exercise
+type CreateExerciseAlternativeRequest struct {
+	AlternativaID uint   `json:"alternativa_id" binding:"required" example:"12"`
+	Nota          string `json:"nota" example:"Use when the leg press is taken"`
+}
+
+// ExerciseRequest represents the editable fields of an exercise; ownership and uploaded media are managed by the system
+type ExerciseRequest struct {
+	Nombre           string                       `json:"nombre" binding:"required,max=100" example:"Bench press"`
+	TipoEjercicioID  uint                         `json:"tipo_ejercicio_id" binding:"required" example:"1"`
+	GrupoMuscularID  uint                         `json:"grupo_muscular_id" example:"2"` // main primary muscle group, defaults to the primary in grupos_musculares
+	Descripcion      string                       `json:"descripcion" binding:"max=1000" example:"Compound chest exercise"`
+	Instrucciones    string                       `json:"instrucciones" binding:"max=2000" example:"Lower the bar to mid-chest and press it up"`
+	Equipamiento     string                       `json:"equipamiento" binding:"omitempty,oneof=barbell dumbbell machine bodyweight cable kettlebell band other" example:"barbell"`
+	Dificultad       string                       `json:"dificultad" binding:"omitempty,oneof=beginner intermediate advanced" example:"intermediate"`
+	Unilateral       bool                         `json:"unilateral" example:"false"`
+	ImagenURL        string                       `json:"imagen_url,omitempty" binding:"omitempty,http_url" example:"https://example.com/bench.png"`
+	VideoURL         string                       `json:"video_url,omitempty" binding:"omitempty,http_url" example:"https://example.com/bench.mp4"`
+	GruposMusculares []ExerciseMuscleGroupRequest `json:"grupos_musculares,omitempty" binding:"omitempty,dive"`
+}
+
+// ExerciseMuscleGroupRequest links the exercise to a primary or secondary muscle group
+type ExerciseMuscleGroupRequest struct {
+	GrupoMuscularID uint   `json:"grupo_muscular_id" binding:"required" example:"3"`
+	Rol             string `json:"rol" binding:"required,oneof=primary secondary" example:"secondary"`
+}
+
+// ToModel maps the request to the exercise with the given ID, zero for a new exercise
+func (r ExerciseRequest) ToModel(id uint) *models.Ejercicio {
+	ejercicio := &models.Ejercicio{
+		ID:              id,
+		Nombre:          r.Nombre,
+		TipoEjercicioID: r.TipoEjercicioID,
+		GrupoMuscularID: r.GrupoMuscularID,
+		Descripcion:     r.Descripcion,
+		Instrucciones:   r.Instrucciones,
+		Equipamiento:    r.Equipamiento,
+		Dificultad:      r.Dificultad,
+		Unilateral:      r.Unilateral,
+		ImagenURL:       r.ImagenURL,
+		VideoURL:        r.VideoURL,
+	}
+	for _, g := range r.GruposMusculares {
+		ejercicio.GruposMusculares = append(ejercicio.GruposMusculares, models.EjercicioGrupoMuscular{GrupoMuscularID: g.GrupoMuscularID, Rol: g.Rol})
+	}
+	return ejercicio
+}
+
+// MuscleGroupRequest represents a muscle group of the shared catalog
+type MuscleGroupRequest struct {
+	Nombre string `json:"nombre" binding:"required,max=100" example:"Pecho"`
+}
+
+// ToModel maps the request to the muscle group with the given ID, zero for a new one
+func (r MuscleGroupRequest) ToModel(id uint) *models.GrupoMuscular {
+	return &models.GrupoMuscular{ID: id, Nombre: r.Nombre}
+}
+
+// ExerciseTypeRequest represents an exercise type of the shared catalog
+type ExerciseTypeRequest struct {
+	Nombre string `json:"nombre" binding:"required,max=100" example:"Fuerza"`
+}
+
+// ToModel maps the request to the exercise type with the given ID, zero for a new one
+func (r ExerciseTypeRequest) ToModel(id uint) *models.TipoEjercicio {
+	return &models.TipoEjercicio{ID: id, Nombre: r.Nombre}
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// CreateGoalRequest represents a goal to track
+type CreateGoalRequest struct {
+	Tipo        string     `json:"tipo" binding:"required" example:"body_weight"` // body_weight, body_fat or exercise_1rm
+	EjercicioID *uint      `json:"ejercicio_id,omitempty" example:"12"`           // required for exercise_1rm
+	Objetivo    float64    `json:"objetivo" binding:"required" example:"75"`
+	FechaLimite *time.Time `json:"fecha_limite,omitempty" example:"2026-12-31T00:00:00Z"`
+	Unit        string     `json:"unit,omitempty" example:"kg"` // unit of objetivo (kg, lb), defaults to the caller preference
+}
@@ -0,0 +1,16 @@
+package dto
+
+import repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+
+// ListResponse is a page of a list endpoint. Pass next_cursor as the cursor query parameter to get the
+// following page; it is omitted on the last one. Total counts every item matching the filters.
+type ListResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty" example:"WyIyMDI1LTAxLTE1VDEwOjAwOjAwWiIsNDJd"`
+	Total      int64  `json:"total" example:"42"`
+}
+
+// NewListResponse builds the response of a page read from a repository
+func NewListResponse[T any](page *repositories.Page[T]) ListResponse[T] {
+	return ListResponse[T]{Items: page.Items, NextCursor: page.NextCursor, Total: page.Total}
+}
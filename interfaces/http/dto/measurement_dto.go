@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+// CreateMeasurementMetricRequest represents a custom measurement metric
+type CreateMeasurementMetricRequest struct {
+	Nombre string `json:"nombre" binding:"required" example:"Antebrazo"`
+	Unidad string `json:"unidad" binding:"required" example:"cm"`
+	Clave  string `json:"clave,omitempty" example:"antebrazo"` // defaults to the name in snake case
+}
+
+// MeasurementRequest represents a body measurement of the caller. Metric values reference the metric by ID or key;
+// peso_corporal, grasa_corporal and musculo are kept in sync with their standard metrics.
+type MeasurementRequest struct {
+	Fecha         time.Time                 `json:"fecha" binding:"required" example:"2025-01-15T08:00:00Z"`
+	PesoCorporal  float32                   `json:"peso_corporal" binding:"gte=0" example:"80"`
+	GrasaCorporal float32                   `json:"grasa_corporal" binding:"gte=0,lt=100" example:"19"`
+	Musculo       float32                   `json:"musculo" binding:"gte=0" example:"36"`
+	Valores       []MeasurementValueRequest `json:"valores,omitempty" binding:"omitempty,dive"`
+	Unit          string                    `json:"unit,omitempty" binding:"omitempty,oneof=kg lb cm in metric imperial" example:"kg"` // unit of the values, defaults to the caller preference
+}
+
+// MeasurementValueRequest is the value of a standard or custom metric
+type MeasurementValueRequest struct {
+	MetricaID uint    `json:"metrica_id,omitempty" binding:"required_without=Clave" example:"4"`
+	Clave     string  `json:"clave,omitempty" binding:"required_without=MetricaID" example:"cintura"`
+	Valor     float64 `json:"valor" example:"82.5"`
+}
+
+// ToModel maps the request to the measurement with the given ID, zero for a new one
+func (r MeasurementRequest) ToModel(id uint) *models.Medicion {
+	medicion := &models.Medicion{
+		ID:            id,
+		Fecha:         r.Fecha,
+		PesoCorporal:  r.PesoCorporal,
+		GrasaCorporal: r.GrasaCorporal,
+		Musculo:       r.Musculo,
+	}
+	for _, v := range r.Valores {
+		medicion.Valores = append(medicion.Valores, models.ValorMedicion{MetricaID: v.MetricaID, Clave: v.Clave, Valor: v.Valor})
+	}
+	return medicion
+}
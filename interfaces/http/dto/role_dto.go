@@ -0,0 +1,22 @@
+package dto
+
+import models "github.com/Diegonr1791/GymBro/internal/domain/models"
+
+// RoleRequest represents the editable fields of a role; system roles are only created by the seeder
+type RoleRequest struct {
+	Name        string `json:"name" binding:"required,max=50" example:"coach"`
+	Description string `json:"description" binding:"max=255" example:"Coaches with access to their athletes"`
+	IsActive    bool   `json:"is_active" example:"true"`
+	Priority    int    `json:"priority" binding:"gte=0" example:"5"`
+}
+
+// ToModel maps the request to the role with the given ID, zero for a new role
+func (r RoleRequest) ToModel(id uint) *models.Role {
+	return &models.Role{
+		ID:          id,
+		Name:        r.Name,
+		Description: r.Description,
+		IsActive:    r.IsActive,
+		Priority:    r.Priority,
+	}
+}
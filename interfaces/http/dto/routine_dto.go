@@ -0,0 +1,35 @@
+package dto
+
+import models "github.com/Diegonr1791/GymBro/internal/domain/models"
+
+// RoutineRequest represents the editable fields of a routine; the owner, attribution and versions are managed by the system
+type RoutineRequest struct {
+	Nombre   string `json:"nombre" binding:"required,max=100" example:"Push Pull Legs"`
+	Objetivo string `json:"objetivo" binding:"max=255" example:"Hypertrophy"`
+	Publica  bool   `json:"publica" example:"false"`
+}
+
+// ToModel maps the request to the routine with the given ID, zero for a new routine
+func (r RoutineRequest) ToModel(id uint) *models.Rutina {
+	return &models.Rutina{
+		ID:       id,
+		Nombre:   r.Nombre,
+		Objetivo: r.Objetivo,
+		Publica:  r.Publica,
+	}
+}
+
+// RoutineMuscleGroupRequest links a routine to a muscle group
+type RoutineMuscleGroupRequest struct {
+	RutinaID        uint `json:"rutina_id" binding:"required" example:"3"`
+	GrupoMuscularID uint `json:"grupo_muscular_id" binding:"required" example:"1"`
+}
+
+// ToModel maps the request to the link with the given ID, zero for a new link
+func (r RoutineMuscleGroupRequest) ToModel(id uint) *models.RutinaGrupoMuscular {
+	return &models.RutinaGrupoMuscular{
+		ID:              id,
+		RutinaID:        r.RutinaID,
+		GrupoMuscularID: r.GrupoMuscularID,
+	}
+}
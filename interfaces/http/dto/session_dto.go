@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+// SwapExerciseRequest replaces the exercise of a session exercise
+type SwapExerciseRequest struct {
+	EjercicioID uint `json:"ejercicio_id" binding:"required" example:"12"`
+}
+
+// SessionRequest represents the editable fields of a session; the owner and lifecycle are managed by the system
+type SessionRequest struct {
+	Fecha       time.Time `json:"fecha" binding:"required" example:"2025-01-15T10:00:00Z"`
+	DuracionMin int       `json:"duracion_min" binding:"gte=0,lte=1440" example:"60"`
+	Comentarios string    `json:"comentarios" binding:"max=1000" example:"Felt strong today"`
+	RutinaID    *uint     `json:"rutina_id,omitempty" binding:"omitempty,gt=0" example:"3"`
+}
+
+// ToModel maps the request to the session with the given ID, zero for a new session
+func (r SessionRequest) ToModel(id uint) *models.Sesion {
+	return &models.Sesion{
+		ID:          id,
+		Fecha:       r.Fecha,
+		DuracionMin: r.DuracionMin,
+		Comentarios: r.Comentarios,
+		RutinaID:    r.RutinaID,
+	}
+}
+
+// SessionExerciseRequest represents an exercise performed in a session
+type SessionExerciseRequest struct {
+	SesionID     uint      `json:"sesion_id" binding:"required" example:"7"`
+	EjercicioID  uint      `json:"ejercicio_id" binding:"required" example:"12"`
+	Fecha        time.Time `json:"fecha" example:"2025-01-15T10:00:00Z"`
+	Series       int       `json:"series" binding:"gte=0,lte=100" example:"4"`
+	Repeticiones int       `json:"repeticiones" binding:"gte=0,lte=1000" example:"8"`
+	Orden        int       `json:"orden" binding:"gte=0" example:"1"`
+	Peso         float64   `json:"peso" binding:"gte=0" example:"80"`
+	Observacion  string    `json:"observacion" binding:"max=500" example:"Last set to failure"`
+	Unit         string    `json:"unit,omitempty" binding:"omitempty,oneof=kg lb metric imperial" example:"kg"` // unit of peso, defaults to the caller preference
+}
+
+// ToModel maps the request to the session exercise with the given ID, zero for a new one
+func (r SessionExerciseRequest) ToModel(id uint) *models.SesionEjercicio {
+	return &models.SesionEjercicio{
+		ID:           id,
+		SesionID:     r.SesionID,
+		EjercicioID:  r.EjercicioID,
+		Fecha:        r.Fecha,
+		Series:       r.Series,
+		Repeticiones: r.Repeticiones,
+		Orden:        r.Orden,
+		Peso:         r.Peso,
+		Observacion:  r.Observacion,
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+// UpsertTranslationRequest represents the localized texts of a catalog entity
+type UpsertTranslationRequest struct {
+	Nombre        string `json:"nombre" binding:"required" example:"Bench press"`
+	Descripcion   string `json:"descripcion,omitempty" example:"Compound chest exercise"`
+	Instrucciones string `json:"instrucciones,omitempty" example:"Lower the bar to mid-chest and press it up"`
+}
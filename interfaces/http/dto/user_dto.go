@@ -1,27 +1,116 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+)
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	RoleID    uint      `json:"role_id"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uint      `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	RoleID         uint      `json:"role_id"`
+	Idioma         string    `json:"idioma,omitempty"`
+	AlturaCm       float64   `json:"altura_cm,omitempty"`
+	UnidadPeso     string    `json:"unidad_peso,omitempty"`
+	UnidadLongitud string    `json:"unidad_longitud,omitempty"`
+	IsActive       bool      `json:"is_active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// NewUserResponse builds the public view of a user, without the password hash or deletion flags
+func NewUserResponse(u *models.User) UserResponse {
+	return UserResponse{
+		ID:             u.ID,
+		Name:           u.Name,
+		Email:          u.Email,
+		RoleID:         u.RoleID,
+		Idioma:         u.Idioma,
+		AlturaCm:       u.AlturaCm,
+		UnidadPeso:     u.UnidadPeso,
+		UnidadLongitud: u.UnidadLongitud,
+		IsActive:       u.IsActive,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
+	}
+}
+
+// NewUserListResponse builds a page of users
+func NewUserListResponse(page *repositories.Page[models.User]) ListResponse[UserResponse] {
+	items := make([]UserResponse, 0, len(page.Items))
+	for i := range page.Items {
+		items = append(items, NewUserResponse(&page.Items[i]))
+	}
+	return ListResponse[UserResponse]{Items: items, NextCursor: page.NextCursor, Total: page.Total}
 }
 
 type CreateUserRequest struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	RoleID   uint   `json:"role_id"`
+	Name           string  `json:"name" binding:"required,max=100" example:"John Doe"`
+	Email          string  `json:"email" binding:"required,email" example:"john@example.com"`
+	Password       string  `json:"password" binding:"required,min=8,max=128" example:"Password123"`
+	RoleID         uint    `json:"role_id" binding:"required" example:"2"`
+	Idioma         string  `json:"idioma,omitempty" binding:"omitempty,max=8" example:"en"`
+	AlturaCm       float64 `json:"altura_cm,omitempty" binding:"omitempty,gt=0,lte=300" example:"180"`
+	UnidadPeso     string  `json:"unidad_peso,omitempty" binding:"omitempty,oneof=kg lb" example:"kg"`
+	UnidadLongitud string  `json:"unidad_longitud,omitempty" binding:"omitempty,oneof=cm in" example:"cm"`
 }
 
+// ToModel maps the request to a new user
+func (r CreateUserRequest) ToModel() *models.User {
+	return &models.User{
+		Name:           r.Name,
+		Email:          r.Email,
+		Password:       r.Password,
+		RoleID:         r.RoleID,
+		Idioma:         r.Idioma,
+		AlturaCm:       r.AlturaCm,
+		UnidadPeso:     r.UnidadPeso,
+		UnidadLongitud: r.UnidadLongitud,
+	}
+}
+
+// UpdateUserRequest represents the editable profile of a user. Only admins can change the role and the
+// account status; the password is changed through PATCH with the current password.
 type UpdateUserRequest struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	RoleID   uint   `json:"role_id"`
-	IsActive bool   `json:"is_active"`
+	Name           string  `json:"name" binding:"omitempty,max=100" example:"John Doe"`
+	Email          string  `json:"email" binding:"omitempty,email" example:"john@example.com"`
+	RoleID         uint    `json:"role_id" example:"2"`
+	Idioma         string  `json:"idioma,omitempty" binding:"omitempty,max=8" example:"en"`
+	AlturaCm       float64 `json:"altura_cm,omitempty" binding:"omitempty,gt=0,lte=300" example:"180"`
+	UnidadPeso     string  `json:"unidad_peso,omitempty" binding:"omitempty,oneof=kg lb" example:"kg"`
+	UnidadLongitud string  `json:"unidad_longitud,omitempty" binding:"omitempty,oneof=cm in" example:"cm"`
+	IsActive       *bool   `json:"is_active,omitempty" example:"true"` // nil keeps the current status
+}
+
+// ToModel maps the request to the user with the given ID; empty fields keep their current value and
+// IsActive is passed apart, since false cannot be told apart from a missing value in the model
+func (r UpdateUserRequest) ToModel(id uint) *models.User {
+	return &models.User{
+		ID:             id,
+		Name:           r.Name,
+		Email:          r.Email,
+		RoleID:         r.RoleID,
+		Idioma:         r.Idioma,
+		AlturaCm:       r.AlturaCm,
+		UnidadPeso:     r.UnidadPeso,
+		UnidadLongitud: r.UnidadLongitud,
+	}
+}
+
+// PatchUserRequest represents the fields a JSON merge patch can set on a user. Changing the password
+// requires the current one.
+type PatchUserRequest struct {
+	Name            string  `json:"name" binding:"required,max=100" example:"John Doe"`
+	Email           string  `json:"email" binding:"required,email" example:"john@example.com"`
+	Password        string  `json:"password" binding:"required,min=8,max=128" example:"NewPassword123"`
+	CurrentPassword string  `json:"current_password" binding:"required_with=Password" example:"Password123"`
+	RoleID          uint    `json:"role_id" binding:"required" example:"2"`
+	Idioma          string  `json:"idioma,omitempty" binding:"omitempty,max=8" example:"en"`
+	AlturaCm        float64 `json:"altura_cm,omitempty" binding:"omitempty,gt=0,lte=300" example:"180"`
+	UnidadPeso      string  `json:"unidad_peso,omitempty" binding:"omitempty,oneof=kg lb" example:"kg"`
+	UnidadLongitud  string  `json:"unidad_longitud,omitempty" binding:"omitempty,oneof=cm in" example:"cm"`
+	IsActive        *bool   `json:"is_active" example:"true"`
 }
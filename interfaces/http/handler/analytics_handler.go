@@ -0,0 +1,178 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Diegonr1791/GymBro/interfaces/http/middleware"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	uc *usecase.AnalyticsUsecase
+	tr *usecase.TranslationUsecase
+}
+
+func NewAnalyticsHandler(r gin.IRouter, uc *usecase.AnalyticsUsecase, tr *usecase.TranslationUsecase) {
+	h := &AnalyticsHandler{uc, tr}
+
+	// Grouping the caller's analytics routes under "me/analytics"
+	analyticsRoutes := r.Group("/me/analytics")
+	{
+		analyticsRoutes.GET("/exercises/:id/progression", h.GetExerciseProgression)
+		analyticsRoutes.GET("/muscle-groups/volume", h.GetMuscleGroupVolume)
+		analyticsRoutes.GET("/consistency", h.GetConsistency)
+	}
+}
+
+// @Summary      Get my strength progression on an exercise
+// @Description  Get the best estimated one-rep max per week or month, taking the best set of each session, with the trend slope (weight per period) and the percentage change between the first and last period. Only completed sessions and untracked sessions with logged exercises are counted.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path   int     true   "Exercise ID"
+// @Param        from     query  string  false  "Start date (format: 2006-01-02, default: six months before to)"
+// @Param        to       query  string  false  "End date, inclusive (format: 2006-01-02, default: today)"
+// @Param        bucket   query  string  false  "Aggregation period: week (default) or month"
+// @Param        formula  query  string  false  "1RM formula: epley (default) or brzycki"
+// @Param        unit     query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.ProgresionEjercicio
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID, dates, bucket or formula"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/analytics/exercises/{id}/progression [get]
+func (h *AnalyticsHandler) GetExerciseProgression(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+	from, err := queryDate(c, "from")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	to, err := queryDate(c, "to")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	progresion, err := h.uc.GetExerciseProgression(userID, uint(id), from, to, c.Query("bucket"), c.Query("formula"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	progresion.ConvertWeights(salida.WeightFromSI)
+	c.JSON(http.StatusOK, progresion)
+}
+
+// @Summary      Get my weekly volume per muscle group
+// @Description  Get the tonnage and working sets (every set with at least one rep) of every muscle group in the sessions of a week compared with the previous week, flagged as under, optimal or over against the weekly set targets. Secondary muscle groups count half. Only completed sessions and untracked sessions with logged exercises are counted, and names follow the response language.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        week      query  string  false  "Any date of the week (format: 2006-01-02, default: current week)"
+// @Param        min_sets  query  int     false  "Minimum weekly sets per muscle group (default: WEEKLY_SETS_TARGET_MIN)"
+// @Param        max_sets  query  int     false  "Maximum weekly sets per muscle group (default: WEEKLY_SETS_TARGET_MAX)"
+// @Param        unit      query  string  false  "Units of the tonnage: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.DashboardVolumen
+// @Failure      400  {object}  errors.ProblemDetails "Invalid date or targets"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/analytics/muscle-groups/volume [get]
+func (h *AnalyticsHandler) GetMuscleGroupVolume(c *gin.Context) {
+	week, err := queryDate(c, "week")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	minSets, err := queryUint(c, "min_sets")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_TARGETS", "min_sets must be a valid number", err))
+		return
+	}
+	maxSets, err := queryUint(c, "max_sets")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_TARGETS", "max_sets must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	dashboard, err := h.uc.GetMuscleGroupVolume(userID, week, usecase.VolumeTargets{MinSets: float64(minSets), MaxSets: float64(maxSets)})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	dashboard.ConvertWeights(salida.WeightFromSI)
+
+	// Muscle group names follow the response language like the catalog endpoints
+	localized := make([]*models.GrupoMuscular, 0, len(dashboard.Grupos))
+	for _, g := range dashboard.Grupos {
+		localized = append(localized, &models.GrupoMuscular{ID: g.GrupoMuscularID, Nombre: g.Nombre})
+	}
+	if err := h.tr.LocalizeMuscleGroups(middleware.GetLocale(c), localized...); err != nil {
+		c.Error(err)
+		return
+	}
+	for i, g := range localized {
+		dashboard.Grupos[i].Nombre = g.Nombre
+	}
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// @Summary      Get my training consistency
+// @Description  Get the sessions and minutes trained per day of a year (heatmap data), the sessions-per-week average and the current and longest daily and weekly streaks. Only completed sessions and untracked sessions with logged exercises are counted.
+// @Tags         analytics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        year  query  int  false  "Calendar year (default: last 365 days)"
+// @Success      200  {object}  models.ResumenConsistencia
+// @Failure      400  {object}  errors.ProblemDetails "Invalid year"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/analytics/consistency [get]
+func (h *AnalyticsHandler) GetConsistency(c *gin.Context) {
+	year, err := queryUint(c, "year")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_YEAR", "year must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resumen, err := h.uc.GetConsistency(userID, int(year))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, resumen)
+}
@@ -72,9 +72,9 @@ func (h *AuthHandler) validateLoginRequest(req *LoginRequest) error {
 // @Produce      json
 // @Param        credentials body LoginRequest true "Login credentials"
 // @Success      200  {object}  LoginResponse
-// @Failure      400  {object}  errors.ErrorResponse "Invalid data"
-// @Failure      401  {object}  errors.ErrorResponse "Invalid credentials"
-// @Failure      500  {object}  errors.ErrorResponse "Internal server error"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid data"
+// @Failure      401  {object}  errors.ProblemDetails "Invalid credentials"
+// @Failure      500  {object}  errors.ProblemDetails "Internal server error"
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
@@ -123,8 +123,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Tags         authentication
 // @Produce      json
 // @Success      200  {object}  map[string]string "New access token"
-// @Failure      400  {object}  errors.ErrorResponse "Cookie not found"
-// @Failure      401  {object}  errors.ErrorResponse "Invalid refresh token"
+// @Failure      400  {object}  errors.ProblemDetails "Cookie not found"
+// @Failure      401  {object}  errors.ProblemDetails "Invalid refresh token"
 // @Router       /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	refreshTokenString, err := c.Cookie("refresh_token")
@@ -147,7 +147,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 // @Tags         authentication
 // @Produce      json
 // @Success      200  {object}  map[string]string "Session closed successfully"
-// @Failure      400  {object}  errors.ErrorResponse "No active session to close"
+// @Failure      400  {object}  errors.ProblemDetails "No active session to close"
 // @Router       /auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
 	refreshTokenString, err := c.Cookie("refresh_token")
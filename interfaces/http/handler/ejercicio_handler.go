@@ -1,21 +1,28 @@
 package http
 
 import (
+	"io"
 	"net/http"
 	"strconv"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	"github.com/Diegonr1791/GymBro/interfaces/http/middleware"
+	"github.com/Diegonr1791/GymBro/internal/auth"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type ExerciseHandler struct {
-	uc *usecase.ExerciseUsecase
+	uc     *usecase.ExerciseUsecase
+	tr     *usecase.TranslationUsecase
+	signer *auth.URLSigner
 }
 
-func NewExerciseHandler(r gin.IRouter, uc *usecase.ExerciseUsecase) {
-	h := &ExerciseHandler{uc}
+func NewExerciseHandler(r gin.IRouter, uc *usecase.ExerciseUsecase, tr *usecase.TranslationUsecase, signer *auth.URLSigner, catalogAdminMiddleware gin.HandlerFunc) {
+	h := &ExerciseHandler{uc, tr, signer}
 
 	// Grouping exercise routes under "exercises"
 	exerciseRoutes := r.Group("/exercises")
@@ -25,38 +32,133 @@ func NewExerciseHandler(r gin.IRouter, uc *usecase.ExerciseUsecase) {
 		exerciseRoutes.GET("/:id", h.GetByID)
 		exerciseRoutes.PUT("/:id", h.Update)
 		exerciseRoutes.DELETE("/:id", h.Delete)
+		exerciseRoutes.POST("/:id/image", h.UploadImage)
+		exerciseRoutes.POST("/:id/video", h.UploadVideo)
+
+		exerciseRoutes.GET("/:id/alternatives", h.GetAlternatives)
+
+		// Managing the shared catalog requires admin permissions
+		exerciseRoutes.POST("/:id/promote", catalogAdminMiddleware, h.Promote)
+		exerciseRoutes.POST("/:id/alternatives", catalogAdminMiddleware, h.AddAlternative)
+		exerciseRoutes.DELETE("/:id/alternatives/:alternative_id", catalogAdminMiddleware, h.RemoveAlternative)
 		exerciseRoutes.GET("/muscle-group/:id", h.GetByMuscleGroup)
 	}
 }
 
 // @Summary      Get all exercises
-// @Description  Get a complete list of exercises
+// @Description  Get the shared exercise catalog plus the caller's custom exercises, optionally filtered by name, type, muscle groups, equipment, difficulty, unilateral flag, media and custom flag
 // @Tags         exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.Ejercicio
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        q                          query  string  false  "Search by name"
+// @Param        type_id                    query  int     false  "Exercise type ID"
+// @Param        muscle_group_id            query  int     false  "Muscle group ID (primary or secondary)"
+// @Param        primary_muscle_group_id    query  int     false  "Primary muscle group ID"
+// @Param        secondary_muscle_group_id  query  int     false  "Secondary muscle group ID"
+// @Param        equipment                  query  string  false  "Equipment (barbell, dumbbell, machine, bodyweight, cable, kettlebell, band, other)"
+// @Param        difficulty                 query  string  false  "Difficulty (beginner, intermediate, advanced)"
+// @Param        unilateral                 query  bool    false  "Unilateral exercises only (true) or bilateral only (false)"
+// @Param        has_media                  query  bool    false  "Exercises with (true) or without (false) media"
+// @Param        custom                     query  bool    false  "Only the caller's custom exercises (true) or only the shared catalog (false)"
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, nombre, tipo_ejercicio_id, grupo_muscular_id, unilateral, is_custom (default: nombre)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, nombre, tipo_ejercicio_id, grupo_muscular_id, unilateral, is_custom, equipamiento, dificultad"  collectionFormat(multi)
+// @Param        fields   query  string  false  "Comma separated fields to return: id, nombre, tipo_ejercicio_id, grupo_muscular_id, usuario_id, is_custom, descripcion, instrucciones, equipamiento, dificultad, unilateral, imagen_url, video_url"
+// @Param        include  query  string  false  "Comma separated relations to embed: type, muscle_group"
+// @Success      200  {object}  dto.ListResponse[models.Ejercicio]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid filter, limit, cursor, sort, fields or include"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /exercises [get]
 func (h *ExerciseHandler) GetAll(c *gin.Context) {
-	ejercicios, err := h.uc.GetAllExercises()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, ejercicios)
+	if spec.Projection, err = projectionSpec(c, repositories.ExerciseProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filter := repositories.ExerciseFilter{
+		ViewerID:     userID,
+		Search:       c.Query("q"),
+		Equipamiento: c.Query("equipment"),
+		Dificultad:   c.Query("difficulty"),
+	}
+
+	uintParams := []struct {
+		key  string
+		dest *uint
+	}{
+		{"type_id", &filter.TipoEjercicioID},
+		{"muscle_group_id", &filter.GrupoMuscularID},
+		{"primary_muscle_group_id", &filter.PrimaryGrupoMuscularID},
+		{"secondary_muscle_group_id", &filter.SecondaryGrupoMuscularID},
+	}
+	for _, p := range uintParams {
+		if *p.dest, err = queryUint(c, p.key); err != nil {
+			c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_FILTER", p.key+" must be a valid number", err))
+			return
+		}
+	}
+	if filter.Unilateral, err = queryBool(c, "unilateral"); err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_FILTER", "unilateral must be true or false", err))
+		return
+	}
+	if filter.HasMedia, err = queryBool(c, "has_media"); err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_FILTER", "has_media must be true or false", err))
+		return
+	}
+	if filter.Custom, err = queryBool(c, "custom"); err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_FILTER", "custom must be true or false", err))
+		return
+	}
+
+	ejercicios, err := h.uc.SearchExercises(filter, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.tr.LocalizeExercises(middleware.GetLocale(c), ejercicios.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.localizeIncluded(c, ejercicios.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	h.signMedia(ejercicios.Items...)
+	response, err := sparseList(repositories.ExerciseProjection, spec.Projection, ejercicios)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Get exercise by ID
-// @Description  Get a specific exercise by its ID
+// @Description  Get a specific exercise by its ID; custom exercises are only visible to their owner
 // @Tags         exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Exercise ID"
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
+// @Param        fields   query  string  false  "Comma separated fields to return: id, nombre, tipo_ejercicio_id, grupo_muscular_id, usuario_id, is_custom, descripcion, instrucciones, equipamiento, dificultad, unilateral, imagen_url, video_url"
+// @Param        include  query  string  false  "Comma separated relations to embed: type, muscle_group"
 // @Success      200  {object}  models.Ejercicio
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Exercise not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format, fields or include"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
 // @Router       /exercises/{id} [get]
 func (h *ExerciseHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -65,33 +167,65 @@ func (h *ExerciseHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	ejercicio, err := h.uc.GetExerciseByID(uint(id))
+	proj, err := projectionSpec(c, repositories.ExerciseProjection)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, ejercicio)
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	ejercicio, err := h.uc.GetExerciseForUser(uint(id), userID, proj)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.tr.LocalizeExercises(middleware.GetLocale(c), ejercicio); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.localizeIncluded(c, ejercicio); err != nil {
+		c.Error(err)
+		return
+	}
+	h.signMedia(ejercicio)
+	response, err := sparse(repositories.ExerciseProjection, proj, ejercicio)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Create a new exercise
-// @Description  Create a new exercise in the system
+// @Description  Admins add the exercise to the shared catalog; other users create a private custom exercise
 // @Tags         exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        exercise body models.Ejercicio true "Exercise data"
+// @Param        exercise body dto.ExerciseRequest true "Exercise data"
 // @Success      201  {object}  models.Ejercicio
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /exercises [post]
 func (h *ExerciseHandler) Create(c *gin.Context) {
-	var ejercicio models.Ejercicio
-	if err := c.ShouldBindJSON(&ejercicio); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	userID, roleID, ok := currentActor(c)
+	if !ok {
 		return
 	}
 
-	if err := h.uc.CreateExercise(&ejercicio); err != nil {
+	var req dto.ExerciseRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	ejercicio := req.ToModel(0)
+	if err := h.uc.CreateExercise(ejercicio, userID, roleID); err != nil {
 		c.Error(err)
 		return
 	}
@@ -99,17 +233,18 @@ func (h *ExerciseHandler) Create(c *gin.Context) {
 }
 
 // @Summary      Update exercise
-// @Description  Update an existing exercise
+// @Description  Update an existing exercise; custom exercises by their owner, shared ones by admins
 // @Tags         exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id        path   int  true  "Exercise ID"
-// @Param        exercise  body   models.Ejercicio true "Updated exercise data"
+// @Param        exercise  body   dto.ExerciseRequest true "Updated exercise data"
 // @Success      200  {object}  models.Ejercicio
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      403  {object}  errors.ProblemDetails "Shared catalog is read-only"
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /exercises/{id} [put]
 func (h *ExerciseHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -118,30 +253,41 @@ func (h *ExerciseHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var ejercicioUpdate models.Ejercicio
-	if err := c.ShouldBindJSON(&ejercicioUpdate); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	userID, roleID, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.ExerciseRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	ejercicioUpdate.ID = uint(id)
-	if err := h.uc.UpdateExercise(&ejercicioUpdate); err != nil {
+	ejercicioUpdate := req.ToModel(uint(id))
+	if err := h.uc.UpdateExercise(ejercicioUpdate, userID, roleID); err != nil {
 		c.Error(err)
 		return
 	}
+	h.signMedia(ejercicioUpdate)
 	c.JSON(http.StatusOK, ejercicioUpdate)
 }
 
 // @Summary      Delete exercise
-// @Description  Delete an exercise from the system
+// @Description  Delete an exercise; custom exercises by their owner, shared ones by admins. Exercises performed in sessions require cascade or reassign_to
 // @Tags         exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id  path      int  true  "Exercise ID"
+// @Param        id           path      int   true   "Exercise ID"
+// @Param        cascade      query     bool  false  "Delete the session entries that performed the exercise"
+// @Param        reassign_to  query     int   false  "Move the session entries to this exercise before deleting"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object} errors.ProblemDetails "Shared catalog is read-only"
+// @Failure      404 {object} errors.ProblemDetails "Exercise not found"
+// @Failure      409 {object} errors.ProblemDetails "Still referenced; details lists the dependents"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /exercises/{id} [delete]
 func (h *ExerciseHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -150,7 +296,18 @@ func (h *ExerciseHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.uc.DeleteExercise(uint(id)); err != nil {
+	userID, roleID, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	opts, err := deleteOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.uc.DeleteExercise(uint(id), userID, roleID, opts); err != nil {
 		c.Error(err)
 		return
 	}
@@ -158,27 +315,327 @@ func (h *ExerciseHandler) Delete(c *gin.Context) {
 }
 
 // @Summary      Get exercises by muscle group
-// @Description  Get all exercises of a specific muscle group
+// @Description  Get a page of the exercises of a specific muscle group
 // @Tags         exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Muscle Group ID"
-// @Success      200 {array}   models.Ejercicio
-// @Failure      400 {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, nombre, tipo_ejercicio_id, grupo_muscular_id, unilateral, is_custom (default: nombre)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, nombre, tipo_ejercicio_id, grupo_muscular_id, unilateral, is_custom, equipamiento, dificultad"  collectionFormat(multi)
+// @Param        fields   query  string  false  "Comma separated fields to return: id, nombre, tipo_ejercicio_id, grupo_muscular_id, usuario_id, is_custom, descripcion, instrucciones, equipamiento, dificultad, unilateral, imagen_url, video_url"
+// @Param        include  query  string  false  "Comma separated relations to embed: type, muscle_group"
+// @Success      200 {object}  dto.ListResponse[models.Ejercicio]
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
 // @Router       /exercises/muscle-group/{id} [get]
 func (h *ExerciseHandler) GetByMuscleGroup(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if spec.Projection, err = projectionSpec(c, repositories.ExerciseProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Muscle group ID must be a valid number", err))
 		return
 	}
 
-	ejercicios, err := h.uc.GetExercisesByMuscleGroup(uint(id))
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	ejercicios, err := h.uc.GetExercisesByMuscleGroup(uint(id), userID, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.tr.LocalizeExercises(middleware.GetLocale(c), ejercicios.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.localizeIncluded(c, ejercicios.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	h.signMedia(ejercicios.Items...)
+	response, err := sparseList(repositories.ExerciseProjection, spec.Projection, ejercicios)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, ejercicios)
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Upload exercise image
+// @Description  Upload a JPEG, PNG or GIF image for the exercise; a thumbnail is generated and the previous image is replaced
+// @Tags         exercises
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int   true  "Exercise ID"
+// @Param        file  formData  file  true  "Image file"
+// @Success      200  {object}  models.Ejercicio
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID or missing file"
+// @Failure      403  {object}  errors.ProblemDetails "Shared catalog is read-only"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
+// @Failure      413  {object}  errors.ProblemDetails "File too large"
+// @Failure      415  {object}  errors.ProblemDetails "Unsupported media type"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/image [post]
+func (h *ExerciseHandler) UploadImage(c *gin.Context) {
+	h.upload(c, h.uc.UploadExerciseImage)
+}
+
+// @Summary      Upload exercise video
+// @Description  Upload a short MP4 or WebM clip for the exercise, replacing the previous one
+// @Tags         exercises
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int   true  "Exercise ID"
+// @Param        file  formData  file  true  "Video file"
+// @Success      200  {object}  models.Ejercicio
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID or missing file"
+// @Failure      403  {object}  errors.ProblemDetails "Shared catalog is read-only"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
+// @Failure      413  {object}  errors.ProblemDetails "File too large"
+// @Failure      415  {object}  errors.ProblemDetails "Unsupported media type"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/video [post]
+func (h *ExerciseHandler) UploadVideo(c *gin.Context) {
+	h.upload(c, h.uc.UploadExerciseVideo)
+}
+
+// @Summary      Promote custom exercise
+// @Description  Move a user's custom exercise into the shared catalog (admin only)
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Exercise ID"
+// @Success      200  {object}  models.Ejercicio
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      403  {object}  errors.ProblemDetails "Insufficient permissions"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
+// @Failure      409  {object}  errors.ProblemDetails "Exercise is not custom"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/promote [post]
+func (h *ExerciseHandler) Promote(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+
+	ejercicio, err := h.uc.PromoteExercise(uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.signMedia(ejercicio)
+	c.JSON(http.StatusOK, ejercicio)
+}
+
+// @Summary      Get exercise alternatives
+// @Description  Get substitutes for an exercise: admin-curated alternatives first, then exercises sharing its main muscle group ranked by shared muscle group, exercise type and equipment
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path   int  true   "Exercise ID"
+// @Param        limit  query  int  false  "Maximum number of alternatives (default 10, max 50)"
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
+// @Success      200  {array}   models.EjercicioSugerido
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/alternatives [get]
+func (h *ExerciseHandler) GetAlternatives(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+	limit, err := queryUint(c, "limit")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_LIMIT", "limit must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	alternativas, err := h.uc.GetAlternatives(uint(id), userID, int(limit))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	ejercicios := make([]*models.Ejercicio, 0, len(alternativas))
+	for _, a := range alternativas {
+		ejercicios = append(ejercicios, a.Ejercicio)
+	}
+	if err := h.tr.LocalizeExercises(middleware.GetLocale(c), ejercicios...); err != nil {
+		c.Error(err)
+		return
+	}
+	h.signMedia(ejercicios...)
+	c.JSON(http.StatusOK, alternativas)
+}
+
+// @Summary      Add exercise alternative
+// @Description  Curate a substitution between two shared catalog exercises; it applies in both directions (admin only)
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      int                                   true  "Exercise ID"
+// @Param        alternative  body      dto.CreateExerciseAlternativeRequest  true  "Alternative exercise"
+// @Success      201  {object}  models.EjercicioAlternativa "Alternative created"
+// @Success      200  {object}  models.EjercicioAlternativa "Alternative already existed"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID, body or alternative"
+// @Failure      403  {object}  errors.ProblemDetails "Insufficient permissions"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/alternatives [post]
+func (h *ExerciseHandler) AddAlternative(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+
+	var req dto.CreateExerciseAlternativeRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	alternativa, created, err := h.uc.AddAlternative(uint(id), req.AlternativaID, req.Nota)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, alternativa)
+}
+
+// @Summary      Remove exercise alternative
+// @Description  Remove a curated substitution between two exercises (admin only)
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id              path  int  true  "Exercise ID"
+// @Param        alternative_id  path  int  true  "Alternative exercise ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object} errors.ProblemDetails "Insufficient permissions"
+// @Failure      404 {object} errors.ProblemDetails "Alternative not found"
+// @Failure      500 {object} errors.ProblemDetails
+// @Router       /exercises/{id}/alternatives/{alternative_id} [delete]
+func (h *ExerciseHandler) RemoveAlternative(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+	alternativeID, err := strconv.Atoi(c.Param("alternative_id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Alternative exercise ID must be a valid number", err))
+		return
+	}
+
+	if err := h.uc.RemoveAlternative(uint(id), uint(alternativeID)); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// upload reads the multipart "file" field and hands it to the given upload use case
+func (h *ExerciseHandler) upload(c *gin.Context, store func(id, userID, roleID uint, content io.Reader, size int64) (*models.Ejercicio, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+
+	userID, roleID, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "FILE_REQUIRED", "A multipart file field named 'file' is required", err))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_UPLOAD", "Failed to read uploaded file", err))
+		return
+	}
+	defer file.Close()
+
+	ejercicio, err := store(uint(id), userID, roleID, file, fileHeader.Size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	h.signMedia(ejercicio)
+	c.JSON(http.StatusOK, ejercicio)
+}
+
+// localizeIncluded translates the names of the included exercise types and muscle groups
+func (h *ExerciseHandler) localizeIncluded(c *gin.Context, ejercicios ...*models.Ejercicio) error {
+	var tipos []*models.TipoEjercicio
+	var grupos []*models.GrupoMuscular
+	for _, e := range ejercicios {
+		if e.TipoEjercicio != nil {
+			tipos = append(tipos, e.TipoEjercicio)
+		}
+		if e.GrupoMuscular != nil {
+			grupos = append(grupos, e.GrupoMuscular)
+		}
+	}
+	locale := middleware.GetLocale(c)
+	if err := h.tr.LocalizeExerciseTypes(locale, tipos...); err != nil {
+		return err
+	}
+	return h.tr.LocalizeMuscleGroups(locale, grupos...)
+}
+
+// signMedia fills the signed download URLs of uploaded exercise media
+func (h *ExerciseHandler) signMedia(ejercicios ...*models.Ejercicio) {
+	for _, e := range ejercicios {
+		if !e.HasUploadedMedia() {
+			continue
+		}
+		media := &models.EjercicioMedia{}
+		if e.ImagenKey != "" {
+			media.ImagenURL, media.ExpiraEn = h.signer.Sign(e.ImagenKey)
+		}
+		if e.MiniaturaKey != "" {
+			media.MiniaturaURL, media.ExpiraEn = h.signer.Sign(e.MiniaturaKey)
+		}
+		if e.VideoKey != "" {
+			media.VideoURL, media.ExpiraEn = h.signer.Sign(e.VideoKey)
+		}
+		e.Media = media
+	}
 }
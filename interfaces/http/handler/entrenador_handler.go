@@ -0,0 +1,143 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CoachHandler struct {
+	uc *usecase.CoachUsecase
+}
+
+func NewCoachHandler(r gin.IRouter, uc *usecase.CoachUsecase) {
+	h := &CoachHandler{uc}
+
+	// Grouping the caller's coach routes under "me/coaches"
+	coachRoutes := r.Group("/me/coaches")
+	{
+		coachRoutes.GET("", h.GetCoaches)
+		coachRoutes.POST("", h.Grant)
+		coachRoutes.DELETE("/:coach_id", h.Revoke)
+	}
+
+	// Users that authorized the caller as their coach
+	r.GET("/me/clients", h.GetClients)
+}
+
+// @Summary      Get my coaches
+// @Description  Get the coaches the caller authorized to see their private data, such as progress photos
+// @Tags         coaches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.AccesoEntrenador
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/coaches [get]
+func (h *CoachHandler) GetCoaches(c *gin.Context) {
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	accesos, err := h.uc.GetCoaches(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, accesos)
+}
+
+// @Summary      Authorize coach
+// @Description  Authorize the user with the given email as a coach of the caller
+// @Tags         coaches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        coach  body      dto.GrantCoachRequest  true  "Coach email"
+// @Success      201  {object}  models.AccesoEntrenador
+// @Failure      400  {object}  errors.ProblemDetails "Invalid body or own email"
+// @Failure      404  {object}  errors.ProblemDetails "Coach not found"
+// @Failure      409  {object}  errors.ProblemDetails "Coach already authorized"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/coaches [post]
+func (h *CoachHandler) Grant(c *gin.Context) {
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req dto.GrantCoachRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	acceso, err := h.uc.GrantAccess(userID, req.Email)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, acceso)
+}
+
+// @Summary      Revoke coach
+// @Description  Remove the access of a coach to the caller's private data
+// @Tags         coaches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        coach_id  path  int  true  "Coach user ID"
+// @Success      204 "No Content"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Coach not authorized"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/coaches/{coach_id} [delete]
+func (h *CoachHandler) Revoke(c *gin.Context) {
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	coachID, err := strconv.Atoi(c.Param("coach_id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Coach ID must be a valid number", err))
+		return
+	}
+
+	if err := h.uc.RevokeAccess(userID, uint(coachID)); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// @Summary      Get my clients
+// @Description  Get the users that authorized the caller as their coach
+// @Tags         coaches
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   models.AccesoEntrenador
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/clients [get]
+func (h *CoachHandler) GetClients(c *gin.Context) {
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	accesos, err := h.uc.GetClients(userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, accesos)
+}
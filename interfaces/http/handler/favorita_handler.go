@@ -4,8 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	dto "github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -19,159 +19,119 @@ func NewFavoriteHandler(r gin.IRouter, uc *usecase.FavoriteUsecase) {
 
 	favoriteRoutes := r.Group("/favorites")
 	{
-		favoriteRoutes.POST("", h.Create)
-		favoriteRoutes.GET("", h.GetAll)
-		favoriteRoutes.GET("/:id", h.GetByID)
-		favoriteRoutes.GET("/user/:user_id", h.GetByUserID)
-		favoriteRoutes.PUT("/:id", h.Update)
-		favoriteRoutes.DELETE("/:id", h.Delete)
+		favoriteRoutes.GET("", h.GetMine)
+	}
+
+	// Favorites are toggled on the routine itself for the authenticated user
+	routineRoutes := r.Group("/routines")
+	{
+		routineRoutes.PUT("/:id/favorite", h.Add)
+		routineRoutes.DELETE("/:id/favorite", h.Remove)
 	}
 }
 
-// @Summary      Create a new favorite
-// @Description  Create a new favorite in the system
+// @Summary      Favorite a routine
+// @Description  Mark a routine as favorite for the authenticated user. Idempotent: returns 201 when created and 200 when it already existed
 // @Tags         favorites
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        favorite body models.Favorita true "Favorite data"
+// @Param        id   path      int  true  "Routine ID"
+// @Success      200  {object}  models.Favorita
 // @Success      201  {object}  models.Favorita
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
-// @Router       /favorites [post]
-func (h *FavoriteHandler) Create(c *gin.Context) {
-	var favorita models.Favorita
-	if err := c.ShouldBindJSON(&favorita); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      403  {object}  errors.ProblemDetails "Routine is private"
+// @Failure      404  {object}  errors.ProblemDetails "Routine not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /routines/{id}/favorite [put]
+func (h *FavoriteHandler) Add(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Routine ID must be a valid number", err))
 		return
 	}
-	if err := h.uc.Create(&favorita); err != nil {
+
+	userID, err := currentUserID(c)
+	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusCreated, favorita)
-}
 
-// @Summary      Get all favorites
-// @Description  Get a complete list of favorites
-// @Tags         favorites
-// @Accept       json
-// @Produce      json
-// @Security     BearerAuth
-// @Success      200  {array}   models.Favorita
-// @Failure      500  {object}  errors.ErrorResponse
-// @Router       /favorites [get]
-func (h *FavoriteHandler) GetAll(c *gin.Context) {
-	favoritas, err := h.uc.GetAll()
+	favorita, created, err := h.uc.AddFavorite(userID, uint(id))
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, favoritas)
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, favorita)
 }
 
-// @Summary      Get favorite by ID
-// @Description  Get a specific favorite by its ID
+// @Summary      Unfavorite a routine
+// @Description  Remove a routine from the authenticated user's favorites. Idempotent
 // @Tags         favorites
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id   path      int  true  "Favorite ID"
-// @Success      200  {object}  models.Favorita
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Favorite not found"
-// @Router       /favorites/{id} [get]
-func (h *FavoriteHandler) GetByID(c *gin.Context) {
+// @Param        id  path      int  true  "Routine ID"
+// @Success      204 "No Content"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
+// @Router       /routines/{id}/favorite [delete]
+func (h *FavoriteHandler) Remove(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Favorite ID must be a valid number", err))
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Routine ID must be a valid number", err))
 		return
 	}
-	favorita, err := h.uc.GetByID(uint(id))
+
+	userID, err := currentUserID(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, favorita)
-}
 
-// @Summary      Update favorite
-// @Description  Update an existing favorite
-// @Tags         favorites
-// @Accept       json
-// @Produce      json
-// @Security     BearerAuth
-// @Param        id       path   int  true  "Favorite ID"
-// @Param        favorite body   models.Favorita true "Updated favorite data"
-// @Success      200  {object}  models.Favorita
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
-// @Router       /favorites/{id} [put]
-func (h *FavoriteHandler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Favorite ID must be a valid number", err))
-		return
-	}
-	var favorita models.Favorita
-	if err := c.ShouldBindJSON(&favorita); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
-		return
-	}
-	favorita.ID = uint(id)
-	if err := h.uc.Update(&favorita); err != nil {
+	if err := h.uc.RemoveFavorite(userID, uint(id)); err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, favorita)
+	c.Status(http.StatusNoContent)
 }
 
-// @Summary      Delete favorite
-// @Description  Delete a favorite from the system
+// @Summary      Get my favorites
+// @Description  Get the favorite routines of the authenticated user
 // @Tags         favorites
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id  path      int  true  "Favorite ID"
-// @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
-// @Router       /favorites/{id} [delete]
-func (h *FavoriteHandler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, rutina_id, fecha (default: -fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, rutina_id, fecha"  collectionFormat(multi)
+// @Success      200 {object}  dto.ListResponse[models.Favorita]
+// @Failure      400 {object}  errors.ProblemDetails "Invalid query"
+// @Failure      401 {object}  errors.ProblemDetails
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /favorites [get]
+func (h *FavoriteHandler) GetMine(c *gin.Context) {
+	spec, err := listSpec(c)
 	if err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Favorite ID must be a valid number", err))
-		return
-	}
-	if err := h.uc.Delete(uint(id)); err != nil {
 		c.Error(err)
 		return
 	}
-	c.Status(http.StatusNoContent)
-}
-
-// @Summary      Get favorites by user
-// @Description  Get all favorites of a specific user
-// @Tags         favorites
-// @Accept       json
-// @Produce      json
-// @Security     BearerAuth
-// @Param        user_id  path      int  true  "User ID"
-// @Success      200 {array}   models.Favorita
-// @Failure      400 {object}  errors.ErrorResponse "Invalid user ID format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
-// @Router       /favorites/user/{user_id} [get]
-func (h *FavoriteHandler) GetByUserID(c *gin.Context) {
-	userID, err := strconv.Atoi(c.Param("user_id"))
+	userID, err := currentUserID(c)
 	if err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_USER_ID", "User ID must be a valid number", err))
+		c.Error(err)
 		return
 	}
-	favoritas, err := h.uc.GetByUserID(uint(userID))
+
+	favoritas, err := h.uc.GetByUserID(userID, spec)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, favoritas)
+	c.JSON(http.StatusOK, dto.NewListResponse(favoritas))
 }
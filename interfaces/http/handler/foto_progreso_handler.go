@@ -0,0 +1,220 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	dto "github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ProgressPhotoHandler struct {
+	uc *usecase.ProgressPhotoUsecase
+}
+
+// NewProgressPhotoHandler registers the progress photo routes. Photos are private, so they are served
+// through these authenticated routes instead of signed media URLs.
+func NewProgressPhotoHandler(r gin.IRouter, uc *usecase.ProgressPhotoUsecase) {
+	h := &ProgressPhotoHandler{uc}
+
+	// Grouping the photo routes under the measurement they belong to
+	photoRoutes := r.Group("/measurements/:id/photos")
+	{
+		photoRoutes.GET("", h.GetAll)
+		photoRoutes.POST("", h.Upload)
+		photoRoutes.GET("/:photo_id", h.Download)
+		photoRoutes.DELETE("/:photo_id", h.Delete)
+	}
+}
+
+// @Summary      Get progress photos
+// @Description  Get the photos of a measurement; only its owner and their authorized coaches can see them
+// @Tags         measurements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Measurement ID"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, pose, created_at (default: pose)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, pose, created_at"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.FotoProgreso]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404  {object}  errors.ProblemDetails "Measurement not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurements/{id}/photos [get]
+func (h *ProgressPhotoHandler) GetAll(c *gin.Context) {
+	medicionID, ok := measurementIDParam(c)
+	if !ok {
+		return
+	}
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	fotos, err := h.uc.GetPhotos(medicionID, userID, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(fotos))
+}
+
+// @Summary      Upload progress photo
+// @Description  Upload a JPEG or PNG photo of the caller's measurement for a pose, replacing the previous one. EXIF metadata is removed before storing it.
+// @Tags         measurements
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int     true  "Measurement ID"
+// @Param        pose  formData  string  true  "Pose: front, side or back"
+// @Param        file  formData  file    true  "Image file"
+// @Success      201  {object}  models.FotoProgreso
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID, pose, image or missing file"
+// @Failure      403  {object}  errors.ProblemDetails "Only the owner can upload photos"
+// @Failure      404  {object}  errors.ProblemDetails "Measurement not found"
+// @Failure      413  {object}  errors.ProblemDetails "File too large"
+// @Failure      415  {object}  errors.ProblemDetails "Unsupported media type"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurements/{id}/photos [post]
+func (h *ProgressPhotoHandler) Upload(c *gin.Context) {
+	medicionID, ok := measurementIDParam(c)
+	if !ok {
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "FILE_REQUIRED", "A multipart file field named 'file' is required", err))
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_UPLOAD", "Failed to read uploaded file", err))
+		return
+	}
+	defer file.Close()
+
+	foto, err := h.uc.UploadPhoto(medicionID, userID, c.PostForm("pose"), file, fileHeader.Size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, foto)
+}
+
+// @Summary      Download progress photo
+// @Description  Download the image of a progress photo, or its thumbnail; only the owner and their authorized coaches can download it
+// @Tags         measurements
+// @Produce      octet-stream
+// @Security     BearerAuth
+// @Param        id         path   int   true   "Measurement ID"
+// @Param        photo_id   path   int   true   "Photo ID"
+// @Param        thumbnail  query  bool  false  "Download the thumbnail instead of the full image"
+// @Success      200  {file}    file
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Photo not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurements/{id}/photos/{photo_id} [get]
+func (h *ProgressPhotoHandler) Download(c *gin.Context) {
+	medicionID, ok := measurementIDParam(c)
+	if !ok {
+		return
+	}
+	photoID, ok := photoIDParam(c)
+	if !ok {
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	thumbnail, err := queryBool(c, "thumbnail")
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_QUERY", "thumbnail must be true or false", err))
+		return
+	}
+
+	content, contentType, err := h.uc.OpenPhoto(medicionID, photoID, userID, thumbnail != nil && *thumbnail)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, content, map[string]string{
+		"Cache-Control":          "private, no-store",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// @Summary      Delete progress photo
+// @Description  Delete a photo of the caller's measurement
+// @Tags         measurements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path  int  true  "Measurement ID"
+// @Param        photo_id  path  int  true  "Photo ID"
+// @Success      204 "No Content"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      403  {object}  errors.ProblemDetails "Only the owner can delete photos"
+// @Failure      404  {object}  errors.ProblemDetails "Photo not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurements/{id}/photos/{photo_id} [delete]
+func (h *ProgressPhotoHandler) Delete(c *gin.Context) {
+	medicionID, ok := measurementIDParam(c)
+	if !ok {
+		return
+	}
+	photoID, ok := photoIDParam(c)
+	if !ok {
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.uc.DeletePhoto(medicionID, photoID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// measurementIDParam parses the measurement ID of the route, reporting the error when it is invalid
+func measurementIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Measurement ID must be a valid number", err))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// photoIDParam parses the photo ID of the route, reporting the error when it is invalid
+func photoIDParam(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("photo_id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Photo ID must be a valid number", err))
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	"github.com/Diegonr1791/GymBro/interfaces/http/middleware"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
@@ -12,10 +14,11 @@ import (
 
 type GrupoMuscularHandler struct {
 	uc *usecase.GrupoMuscularUseCase
+	tr *usecase.TranslationUsecase
 }
 
-func NewGrupoMuscularHandler(r gin.IRouter, uc *usecase.GrupoMuscularUseCase) {
-	h := &GrupoMuscularHandler{uc}
+func NewGrupoMuscularHandler(r gin.IRouter, uc *usecase.GrupoMuscularUseCase, tr *usecase.TranslationUsecase) {
+	h := &GrupoMuscularHandler{uc, tr}
 
 	// Grouping muscle group routes under "muscle-groups"
 	muscleGroupRoutes := r.Group("/muscle-groups")
@@ -34,19 +37,20 @@ func NewGrupoMuscularHandler(r gin.IRouter, uc *usecase.GrupoMuscularUseCase) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        muscle-group body models.GrupoMuscular true "Muscle group data"
+// @Param        muscle-group body dto.MuscleGroupRequest true "Muscle group data"
 // @Success      201  {object}  models.GrupoMuscular
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /muscle-groups [post]
 func (h *GrupoMuscularHandler) Create(c *gin.Context) {
-	var g models.GrupoMuscular
-	if err := c.ShouldBindJSON(&g); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.MuscleGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.uc.CreateMuscleGroup(&g); err != nil {
+	g := req.ToModel(0)
+	if err := h.uc.CreateMuscleGroup(g); err != nil {
 		c.Error(err)
 		return
 	}
@@ -54,21 +58,41 @@ func (h *GrupoMuscularHandler) Create(c *gin.Context) {
 }
 
 // @Summary      Get all muscle groups
-// @Description  Get a complete list of muscle groups
+// @Description  Get a page of muscle groups
 // @Tags         muscle-groups
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.GrupoMuscular
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, nombre (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, nombre"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.GrupoMuscular]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /muscle-groups [get]
 func (h *GrupoMuscularHandler) GetAll(c *gin.Context) {
-	grupos, err := h.uc.GetAllMuscleGroups()
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	grupos, err := h.uc.GetAllMuscleGroups(spec)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, grupos)
+	localized := make([]*models.GrupoMuscular, 0, len(grupos.Items))
+	for i := range grupos.Items {
+		localized = append(localized, &grupos.Items[i])
+	}
+	if err := h.tr.LocalizeMuscleGroups(middleware.GetLocale(c), localized...); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(grupos))
 }
 
 // @Summary      Get muscle group by ID
@@ -78,9 +102,10 @@ func (h *GrupoMuscularHandler) GetAll(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Muscle Group ID"
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
 // @Success      200  {object}  models.GrupoMuscular
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Muscle group not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Muscle group not found"
 // @Router       /muscle-groups/{id} [get]
 func (h *GrupoMuscularHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -94,6 +119,10 @@ func (h *GrupoMuscularHandler) GetByID(c *gin.Context) {
 		c.Error(err)
 		return
 	}
+	if err := h.tr.LocalizeMuscleGroups(middleware.GetLocale(c), grupo); err != nil {
+		c.Error(err)
+		return
+	}
 	c.JSON(http.StatusOK, grupo)
 }
 
@@ -104,11 +133,11 @@ func (h *GrupoMuscularHandler) GetByID(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id           path   int  true  "Muscle Group ID"
-// @Param        muscle-group body   models.GrupoMuscular true "Updated muscle group data"
+// @Param        muscle-group body   dto.MuscleGroupRequest true "Updated muscle group data"
 // @Success      200  {object}  models.GrupoMuscular
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /muscle-groups/{id} [put]
 func (h *GrupoMuscularHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -117,14 +146,14 @@ func (h *GrupoMuscularHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var g models.GrupoMuscular
-	if err := c.ShouldBindJSON(&g); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.MuscleGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	g.ID = uint(id)
-	if err := h.uc.UpdateMuscleGroup(&g); err != nil {
+	g := req.ToModel(uint(id))
+	if err := h.uc.UpdateMuscleGroup(g); err != nil {
 		c.Error(err)
 		return
 	}
@@ -137,10 +166,15 @@ func (h *GrupoMuscularHandler) Update(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id  path      int  true  "Muscle Group ID"
+// @Param        id           path      int   true   "Muscle Group ID"
+// @Param        cascade      query     bool  false  "Delete the dependents too (admin only); refused when its exercises have user history"
+// @Param        reassign_to  query     int   false  "Move the dependents to this muscle group before deleting (admin only)"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object} errors.ProblemDetails "Insufficient permissions"
+// @Failure      404 {object} errors.ProblemDetails "Muscle group not found"
+// @Failure      409 {object} errors.ProblemDetails "Still referenced, or cascading would delete user history; details lists the dependents"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /muscle-groups/{id} [delete]
 func (h *GrupoMuscularHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -149,7 +183,18 @@ func (h *GrupoMuscularHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.uc.DeleteMuscleGroup(uint(id)); err != nil {
+	opts, err := deleteOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	roleID, err := currentRoleID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.uc.DeleteMuscleGroup(uint(id), roleID, opts); err != nil {
 		c.Error(err)
 		return
 	}
@@ -0,0 +1,325 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	"github.com/Diegonr1791/GymBro/interfaces/http/middleware"
+	"github.com/Diegonr1791/GymBro/internal/auth"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// currentUserID returns the authenticated user's ID from the JWT claims
+func currentUserID(c *gin.Context) (uint, error) {
+	userID, ok := auth.GetUserIDFromContext(c)
+	if !ok || userID <= 0 {
+		return 0, domainErrors.ErrUnauthorized
+	}
+	return uint(userID), nil
+}
+
+// currentRoleID returns the authenticated user's role ID from the JWT claims
+func currentRoleID(c *gin.Context) (uint, error) {
+	roleID, ok := auth.GetRoleIDFromContext(c)
+	if !ok {
+		return 0, domainErrors.ErrUnauthorized
+	}
+	return roleID, nil
+}
+
+// currentActor returns the authenticated user and role IDs, recording the error on the context when missing
+func currentActor(c *gin.Context) (userID, roleID uint, ok bool) {
+	var err error
+	if userID, err = currentUserID(c); err != nil {
+		c.Error(err)
+		return 0, 0, false
+	}
+	if roleID, err = currentRoleID(c); err != nil {
+		c.Error(err)
+		return 0, 0, false
+	}
+	return userID, roleID, true
+}
+
+// queryUint parses an optional unsigned integer query parameter; missing values return 0
+func queryUint(c *gin.Context, key string) (uint, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint(n), nil
+}
+
+// queryBool parses an optional boolean query parameter; missing values return nil
+func queryBool(c *gin.Context, key string) (*bool, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// deleteOptions parses the cascade and reassign_to query parameters of a catalog deletion
+func deleteOptions(c *gin.Context) (repositories.DeleteOptions, error) {
+	var opts repositories.DeleteOptions
+	cascade, err := queryBool(c, "cascade")
+	if err != nil {
+		return opts, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_DELETE_OPTIONS", "cascade must be true or false", err)
+	}
+	if cascade != nil {
+		opts.Cascade = *cascade
+	}
+	if opts.ReassignTo, err = queryUint(c, "reassign_to"); err != nil {
+		return opts, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_DELETE_OPTIONS", "reassign_to must be a valid number", err)
+	}
+	return opts, nil
+}
+
+// queryDate parses an optional date query parameter in 2006-01-02 format; missing values return nil
+func queryDate(c *gin.Context, key string) (*time.Time, error) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_DATE_FORMAT", "Invalid "+key+" date format. Use format: 2006-01-02", err)
+	}
+	return &t, nil
+}
+
+// listSpec parses the pagination, sort and filter query parameters of a list endpoint:
+// limit, cursor, sort=-fecha,id and repeated filter=field:op:value (field:value means eq)
+func listSpec(c *gin.Context) (repositories.QuerySpec, error) {
+	var spec repositories.QuerySpec
+	limit, err := queryUint(c, "limit")
+	if err != nil || limit > repositories.MaxPageLimit {
+		return spec, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_QUERY", fmt.Sprintf("limit must be a number between 1 and %d", repositories.MaxPageLimit), err)
+	}
+	spec.Limit = int(limit)
+	spec.Cursor = c.Query("cursor")
+
+	for _, field := range strings.Split(c.Query("sort"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		desc := strings.HasPrefix(field, "-")
+		spec.Sort = append(spec.Sort, repositories.SortField{Field: strings.TrimPrefix(field, "-"), Desc: desc})
+	}
+
+	for _, raw := range c.QueryArray("filter") {
+		parts := strings.SplitN(raw, ":", 3)
+		switch len(parts) {
+		case 2:
+			spec.Filters = append(spec.Filters, repositories.Filter{Field: parts[0], Op: repositories.FilterEq, Value: parts[1]})
+		case 3:
+			spec.Filters = append(spec.Filters, repositories.Filter{Field: parts[0], Op: parts[1], Value: parts[2]})
+		default:
+			return spec, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_QUERY", "filter must be field:op:value", nil)
+		}
+	}
+	return spec, nil
+}
+
+// projectionSpec parses the comma separated fields and include query parameters and checks them against
+// the whitelist of the resource
+func projectionSpec(c *gin.Context, ps repositories.ProjectionSchema) (repositories.Projection, error) {
+	proj := repositories.Projection{Fields: queryList(c, "fields"), Include: queryList(c, "include")}
+	if err := ps.Validate(proj); err != nil {
+		return proj, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_QUERY", err.Error(), nil)
+	}
+	return proj, nil
+}
+
+// sparse removes from the JSON of the item the whitelisted fields the projection did not request;
+// the id, the relations and the computed fields are kept
+func sparse(ps repositories.ProjectionSchema, proj repositories.Projection, item any) (any, error) {
+	if len(proj.Fields) == 0 {
+		return item, nil
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	for _, field := range ps.Fields {
+		if field != "id" && !slices.Contains(proj.Fields, field) {
+			delete(object, field)
+		}
+	}
+	return object, nil
+}
+
+// sparseList builds the list response of a page, applying sparse to every item
+func sparseList[T any](ps repositories.ProjectionSchema, proj repositories.Projection, page *repositories.Page[T]) (any, error) {
+	if len(proj.Fields) == 0 {
+		return dto.NewListResponse(page), nil
+	}
+	items := make([]any, 0, len(page.Items))
+	for _, item := range page.Items {
+		object, err := sparse(ps, proj, item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, object)
+	}
+	return dto.ListResponse[any]{Items: items, NextCursor: page.NextCursor, Total: page.Total}, nil
+}
+
+// queryList splits a comma separated query parameter, skipping empty values
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Query(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// requestUnits returns the units of the response values: the unit query parameter wins over the caller's preference
+func requestUnits(c *gin.Context) (models.Unidades, error) {
+	return withUnit(middleware.GetUnits(c), c.Query("unit"))
+}
+
+// bindJSON binds and validates the JSON body of a request DTO. Validation failures are reported as
+// VALIDATION_FAILED, with the invalid fields listed by the error handler.
+func bindJSON(c *gin.Context, obj any) error {
+	return bindError(c.ShouldBindJSON(obj))
+}
+
+func bindError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return domainErrors.NewAppError(domainErrors.ErrValidation.HTTPStatus, domainErrors.ErrValidation.Code, domainErrors.ErrValidation.Message, err)
+	}
+	return domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+}
+
+// bindJSONWithUnits binds the JSON body and returns the units its values are expressed in: the body's
+// unit field wins over the unit query parameter and the caller's preference
+func bindJSONWithUnits(c *gin.Context, obj any) (models.Unidades, error) {
+	if err := c.ShouldBindBodyWith(obj, binding.JSON); err != nil {
+		return models.Unidades{}, bindError(err)
+	}
+	var body struct {
+		Unit string `json:"unit"`
+	}
+	if err := c.ShouldBindBodyWith(&body, binding.JSON); err != nil {
+		return models.Unidades{}, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+	}
+	unidades, err := requestUnits(c)
+	if err != nil {
+		return unidades, err
+	}
+	return withUnit(unidades, body.Unit)
+}
+
+// mergePatchContentType is the media type of RFC 7396 JSON merge patches
+const mergePatchContentType = "application/merge-patch+json"
+
+// bindMergePatch reads an RFC 7396 merge patch into req, a request DTO, and validates the members the patch
+// sets with the rules of the DTO. Members left out of the patch keep their current value and are not validated.
+func bindMergePatch(c *gin.Context, req any) (usecase.MergePatch, error) {
+	if ct := c.ContentType(); ct != mergePatchContentType && ct != binding.MIMEJSON {
+		return nil, domainErrors.NewAppError(http.StatusUnsupportedMediaType, "INVALID_CONTENT_TYPE", "PATCH bodies must be application/merge-patch+json", nil)
+	}
+	body, err := c.GetRawData()
+	if err != nil {
+		return nil, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+	}
+	patch, err := usecase.ParseMergePatch(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, req); err != nil {
+		return nil, bindError(err)
+	}
+
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return patch, nil
+	}
+	var absent []string
+	t := reflect.TypeOf(req).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if !patch.Has(name) {
+			absent = append(absent, t.Field(i).Name)
+		}
+	}
+	if err := v.StructExcept(req, absent...); err != nil {
+		return nil, bindError(err)
+	}
+	return patch, nil
+}
+
+// patchUnits removes the unit member of a patch and returns the units its values are expressed in:
+// the unit member wins over the unit query parameter and the caller's preference
+func patchUnits(c *gin.Context, patch usecase.MergePatch, unit string) (models.Unidades, error) {
+	delete(patch, "unit")
+	unidades, err := requestUnits(c)
+	if err != nil {
+		return unidades, err
+	}
+	return withUnit(unidades, unit)
+}
+
+// patchValues replaces the members set by the patch with their value in model, the patch mapped to a
+// model and converted to SI units
+func patchValues(patch usecase.MergePatch, model any) error {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	for field := range patch {
+		if value, ok := object[field]; ok && !patch.Clears(field) {
+			patch[field] = value
+		}
+	}
+	return nil
+}
+
+func withUnit(unidades models.Unidades, unit string) (models.Unidades, error) {
+	if unit == "" {
+		return unidades, nil
+	}
+	unidades, ok := unidades.WithUnit(unit)
+	if !ok {
+		return unidades, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_UNIT", "unit must be one of: kg, lb, cm, in, metric, imperial", nil)
+	}
+	return unidades, nil
+}
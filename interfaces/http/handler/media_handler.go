@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Diegonr1791/GymBro/internal/auth"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type MediaHandler struct {
+	uc     *usecase.MediaUsecase
+	signer *auth.URLSigner
+}
+
+// NewMediaHandler registers the media download route; access is granted by the URL signature
+func NewMediaHandler(r gin.IRouter, uc *usecase.MediaUsecase, signer *auth.URLSigner) {
+	h := &MediaHandler{uc, signer}
+
+	// Grouping media routes under "media"
+	mediaRoutes := r.Group("/media")
+	{
+		mediaRoutes.GET("/*key", h.Download)
+	}
+}
+
+// @Summary      Download media
+// @Description  Download an uploaded file using a signed URL returned by the API
+// @Tags         media
+// @Produce      octet-stream
+// @Param        key        path   string  true  "Media key"
+// @Param        expires    query  int     true  "Expiration (unix seconds)"
+// @Param        signature  query  string  true  "URL signature"
+// @Success      200  {file}    file
+// @Failure      403  {object}  errors.ProblemDetails "Invalid or expired signature"
+// @Failure      404  {object}  errors.ProblemDetails "Media not found"
+// @Router       /media/{key} [get]
+func (h *MediaHandler) Download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	if !h.signer.Verify(key, c.Query("expires"), c.Query("signature")) {
+		c.Error(domainErrors.NewAppError(http.StatusForbidden, "INVALID_SIGNATURE", "The download URL is invalid or has expired", nil))
+		return
+	}
+
+	content, contentType, err := h.uc.Open(key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, -1, contentType, content, map[string]string{
+		"Cache-Control":          "private, max-age=300",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
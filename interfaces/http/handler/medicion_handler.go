@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
@@ -23,51 +24,107 @@ func NewMeasurementHandler(r gin.IRouter, uc *usecase.MeasurementUsecase) {
 		measurementRoutes.GET("", h.GetAll)
 		measurementRoutes.GET("/:id", h.GetByID)
 		measurementRoutes.PUT("/:id", h.Update)
+		measurementRoutes.PATCH("/:id", h.Patch)
 		measurementRoutes.DELETE("/:id", h.Delete)
 		measurementRoutes.GET("/user/:user_id", h.GetByUserID)
 	}
+
+	// Grouping the caller's measurement routes under "me/measurements"
+	myMeasurementRoutes := r.Group("/me/measurements")
+	{
+		myMeasurementRoutes.GET("/summary", h.GetSummary)
+	}
+
+	// Grouping the measurement metric routes under "measurement-metrics"
+	metricRoutes := r.Group("/measurement-metrics")
+	{
+		metricRoutes.GET("", h.GetMetrics)
+		metricRoutes.POST("", h.CreateMetric)
+		metricRoutes.DELETE("/:id", h.DeleteMetric)
+	}
 }
 
 // @Summary      Create a new measurement
-// @Description  Create a new measurement in the system
+// @Description  Create a new measurement in the system. Metric values go in "valores" referencing the metric by ID or key; peso_corporal, grasa_corporal and musculo are kept in sync with their standard metrics.
 // @Tags         measurements
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        measurement body models.Medicion true "Measurement data"
+// @Param        measurement body dto.MeasurementRequest true "Measurement data"
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
 // @Success      201  {object}  models.Medicion
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /measurements [post]
 func (h *MeasurementHandler) Create(c *gin.Context) {
-	var medicion models.Medicion
-	if err := c.ShouldBindJSON(&medicion); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.MeasurementRequest
+	entrada, err := bindJSONWithUnits(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	if err := h.uc.Create(&medicion); err != nil {
+
+	// Measurements always belong to the caller
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	medicion := req.ToModel(0)
+	medicion.UsuarioID = userID
+	if err := h.measurementToSI(medicion, entrada); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.uc.Create(medicion); err != nil {
 		c.Error(err)
 		return
 	}
+	medicion.FromSI(salida)
 	c.JSON(http.StatusCreated, medicion)
 }
 
 // @Summary      Get all measurements
-// @Description  Get a complete list of measurements
+// @Description  Get a page of measurements; weight filters are in kg
 // @Tags         measurements
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.Medicion
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: fecha, peso_corporal, grasa_corporal, musculo, usuario_id, id (default: -fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: fecha, peso_corporal, grasa_corporal, musculo, usuario_id, id"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.Medicion]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /measurements [get]
 func (h *MeasurementHandler) GetAll(c *gin.Context) {
-	mediciones, err := h.uc.GetAll()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, mediciones)
+
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	mediciones, err := h.uc.GetAll(spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range mediciones.Items {
+		mediciones.Items[i].FromSI(salida)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(mediciones))
 }
 
 // @Summary      Get measurement by ID
@@ -77,9 +134,10 @@ func (h *MeasurementHandler) GetAll(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Measurement ID"
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
 // @Success      200  {object}  models.Medicion
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Measurement not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Measurement not found"
 // @Router       /measurements/{id} [get]
 func (h *MeasurementHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -87,11 +145,17 @@ func (h *MeasurementHandler) GetByID(c *gin.Context) {
 		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Measurement ID must be a valid number", err))
 		return
 	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
 	medicion, err := h.uc.GetByID(uint(id))
 	if err != nil {
 		c.Error(err)
 		return
 	}
+	medicion.FromSI(salida)
 	c.JSON(http.StatusOK, medicion)
 }
 
@@ -102,11 +166,12 @@ func (h *MeasurementHandler) GetByID(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id          path   int  true  "Measurement ID"
-// @Param        measurement body   models.Medicion true "Updated measurement data"
+// @Param        measurement body   dto.MeasurementRequest true "Updated measurement data"
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
 // @Success      200  {object}  models.Medicion
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /measurements/{id} [put]
 func (h *MeasurementHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -114,16 +179,100 @@ func (h *MeasurementHandler) Update(c *gin.Context) {
 		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Measurement ID must be a valid number", err))
 		return
 	}
-	var medicion models.Medicion
-	if err := c.ShouldBindJSON(&medicion); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.MeasurementRequest
+	entrada, err := bindJSONWithUnits(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Measurements always belong to the caller
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	medicion := req.ToModel(uint(id))
+	medicion.UsuarioID = userID
+	if err := h.measurementToSI(medicion, entrada); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.uc.Update(medicion); err != nil {
+		c.Error(err)
+		return
+	}
+	medicion.FromSI(salida)
+	c.JSON(http.StatusOK, medicion)
+}
+
+// @Summary      Patch measurement
+// @Description  Partially update a measurement with a JSON merge patch (RFC 7396): only the fields present are changed and valores replaces all the values
+// @Tags         measurements
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path   int  true  "Measurement ID"
+// @Param        measurement body   dto.MeasurementRequest true "Fields to change"
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.Medicion
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      415  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurements/{id} [patch]
+func (h *MeasurementHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Measurement ID must be a valid number", err))
+		return
+	}
+	var req dto.MeasurementRequest
+	patch, err := bindMergePatch(c, &req)
+	if err != nil {
+		c.Error(err)
 		return
 	}
-	medicion.ID = uint(id)
-	if err := h.uc.Update(&medicion); err != nil {
+	entrada, err := patchUnits(c, patch, req.Unit)
+	if err != nil {
 		c.Error(err)
 		return
 	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if !entrada.IsSI() {
+		userID, err := currentUserID(c)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		patched := req.ToModel(uint(id))
+		patched.UsuarioID = userID
+		if err := h.measurementToSI(patched, entrada); err != nil {
+			c.Error(err)
+			return
+		}
+		if err := patchValues(patch, patched); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	medicion, err := h.uc.Patch(uint(id), patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	medicion.FromSI(salida)
 	c.JSON(http.StatusOK, medicion)
 }
 
@@ -135,8 +284,8 @@ func (h *MeasurementHandler) Update(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Measurement ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /measurements/{id} [delete]
 func (h *MeasurementHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -152,26 +301,229 @@ func (h *MeasurementHandler) Delete(c *gin.Context) {
 }
 
 // @Summary      Get measurements by user
-// @Description  Get all measurements of a specific user
+// @Description  Get a page of the measurements of a specific user; weight filters are in kg
 // @Tags         measurements
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        user_id  path      int  true  "User ID"
-// @Success      200 {array}   models.Medicion
-// @Failure      400 {object}  errors.ErrorResponse "Invalid user ID format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: fecha, peso_corporal, grasa_corporal, musculo, usuario_id, id (default: -fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: fecha, peso_corporal, grasa_corporal, musculo, usuario_id, id"  collectionFormat(multi)
+// @Success      200 {object}  dto.ListResponse[models.Medicion]
+// @Failure      400 {object}  errors.ProblemDetails "Invalid user ID format or query"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
 // @Router       /measurements/user/{user_id} [get]
 func (h *MeasurementHandler) GetByUserID(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	userID, err := strconv.Atoi(c.Param("user_id"))
 	if err != nil {
 		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_USER_ID", "User ID must be a valid number", err))
 		return
 	}
-	mediciones, err := h.uc.GetByUserID(uint(userID))
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	mediciones, err := h.uc.GetByUserID(uint(userID), spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range mediciones.Items {
+		mediciones.Items[i].FromSI(salida)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(mediciones))
+}
+
+// @Summary      Get my body composition summary
+// @Description  Get the minimum, maximum, change and weekly trend of the weight, body fat, muscle, lean mass and fat mass between two dates, with the weight smoothed by a 7-day moving average. BMI and FFMI require the height on the profile.
+// @Tags         measurements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        from  query  string  false  "Start date (format: 2006-01-02, default: six months before to)"
+// @Param        to    query  string  false  "End date, inclusive (format: 2006-01-02, default: today)"
+// @Param        unit  query  string  false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.ResumenMediciones
+// @Failure      400  {object}  errors.ProblemDetails "Invalid dates"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/measurements/summary [get]
+func (h *MeasurementHandler) GetSummary(c *gin.Context) {
+	from, err := queryDate(c, "from")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	to, err := queryDate(c, "to")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	resumen, err := h.uc.GetSummary(userID, from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resumen.ConvertWeights(salida.WeightFromSI)
+	c.JSON(http.StatusOK, resumen)
+}
+
+// @Summary      Get measurement metrics
+// @Description  Get the standard measurement metrics (weight, body fat, muscle, circumferences, resting heart rate) and the caller's custom metrics
+// @Tags         measurements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unit    query  string    false  "Units of the response: kg, lb, cm, in, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, clave, nombre, unidad (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, clave, nombre, unidad"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.MetricaMedicion]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid query"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurement-metrics [get]
+func (h *MeasurementHandler) GetMetrics(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	metricas, err := h.uc.GetMetrics(userID, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range metricas.Items {
+		metricas.Items[i].Unidad = salida.Display(metricas.Items[i].Unidad)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(metricas))
+}
+
+// @Summary      Create custom measurement metric
+// @Description  Create a metric with its unit for the caller; the key defaults to the name in snake case
+// @Tags         measurements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        metric  body      dto.CreateMeasurementMetricRequest  true  "Metric data"
+// @Success      201  {object}  models.MetricaMedicion
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails "Metric key already exists"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurement-metrics [post]
+func (h *MeasurementHandler) CreateMetric(c *gin.Context) {
+	var req dto.CreateMeasurementMetricRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	salida, err := requestUnits(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, mediciones)
+
+	metrica := models.MetricaMedicion{Nombre: req.Nombre, Unidad: req.Unidad, Clave: req.Clave}
+	if err := h.uc.CreateMetric(userID, &metrica); err != nil {
+		c.Error(err)
+		return
+	}
+	metrica.Unidad = salida.Display(metrica.Unidad)
+	c.JSON(http.StatusCreated, metrica)
+}
+
+// @Summary      Delete custom measurement metric
+// @Description  Delete a custom metric of the caller along with its values in every measurement
+// @Tags         measurements
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Metric ID"
+// @Success      204 "No Content"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID or standard metric"
+// @Failure      404  {object}  errors.ProblemDetails "Metric not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /measurement-metrics/{id} [delete]
+func (h *MeasurementHandler) DeleteMetric(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Metric ID must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.uc.DeleteMetric(userID, uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// measurementToSI converts a measurement sent in the given units, looking up the canonical unit of the
+// metric of every value
+func (h *MeasurementHandler) measurementToSI(m *models.Medicion, unidades models.Unidades) error {
+	if unidades.IsSI() {
+		return nil
+	}
+	metricas, err := h.uc.GetAllMetrics(m.UsuarioID)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]string, len(metricas))
+	byKey := make(map[string]string, len(metricas))
+	for _, metrica := range metricas {
+		byID[metrica.ID] = metrica.Unidad
+		// Standard metrics come first and win over custom ones with the same key
+		if _, ok := byKey[metrica.Clave]; !ok {
+			byKey[metrica.Clave] = metrica.Unidad
+		}
+	}
+	m.ToSI(unidades, func(v models.ValorMedicion) string {
+		if v.MetricaID != 0 {
+			return byID[v.MetricaID]
+		}
+		return byKey[v.Clave]
+	})
+	return nil
 }
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type GoalHandler struct {
+	uc *usecase.GoalUsecase
+}
+
+func NewGoalHandler(r gin.IRouter, uc *usecase.GoalUsecase) {
+	h := &GoalHandler{uc}
+
+	// Grouping the caller's goal routes under "me/goals"
+	goalRoutes := r.Group("/me/goals")
+	{
+		goalRoutes.GET("", h.GetAll)
+		goalRoutes.POST("", h.Create)
+		goalRoutes.GET("/:id", h.GetByID)
+		goalRoutes.DELETE("/:id", h.Delete)
+	}
+}
+
+// @Summary      Get my goals
+// @Description  Get the caller's goals with their current value, progress, weekly trend, projected completion date and status (on_track, behind, achieved or no_data)
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unit    query  string    false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, tipo, objetivo, created_at (default: -created_at)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, tipo, ejercicio_id, objetivo, fecha_limite, created_at"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.Meta]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid query"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/goals [get]
+func (h *GoalHandler) GetAll(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	metas, err := h.uc.GetGoals(userID, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range metas.Items {
+		metas.Items[i].ConvertWeights(salida.WeightFromSI)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(metas))
+}
+
+// @Summary      Create goal
+// @Description  Set a target body weight, body fat or exercise one-rep max, optionally with a deadline; the current value becomes its starting point
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        goal  body   dto.CreateGoalRequest  true   "Goal data"
+// @Param        unit  query  string                 false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Success      201  {object}  models.Meta
+// @Failure      400  {object}  errors.ProblemDetails "Invalid type, target, exercise or deadline"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/goals [post]
+func (h *GoalHandler) Create(c *gin.Context) {
+	var req dto.CreateGoalRequest
+	entrada, err := bindJSONWithUnits(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	meta := models.Meta{
+		Tipo:        req.Tipo,
+		EjercicioID: req.EjercicioID,
+		Objetivo:    req.Objetivo,
+		FechaLimite: req.FechaLimite,
+	}
+	meta.ConvertWeights(entrada.WeightToSI)
+	if err := h.uc.CreateGoal(userID, &meta); err != nil {
+		c.Error(err)
+		return
+	}
+	meta.ConvertWeights(salida.WeightFromSI)
+	c.JSON(http.StatusCreated, meta)
+}
+
+// @Summary      Get goal by ID
+// @Description  Get one of the caller's goals with its progress
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path   int     true   "Goal ID"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.Meta
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Goal not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/goals/{id} [get]
+func (h *GoalHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Goal ID must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	meta, err := h.uc.GetGoal(userID, uint(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	meta.ConvertWeights(salida.WeightFromSI)
+	c.JSON(http.StatusOK, meta)
+}
+
+// @Summary      Delete goal
+// @Description  Delete one of the caller's goals
+// @Tags         goals
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path  int  true  "Goal ID"
+// @Success      204 "No Content"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Goal not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/goals/{id} [delete]
+func (h *GoalHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Goal ID must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.uc.DeleteGoal(userID, uint(id)); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	dto "github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PersonalRecordHandler struct {
+	uc *usecase.PersonalRecordUsecase
+}
+
+func NewPersonalRecordHandler(r gin.IRouter, uc *usecase.PersonalRecordUsecase) {
+	h := &PersonalRecordHandler{uc}
+
+	// Grouping the caller's personal record routes under "me/records"
+	recordRoutes := r.Group("/me/records")
+	{
+		recordRoutes.GET("", h.GetCurrent)
+		recordRoutes.GET("/:exercise_id/history", h.GetHistory)
+	}
+}
+
+// @Summary      Get my personal records
+// @Description  Get the caller's standing personal records: heaviest weight, best estimated 1RM and best volume per exercise, and most reps per exercise and weight
+// @Tags         records
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unit    query  string    false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, ejercicio_id, tipo, valor, peso, repeticiones, sesion_id, fecha (default: ejercicio_id,tipo,-peso)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, ejercicio_id, tipo, valor, peso, repeticiones, sesion_id, fecha"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.RecordPersonal]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid query"
+// @Failure      401  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/records [get]
+func (h *PersonalRecordHandler) GetCurrent(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	records, err := h.uc.GetCurrentRecords(userID, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range records.Items {
+		records.Items[i].ConvertWeights(salida.WeightFromSI)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(records))
+}
+
+// @Summary      Get my record history for an exercise
+// @Description  Get the personal records the caller set on an exercise, oldest first by default; each entry links to the session where it was set
+// @Tags         records
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        exercise_id  path      int  true  "Exercise ID"
+// @Param        unit    query  string    false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, ejercicio_id, tipo, valor, peso, repeticiones, sesion_id, fecha (default: fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, ejercicio_id, tipo, valor, peso, repeticiones, sesion_id, fecha"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.RecordPersonal]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /me/records/{exercise_id}/history [get]
+func (h *PersonalRecordHandler) GetHistory(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	exerciseID, err := strconv.Atoi(c.Param("exercise_id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Exercise ID must be a valid number", err))
+		return
+	}
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	records, err := h.uc.GetRecordHistory(userID, uint(exerciseID), spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range records.Items {
+		records.Items[i].ConvertWeights(salida.WeightFromSI)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(records))
+}
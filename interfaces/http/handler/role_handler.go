@@ -5,7 +5,8 @@ import (
 	"net/http"
 	"strconv"
 
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	usecase "github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -51,21 +52,22 @@ func NewRoleHandler(r gin.IRouter, roleUseCase *usecase.RoleUseCase) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param role body models.Role true "Role object"
+// @Param role body dto.RoleRequest true "Role data"
 // @Success 201 {object} models.Role
-// @Failure 400 {object} errors.ErrorResponse
-// @Failure 409 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 400 {object} errors.ProblemDetails
+// @Failure 409 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles [post]
 func (h *RoleHandler) CreateRole(c *gin.Context) {
-	var role models.Role
-	if err := c.ShouldBindJSON(&role); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var req dto.RoleRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.roleUseCase.CreateRole(c.Request.Context(), &role); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	role := req.ToModel(0)
+	if err := h.roleUseCase.CreateRole(c.Request.Context(), role); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -80,20 +82,20 @@ func (h *RoleHandler) CreateRole(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Role ID"
 // @Success 200 {object} models.Role
-// @Failure 404 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/{id} [get]
 func (h *RoleHandler) GetRoleByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Role ID must be a valid number", err))
 		return
 	}
 
 	role, err := h.roleUseCase.GetRoleByID(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		c.Error(err)
 		return
 	}
 
@@ -108,19 +110,19 @@ func (h *RoleHandler) GetRoleByID(c *gin.Context) {
 // @Security BearerAuth
 // @Param name path string true "Role name"
 // @Success 200 {object} models.Role
-// @Failure 404 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 404 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/name/{name} [get]
 func (h *RoleHandler) GetRoleByName(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Role name is required"})
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "ROLE_NAME_REQUIRED", "Role name is required", nil))
 		return
 	}
 
 	role, err := h.roleUseCase.GetRoleByName(c.Request.Context(), name)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		c.Error(err)
 		return
 	}
 
@@ -129,40 +131,62 @@ func (h *RoleHandler) GetRoleByName(c *gin.Context) {
 
 // GetAllRoles handles GET /roles
 // @Summary Get all active roles
-// @Description Get all active roles in the system
+// @Description Get a page of active roles in the system
 // @Tags roles
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Role
-// @Failure 500 {object} errors.ErrorResponse
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - prefix for descending: id, name, priority, is_active, is_system, is_deleted, created_at (default: -priority)"
+// @Param filter query []string false "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, name, priority, is_active, is_system, is_deleted, created_at" collectionFormat(multi)
+// @Success 200 {object} dto.ListResponse[models.Role]
+// @Failure 400 {object} errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles [get]
 func (h *RoleHandler) GetAllRoles(c *gin.Context) {
-	roles, err := h.roleUseCase.GetAllRoles(c.Request.Context())
+	spec, err := listSpec(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
+	roles, err := h.roleUseCase.GetAllRoles(c.Request.Context(), spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewListResponse(roles))
 }
 
 // GetAllRolesWithDeleted handles GET /roles/all
 // @Summary Get all roles including deleted ones
-// @Description Get all roles in the system including deleted ones
+// @Description Get a page of roles in the system including deleted ones
 // @Tags roles
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Role
-// @Failure 500 {object} errors.ErrorResponse
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - prefix for descending: id, name, priority, is_active, is_system, is_deleted, created_at (default: -priority)"
+// @Param filter query []string false "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, name, priority, is_active, is_system, is_deleted, created_at" collectionFormat(multi)
+// @Success 200 {object} dto.ListResponse[models.Role]
+// @Failure 400 {object} errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/all [get]
 func (h *RoleHandler) GetAllRolesWithDeleted(c *gin.Context) {
-	roles, err := h.roleUseCase.GetAllRolesWithDeleted(c.Request.Context())
+	spec, err := listSpec(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, roles)
+	roles, err := h.roleUseCase.GetAllRolesWithDeleted(c.Request.Context(), spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewListResponse(roles))
 }
 
 // UpdateRole handles PUT /roles/:id
@@ -173,30 +197,30 @@ func (h *RoleHandler) GetAllRolesWithDeleted(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Role ID"
-// @Param role body models.Role true "Role object"
+// @Param role body dto.RoleRequest true "Role data"
 // @Success 200 {object} models.Role
-// @Failure 400 {object} errors.ErrorResponse
-// @Failure 404 {object} errors.ErrorResponse
-// @Failure 409 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 400 {object} errors.ProblemDetails
+// @Failure 404 {object} errors.ProblemDetails
+// @Failure 409 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/{id} [put]
 func (h *RoleHandler) UpdateRole(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Role ID must be a valid number", err))
 		return
 	}
 
-	var role models.Role
-	if err := c.ShouldBindJSON(&role); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	var req dto.RoleRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	role.ID = uint(id)
-	if err := h.roleUseCase.UpdateRole(c.Request.Context(), &role); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	role := req.ToModel(uint(id))
+	if err := h.roleUseCase.UpdateRole(c.Request.Context(), role); err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -211,20 +235,20 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Role ID"
 // @Success 200 {object} MessageResponse
-// @Failure 400 {object} errors.ErrorResponse
-// @Failure 404 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 400 {object} errors.ProblemDetails
+// @Failure 404 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/{id} [delete]
 func (h *RoleHandler) SoftDeleteRole(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Role ID must be a valid number", err))
 		return
 	}
 
 	if err := h.roleUseCase.SoftDeleteRole(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -239,20 +263,20 @@ func (h *RoleHandler) SoftDeleteRole(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Role ID"
 // @Success 200 {object} MessageResponse
-// @Failure 400 {object} errors.ErrorResponse
-// @Failure 404 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 400 {object} errors.ProblemDetails
+// @Failure 404 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/{id}/hard [delete]
 func (h *RoleHandler) HardDeleteRole(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Role ID must be a valid number", err))
 		return
 	}
 
 	if err := h.roleUseCase.HardDeleteRole(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -267,20 +291,20 @@ func (h *RoleHandler) HardDeleteRole(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Role ID"
 // @Success 200 {object} MessageResponse
-// @Failure 400 {object} errors.ErrorResponse
-// @Failure 404 {object} errors.ErrorResponse
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 400 {object} errors.ProblemDetails
+// @Failure 404 {object} errors.ProblemDetails
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/{id}/restore [post]
 func (h *RoleHandler) RestoreRole(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Role ID must be a valid number", err))
 		return
 	}
 
 	if err := h.roleUseCase.RestoreRole(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -294,12 +318,12 @@ func (h *RoleHandler) RestoreRole(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {array} models.Role
-// @Failure 500 {object} errors.ErrorResponse
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/system [get]
 func (h *RoleHandler) GetSystemRoles(c *gin.Context) {
 	roles, err := h.roleUseCase.GetSystemRoles(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -308,17 +332,22 @@ func (h *RoleHandler) GetSystemRoles(c *gin.Context) {
 
 // GetActiveRoles handles GET /roles/active
 // @Summary Get all active roles
-// @Description Get all active roles in the system
+// @Description Get a page of active roles in the system
 // @Tags roles
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {array} models.Role
-// @Failure 500 {object} errors.ErrorResponse
+// @Param limit query int false "Page size (default 20, max 100)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param sort query string false "Comma separated fields, - prefix for descending: id, name, priority, is_active, is_system, is_deleted, created_at (default: -priority)"
+// @Param filter query []string false "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, name, priority, is_active, is_system, is_deleted, created_at" collectionFormat(multi)
+// @Success 200 {object} dto.ListResponse[models.Role]
+// @Failure 400 {object} errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure 500 {object} errors.ProblemDetails
 // @Router /roles/active [get]
 func (h *RoleHandler) GetActiveRoles(c *gin.Context) {
 	roles, err := h.roleUseCase.GetActiveRoles(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
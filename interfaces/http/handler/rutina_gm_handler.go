@@ -4,8 +4,8 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -34,19 +34,20 @@ func NewRoutineMuscleGroupHandler(router gin.IRouter, uc *usecase.RoutineMuscleG
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        routine_muscle_group body models.RutinaGrupoMuscular true "Routine muscle group data"
+// @Param        routine_muscle_group body dto.RoutineMuscleGroupRequest true "Routine muscle group data"
 // @Success      201  {object}  models.RutinaGrupoMuscular
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /routine-muscle-groups [post]
 func (h *RoutineMuscleGroupHandler) Create(c *gin.Context) {
-	var rutinaGM models.RutinaGrupoMuscular
-	if err := c.ShouldBindJSON(&rutinaGM); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.RoutineMuscleGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.usecase.Create(&rutinaGM); err != nil {
+	rutinaGM := req.ToModel(0)
+	if err := h.usecase.Create(rutinaGM); err != nil {
 		c.Error(err)
 		return
 	}
@@ -55,22 +56,33 @@ func (h *RoutineMuscleGroupHandler) Create(c *gin.Context) {
 }
 
 // @Summary      Get all routine muscle groups
-// @Description  Get a complete list of routine muscle groups
+// @Description  Get a page of routine muscle groups
 // @Tags         routine-muscle-groups
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.RutinaGrupoMuscular
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, rutina_id, grupo_muscular_id (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, rutina_id, grupo_muscular_id"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.RutinaGrupoMuscular]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /routine-muscle-groups [get]
 func (h *RoutineMuscleGroupHandler) GetAll(c *gin.Context) {
-	rutinasGM, err := h.usecase.GetAll()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, rutinasGM)
+	rutinasGM, err := h.usecase.GetAll(spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewListResponse(rutinasGM))
 }
 
 // @Summary      Get routine muscle group by ID
@@ -81,8 +93,8 @@ func (h *RoutineMuscleGroupHandler) GetAll(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Routine muscle group ID"
 // @Success      200  {object}  models.RutinaGrupoMuscular
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Routine muscle group not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Routine muscle group not found"
 // @Router       /routine-muscle-groups/{id} [get]
 func (h *RoutineMuscleGroupHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -107,11 +119,11 @@ func (h *RoutineMuscleGroupHandler) GetByID(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id                    path   int  true  "Routine muscle group ID"
-// @Param        routine_muscle_group  body   models.RutinaGrupoMuscular true "Updated routine muscle group data"
+// @Param        routine_muscle_group  body   dto.RoutineMuscleGroupRequest true "Updated routine muscle group data"
 // @Success      200  {object}  models.RutinaGrupoMuscular
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /routine-muscle-groups/{id} [put]
 func (h *RoutineMuscleGroupHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -120,14 +132,14 @@ func (h *RoutineMuscleGroupHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var rutinaGM models.RutinaGrupoMuscular
-	if err := c.ShouldBindJSON(&rutinaGM); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.RoutineMuscleGroupRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	rutinaGM.ID = uint(id)
-	if err := h.usecase.Update(&rutinaGM); err != nil {
+	rutinaGM := req.ToModel(uint(id))
+	if err := h.usecase.Update(rutinaGM); err != nil {
 		c.Error(err)
 		return
 	}
@@ -143,8 +155,8 @@ func (h *RoutineMuscleGroupHandler) Update(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Routine muscle group ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /routine-muscle-groups/{id} [delete]
 func (h *RoutineMuscleGroupHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -169,8 +181,8 @@ func (h *RoutineMuscleGroupHandler) Delete(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Routine ID"
 // @Success      200 {object}  models.RutinaConGruposMusculares
-// @Failure      400 {object}  errors.ErrorResponse "Invalid routine ID format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
+// @Failure      400 {object}  errors.ProblemDetails "Invalid routine ID format"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
 // @Router       /routine-muscle-groups/routine/{id}/muscle-groups [get]
 func (h *RoutineMuscleGroupHandler) GetMuscleGroupsByRoutine(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -4,8 +4,9 @@ import (
 	"net/http"
 	"strconv"
 
+	dto "github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -21,41 +22,137 @@ func NewRoutineHandler(r gin.IRouter, usecase *usecase.RutinaUsecase) {
 	routineRoutes := r.Group("/routines")
 	{
 		routineRoutes.GET("", handler.GetAll)
+		routineRoutes.GET("/public", handler.GetPublic)
 		routineRoutes.POST("", handler.Create)
 		routineRoutes.GET("/:id", handler.GetByID)
 		routineRoutes.PUT("/:id", handler.Update)
+		routineRoutes.PATCH("/:id", handler.Patch)
 		routineRoutes.DELETE("/:id", handler.Delete)
+		routineRoutes.POST("/:id/clone", handler.Clone)
+		routineRoutes.GET("/:id/versions", handler.GetVersions)
+		routineRoutes.GET("/:id/versions/:version", handler.GetVersion)
 	}
 }
 
-// @Summary      Get all routines
-// @Description  Get a complete list of routines
+// @Summary      Get my routines
+// @Description  Get the routines owned by the authenticated user
 // @Tags         routines
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.Rutina
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, nombre, fecha_creacion, publica, usuario_id, version_actual, favorite_count (default: -fecha_creacion)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, nombre, fecha_creacion, publica, usuario_id, version_actual, favorite_count, objetivo, origen_rutina_id"  collectionFormat(multi)
+// @Param        fields   query  string  false  "Comma separated fields to return: id, nombre, objetivo, fecha_creacion, publica, usuario_id, origen_rutina_id, origen_usuario_id, origen_version_id, version_actual"
+// @Param        include  query  string  false  "Comma separated relations to embed: muscle_groups"
+// @Success      200  {object}  dto.ListResponse[models.Rutina]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort, filter, fields or include"
+// @Failure      401  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /routines [get]
 func (h *RutinaHandler) GetAll(c *gin.Context) {
-	rutinas, err := h.usecase.GetAllRoutines()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, rutinas)
+	if spec.Projection, err = projectionSpec(c, repositories.RoutineProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	rutinas, err := h.usecase.GetRoutinesByUser(userID, spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := sparseList(repositories.RoutineProjection, spec.Projection, rutinas)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Search public routines
+// @Description  Browse the public routine catalog with full-text search on name/goal, filters by muscle group and author, sorting and cursor pagination
+// @Tags         routines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        q                query  string    false  "Full-text search on name and goal"
+// @Param        muscle_group_id  query  int       false  "Muscle group ID"
+// @Param        author_id        query  int       false  "Author user ID"
+// @Param        limit            query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor           query  string    false  "next_cursor of the previous page"
+// @Param        sort             query  string    false  "Comma separated fields, - prefix for descending: id, nombre, fecha_creacion, publica, usuario_id, version_actual, favorite_count (default: -fecha_creacion)"
+// @Param        filter           query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, nombre, fecha_creacion, usuario_id, version_actual, favorite_count, objetivo, origen_rutina_id"  collectionFormat(multi)
+// @Param        fields           query  string    false  "Comma separated fields to return: id, nombre, objetivo, fecha_creacion, publica, usuario_id, origen_rutina_id, origen_usuario_id, origen_version_id, version_actual"
+// @Param        include          query  string    false  "Comma separated relations to embed: muscle_groups"
+// @Success      200  {object}  dto.ListResponse[models.Rutina]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid query parameters"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /routines/public [get]
+func (h *RutinaHandler) GetPublic(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if spec.Projection, err = projectionSpec(c, repositories.RoutineProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
+	filter := repositories.PublicRoutineFilter{Search: c.Query("q")}
+	if filter.GrupoMuscularID, err = queryUint(c, "muscle_group_id"); err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_MUSCLE_GROUP_ID", "Muscle group ID must be a valid number", err))
+		return
+	}
+	if filter.UsuarioID, err = queryUint(c, "author_id"); err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_AUTHOR_ID", "Author ID must be a valid number", err))
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	rutinas, err := h.usecase.SearchPublicRoutines(filter, spec, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := sparseList(repositories.RoutineProjection, spec.Projection, rutinas)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Get routine by ID
-// @Description  Get a specific routine by its ID
+// @Description  Get a specific public or own routine by its ID
 // @Tags         routines
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Routine ID"
+// @Param        fields   query  string  false  "Comma separated fields to return: id, nombre, objetivo, fecha_creacion, publica, usuario_id, origen_rutina_id, origen_usuario_id, origen_version_id, version_actual"
+// @Param        include  query  string  false  "Comma separated relations to embed: muscle_groups"
 // @Success      200  {object}  models.Rutina
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Routine not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format, fields or include"
+// @Failure      403  {object}  errors.ProblemDetails "Routine is private"
+// @Failure      404  {object}  errors.ProblemDetails "Routine not found"
 // @Router       /routines/{id} [get]
 func (h *RutinaHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -64,12 +161,29 @@ func (h *RutinaHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	rutina, err := h.usecase.GetRoutineByID(uint(id))
+	proj, err := projectionSpec(c, repositories.RoutineProjection)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	rutina, err := h.usecase.GetRoutineForUser(uint(id), userID, proj)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, rutina)
+	response, err := sparse(repositories.RoutineProjection, proj, rutina)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Create a new routine
@@ -78,19 +192,28 @@ func (h *RutinaHandler) GetByID(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        routine body models.Rutina true "Routine data"
+// @Param        routine body dto.RoutineRequest true "Routine data"
 // @Success      201  {object}  models.Rutina
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /routines [post]
 func (h *RutinaHandler) Create(c *gin.Context) {
-	var rutina models.Rutina
-	if err := c.ShouldBindJSON(&rutina); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.RoutineRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.usecase.CreateRoutine(&rutina); err != nil {
+	// Routines are always created in the caller's account
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	rutina := req.ToModel(0)
+	rutina.UsuarioID = userID
+
+	if err := h.usecase.CreateRoutine(rutina); err != nil {
 		c.Error(err)
 		return
 	}
@@ -104,11 +227,12 @@ func (h *RutinaHandler) Create(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id       path   int  true  "Routine ID"
-// @Param        routine  body   models.Rutina true "Updated routine data"
+// @Param        routine  body   dto.RoutineRequest true "Updated routine data"
 // @Success      200  {object}  models.Rutina
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      403  {object}  errors.ProblemDetails "Routine belongs to another user"
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /routines/{id} [put]
 func (h *RutinaHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -117,18 +241,81 @@ func (h *RutinaHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var r models.Rutina
-	if err := c.ShouldBindJSON(&r); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.RoutineRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	r := req.ToModel(uint(id))
+	if err := h.usecase.UpdateRoutine(r, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Return the routine with its current favorite information
+	updated, err := h.usecase.GetRoutineForUser(r.ID, userID, repositories.Projection{})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// @Summary      Patch routine
+// @Description  Partially update a routine with a JSON merge patch (RFC 7396): only the fields present are changed
+// @Tags         routines
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path   int  true  "Routine ID"
+// @Param        routine  body   dto.RoutineRequest true "Fields to change"
+// @Success      200  {object}  models.Rutina
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      403  {object}  errors.ProblemDetails "Routine belongs to another user"
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      415  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /routines/{id} [patch]
+func (h *RutinaHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Routine ID must be a valid number", err))
+		return
+	}
+
+	var req dto.RoutineRequest
+	patch, err := bindMergePatch(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	r, err := h.usecase.PatchRoutine(uint(id), patch, userID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	r.ID = uint(id)
-	if err := h.usecase.UpdateRoutine(&r); err != nil {
+	// Return the routine with its current favorite information
+	updated, err := h.usecase.GetRoutineForUser(r.ID, userID, repositories.Projection{})
+	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, r)
+	c.JSON(http.StatusOK, updated)
 }
 
 // @Summary      Delete routine
@@ -139,8 +326,10 @@ func (h *RutinaHandler) Update(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Routine ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object} errors.ProblemDetails "Routine belongs to another user"
+// @Failure      404 {object} errors.ProblemDetails "Routine not found"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /routines/{id} [delete]
 func (h *RutinaHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -149,9 +338,124 @@ func (h *RutinaHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.DeleteRoutine(uint(id)); err != nil {
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.usecase.DeleteRoutine(uint(id), userID); err != nil {
 		c.Error(err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// @Summary      Clone routine
+// @Description  Deep-copy a public routine (or one of your own) with its muscle groups into your account, keeping attribution to the original
+// @Tags         routines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Routine ID"
+// @Success      201 {object}  models.Rutina
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object}  errors.ProblemDetails "Routine is private"
+// @Failure      404 {object}  errors.ProblemDetails "Routine not found"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /routines/{id}/clone [post]
+func (h *RutinaHandler) Clone(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Routine ID must be a valid number", err))
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	clone, err := h.usecase.CloneRoutine(uint(id), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, clone)
+}
+
+// @Summary      Get routine versions
+// @Description  Get the immutable version history of a public or own routine
+// @Tags         routines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Routine ID"
+// @Success      200 {array}   models.RutinaVersion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object}  errors.ProblemDetails "Routine is private"
+// @Failure      404 {object}  errors.ProblemDetails "Routine not found"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /routines/{id}/versions [get]
+func (h *RutinaHandler) GetVersions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Routine ID must be a valid number", err))
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	versions, err := h.usecase.GetRoutineVersions(uint(id), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, versions)
+}
+
+// @Summary      Get routine version
+// @Description  Get a specific immutable version of a public or own routine
+// @Tags         routines
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int  true  "Routine ID"
+// @Param        version  path      int  true  "Version number"
+// @Success      200 {object}  models.RutinaVersion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID or version format"
+// @Failure      403 {object}  errors.ProblemDetails "Routine is private"
+// @Failure      404 {object}  errors.ProblemDetails "Version not found"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /routines/{id}/versions/{version} [get]
+func (h *RutinaHandler) GetVersion(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Routine ID must be a valid number", err))
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_VERSION", "Version must be a valid number", err))
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	v, err := h.usecase.GetRoutineVersion(uint(id), version, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, v)
+}
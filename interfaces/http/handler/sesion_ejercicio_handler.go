@@ -5,8 +5,8 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -25,52 +25,85 @@ func NewSessionExerciseHandler(r gin.IRouter, uc *usecase.SessionExerciseUsecase
 		sessionExerciseRoutes.POST("", h.Create)
 		sessionExerciseRoutes.GET("/:id", h.GetByID)
 		sessionExerciseRoutes.PUT("/:id", h.Update)
+		sessionExerciseRoutes.PATCH("/:id", h.Patch)
 		sessionExerciseRoutes.DELETE("/:id", h.Delete)
+		sessionExerciseRoutes.POST("/:id/swap", h.Swap)
 		sessionExerciseRoutes.GET("/session/:id", h.GetBySessionID)
 	}
 }
 
 // @Summary      Create a new session exercise
-// @Description  Create a new session exercise in the system
+// @Description  Create a new session exercise in the system; nuevos_records lists the personal records it set
 // @Tags         session-exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        session_exercise body models.SesionEjercicio true "Session exercise data"
+// @Param        session_exercise body dto.SessionExerciseRequest true "Session exercise data"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
 // @Success      201  {object}  models.SesionEjercicio
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails "Session is completed or abandoned"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /session-exercises [post]
 func (h *SessionExerciseHandler) Create(c *gin.Context) {
-	var sesionEjercicio models.SesionEjercicio
-	if err := c.ShouldBindJSON(&sesionEjercicio); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.SessionExerciseRequest
+	entrada, err := bindJSONWithUnits(c, &req)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sesionEjercicio := req.ToModel(0)
+	sesionEjercicio.ConvertWeights(entrada.WeightToSI)
 
-	if err := h.uc.CreateSessionExercise(&sesionEjercicio); err != nil {
+	if err := h.uc.CreateSessionExercise(sesionEjercicio); err != nil {
 		c.Error(err)
 		return
 	}
+	sesionEjercicio.ConvertWeights(salida.WeightFromSI)
 	c.JSON(http.StatusCreated, sesionEjercicio)
 }
 
 // @Summary      Get all session exercises
-// @Description  Get a complete list of session exercises
+// @Description  Get a page of session exercises; weight filters are in kg
 // @Tags         session-exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.SesionEjercicio
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, sesion_id, ejercicio_id, fecha, series, repeticiones, orden, peso (default: orden)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, sesion_id, ejercicio_id, fecha, series, repeticiones, orden, peso, observacion, ejercicio_original_id"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.SesionEjercicio]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /session-exercises [get]
 func (h *SessionExerciseHandler) GetAll(c *gin.Context) {
-	sesionesEjercicios, err := h.uc.GetAllSessionExercises()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, sesionesEjercicios)
+
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sesionesEjercicios, err := h.uc.GetAllSessionExercises(spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	for i := range sesionesEjercicios.Items {
+		sesionesEjercicios.Items[i].ConvertWeights(salida.WeightFromSI)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(sesionesEjercicios))
 }
 
 // @Summary      Get session exercise by ID
@@ -80,9 +113,10 @@ func (h *SessionExerciseHandler) GetAll(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Session exercise ID"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
 // @Success      200  {object}  models.SesionEjercicio
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Session exercise not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Session exercise not found"
 // @Router       /session-exercises/{id} [get]
 func (h *SessionExerciseHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -91,26 +125,35 @@ func (h *SessionExerciseHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	sesionEjercicio, err := h.uc.GetSessionExerciseByID(uint(id))
 	if err != nil {
 		c.Error(err)
 		return
 	}
+	sesionEjercicio.ConvertWeights(salida.WeightFromSI)
 	c.JSON(http.StatusOK, sesionEjercicio)
 }
 
 // @Summary      Update session exercise
-// @Description  Update an existing session exercise
+// @Description  Update an existing session exercise; personal records are recalculated and nuevos_records lists the ones it holds
 // @Tags         session-exercises
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id                path   int  true  "Session exercise ID"
-// @Param        session_exercise  body   models.SesionEjercicio true "Updated session exercise data"
+// @Param        session_exercise  body   dto.SessionExerciseRequest true "Updated session exercise data"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
 // @Success      200  {object}  models.SesionEjercicio
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails "Session is completed or abandoned"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /session-exercises/{id} [put]
 func (h *SessionExerciseHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -119,17 +162,82 @@ func (h *SessionExerciseHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var sesionEjercicio models.SesionEjercicio
-	if err := c.ShouldBindJSON(&sesionEjercicio); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.SessionExerciseRequest
+	entrada, err := bindJSONWithUnits(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	sesionEjercicio := req.ToModel(uint(id))
+	sesionEjercicio.ConvertWeights(entrada.WeightToSI)
 
-	sesionEjercicio.ID = uint(id)
-	if err := h.uc.UpdateSessionExercise(&sesionEjercicio); err != nil {
+	if err := h.uc.UpdateSessionExercise(sesionEjercicio); err != nil {
 		c.Error(err)
 		return
 	}
+	sesionEjercicio.ConvertWeights(salida.WeightFromSI)
+	c.JSON(http.StatusOK, sesionEjercicio)
+}
+
+// @Summary      Patch session exercise
+// @Description  Partially update a session exercise with a JSON merge patch (RFC 7396): only the fields present are changed; personal records are recalculated
+// @Tags         session-exercises
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id                path   int  true  "Session exercise ID"
+// @Param        session_exercise  body   dto.SessionExerciseRequest true "Fields to change"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.SesionEjercicio
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      415  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails "Session is completed or abandoned"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /session-exercises/{id} [patch]
+func (h *SessionExerciseHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Session exercise ID must be a valid number", err))
+		return
+	}
+
+	var req dto.SessionExerciseRequest
+	patch, err := bindMergePatch(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	entrada, err := patchUnits(c, patch, req.Unit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !entrada.IsSI() {
+		patched := req.ToModel(uint(id))
+		patched.ConvertWeights(entrada.WeightToSI)
+		if err := patchValues(patch, patched); err != nil {
+			c.Error(err)
+			return
+		}
+	}
+
+	sesionEjercicio, err := h.uc.PatchSessionExercise(uint(id), patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sesionEjercicio.ConvertWeights(salida.WeightFromSI)
 	c.JSON(http.StatusOK, sesionEjercicio)
 }
 
@@ -141,8 +249,9 @@ func (h *SessionExerciseHandler) Update(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Session exercise ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      409 {object} errors.ProblemDetails "Session is completed or abandoned"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /session-exercises/{id} [delete]
 func (h *SessionExerciseHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -159,7 +268,7 @@ func (h *SessionExerciseHandler) Delete(c *gin.Context) {
 }
 
 // @Summary      Get session exercises by session ID
-// @Description  Get all exercises of a specific session
+// @Description  Get a page of the exercises of a specific session; weight filters are in kg
 // @Tags         session-exercises
 // @Accept       json
 // @Produce      json
@@ -167,11 +276,22 @@ func (h *SessionExerciseHandler) Delete(c *gin.Context) {
 // @Param        id           path   int     true  "Session ID"
 // @Param        start_date   query  string  false "Start date (format: 2006-01-02)"
 // @Param        end_date     query  string  false "End date (format: 2006-01-02)"
-// @Success      200 {array}   models.SesionEjercicio
-// @Failure      400 {object}  errors.ErrorResponse "Invalid ID format or date format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, sesion_id, ejercicio_id, fecha, series, repeticiones, orden, peso (default: orden)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, sesion_id, ejercicio_id, fecha, series, repeticiones, orden, peso, observacion, ejercicio_original_id"  collectionFormat(multi)
+// @Success      200 {object}  dto.ListResponse[models.SesionEjercicio]
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or date format"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
 // @Router       /session-exercises/session/{id} [get]
 func (h *SessionExerciseHandler) GetBySessionID(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Session ID must be a valid number", err))
@@ -200,10 +320,62 @@ func (h *SessionExerciseHandler) GetBySessionID(c *gin.Context) {
 		}
 	}
 
-	sesionesEjercicios, err := h.uc.GetSessionExercisesBySessionID(uint(id), startDate, endDate)
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sesionesEjercicios, err := h.uc.GetSessionExercisesBySessionID(uint(id), startDate, endDate, spec)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, sesionesEjercicios)
+	for i := range sesionesEjercicios.Items {
+		sesionesEjercicios.Items[i].ConvertWeights(salida.WeightFromSI)
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(sesionesEjercicios))
+}
+
+// @Summary      Swap session exercise
+// @Description  Replace the exercise of a session exercise while the session is in progress, keeping the originally planned exercise
+// @Tags         session-exercises
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                      true  "Session exercise ID"
+// @Param        swap  body      dto.SwapExerciseRequest  true  "New exercise"
+// @Param        unit  query  string  false  "Units of the weights: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200  {object}  models.SesionEjercicio
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID, body or exercise"
+// @Failure      404  {object}  errors.ProblemDetails "Session exercise not found"
+// @Failure      409  {object}  errors.ProblemDetails "Session is not in progress"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /session-exercises/{id}/swap [post]
+func (h *SessionExerciseHandler) Swap(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Session exercise ID must be a valid number", err))
+		return
+	}
+
+	var req dto.SwapExerciseRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	salida, err := requestUnits(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sesionEjercicio, err := h.uc.SwapExercise(uint(id), req.EjercicioID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sesionEjercicio.ConvertWeights(salida.WeightFromSI)
+	c.JSON(http.StatusOK, sesionEjercicio)
 }
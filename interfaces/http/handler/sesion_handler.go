@@ -5,8 +5,10 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -25,9 +27,17 @@ func NewSessionHandler(r gin.IRouter, uc *usecase.SessionUsecase) {
 		sessionRoutes.POST("", h.Create)
 		sessionRoutes.GET("/:id", h.GetByID)
 		sessionRoutes.PUT("/:id", h.Update)
+		sessionRoutes.PATCH("/:id", h.Patch)
 		sessionRoutes.DELETE("/:id", h.Delete)
 		sessionRoutes.GET("/user/:id", h.GetByUserID)
 		sessionRoutes.GET("/date-range", h.GetByDateRange)
+
+		// Live workout lifecycle
+		sessionRoutes.POST("/:id/start", h.Start)
+		sessionRoutes.POST("/:id/pause", h.Pause)
+		sessionRoutes.POST("/:id/resume", h.Resume)
+		sessionRoutes.POST("/:id/finish", h.Finish)
+		sessionRoutes.POST("/:id/abandon", h.Abandon)
 	}
 }
 
@@ -37,19 +47,28 @@ func NewSessionHandler(r gin.IRouter, uc *usecase.SessionUsecase) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        session body models.Sesion true "Session data"
+// @Param        session body dto.SessionRequest true "Session data"
 // @Success      201  {object}  models.Sesion
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /sessions [post]
 func (h *SessionHandler) Create(c *gin.Context) {
-	var sesion models.Sesion
-	if err := c.ShouldBindJSON(&sesion); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.SessionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.uc.CreateSession(&sesion); err != nil {
+	// Sessions are always created in the caller's account
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	sesion := req.ToModel(0)
+	sesion.UsuarioID = userID
+
+	if err := h.uc.CreateSession(sesion); err != nil {
 		c.Error(err)
 		return
 	}
@@ -57,21 +76,48 @@ func (h *SessionHandler) Create(c *gin.Context) {
 }
 
 // @Summary      Get all sessions
-// @Description  Get a complete list of training sessions
+// @Description  Get a page of training sessions
 // @Tags         sessions
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.Sesion
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: fecha, duracion_min, estado, usuario_id, id (default: -fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: fecha, duracion_min, estado, usuario_id, rutina_id, comentarios, id"  collectionFormat(multi)
+// @Param        fields   query  string  false  "Comma separated fields to return: id, usuario_id, fecha, duracion_min, comentarios, rutina_id, rutina_version_id, estado, iniciada_en, pausada_en, finalizada_en, pausa_total_seg"
+// @Param        include  query  string  false  "Comma separated relations to embed: routine, exercises, exercises.ejercicio"
+// @Param        unit     query  string  false  "Units of the weights of the included exercises: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200  {object}  dto.ListResponse[models.Sesion]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort, filter, fields or include"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /sessions [get]
 func (h *SessionHandler) GetAll(c *gin.Context) {
-	sesiones, err := h.uc.GetAllSessions()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, sesiones)
+	if spec.Projection, err = projectionSpec(c, repositories.SessionProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
+	sesiones, err := h.uc.GetAllSessions(spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := convertIncludedWeights(c, sesiones.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := sparseList(repositories.SessionProjection, spec.Projection, sesiones)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Get session by ID
@@ -81,9 +127,12 @@ func (h *SessionHandler) GetAll(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Session ID"
+// @Param        fields   query  string  false  "Comma separated fields to return: id, usuario_id, fecha, duracion_min, comentarios, rutina_id, rutina_version_id, estado, iniciada_en, pausada_en, finalizada_en, pausa_total_seg"
+// @Param        include  query  string  false  "Comma separated relations to embed: routine, exercises, exercises.ejercicio"
+// @Param        unit     query  string  false  "Units of the weights of the included exercises: kg, lb, metric or imperial (default: caller preference)"
 // @Success      200  {object}  models.Sesion
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Session not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format, fields or include"
+// @Failure      404  {object}  errors.ProblemDetails "Session not found"
 // @Router       /sessions/{id} [get]
 func (h *SessionHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -92,12 +141,27 @@ func (h *SessionHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	sesion, err := h.uc.GetSessionByID(uint(id))
+	proj, err := projectionSpec(c, repositories.SessionProjection)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, sesion)
+
+	sesion, err := h.uc.GetSessionByID(uint(id), proj)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := convertIncludedWeights(c, sesion); err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := sparse(repositories.SessionProjection, proj, sesion)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Update session
@@ -107,11 +171,11 @@ func (h *SessionHandler) GetByID(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id       path   int  true  "Session ID"
-// @Param        session  body   models.Sesion true "Updated session data"
+// @Param        session  body   dto.SessionRequest true "Updated session data"
 // @Success      200  {object}  models.Sesion
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /sessions/{id} [put]
 func (h *SessionHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -120,14 +184,62 @@ func (h *SessionHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var sesion models.Sesion
-	if err := c.ShouldBindJSON(&sesion); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.SessionRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	sesion.ID = uint(id)
-	if err := h.uc.UpdateSession(&sesion); err != nil {
+	sesion := req.ToModel(uint(id))
+	if err := h.uc.UpdateSession(sesion, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, sesion)
+}
+
+// @Summary      Patch session
+// @Description  Partially update a session with a JSON merge patch (RFC 7396): only the fields present are changed and null clears a field
+// @Tags         sessions
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path   int  true  "Session ID"
+// @Param        session  body   dto.SessionRequest true "Fields to change"
+// @Success      200  {object}  models.Sesion
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      415  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /sessions/{id} [patch]
+func (h *SessionHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Session ID must be a valid number", err))
+		return
+	}
+
+	var req dto.SessionRequest
+	patch, err := bindMergePatch(c, &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sesion, err := h.uc.PatchSession(uint(id), patch, userID)
+	if err != nil {
 		c.Error(err)
 		return
 	}
@@ -142,8 +254,9 @@ func (h *SessionHandler) Update(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "Session ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      404 {object} errors.ProblemDetails "Session not found"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /sessions/{id} [delete]
 func (h *SessionHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -152,7 +265,13 @@ func (h *SessionHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.uc.DeleteSession(uint(id)); err != nil {
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.uc.DeleteSession(uint(id), userID); err != nil {
 		c.Error(err)
 		return
 	}
@@ -160,44 +279,87 @@ func (h *SessionHandler) Delete(c *gin.Context) {
 }
 
 // @Summary      Get sessions by user
-// @Description  Get all sessions of a specific user
+// @Description  Get a page of the sessions of a specific user
 // @Tags         sessions
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id  path      int  true  "User ID"
-// @Success      200 {array}   models.Sesion
-// @Failure      400 {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: fecha, duracion_min, estado, usuario_id, id (default: -fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: fecha, duracion_min, estado, usuario_id, rutina_id, comentarios, id"  collectionFormat(multi)
+// @Param        fields   query  string  false  "Comma separated fields to return: id, usuario_id, fecha, duracion_min, comentarios, rutina_id, rutina_version_id, estado, iniciada_en, pausada_en, finalizada_en, pausa_total_seg"
+// @Param        include  query  string  false  "Comma separated relations to embed: routine, exercises, exercises.ejercicio"
+// @Param        unit     query  string  false  "Units of the weights of the included exercises: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200 {object}  dto.ListResponse[models.Sesion]
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
 // @Router       /sessions/user/{id} [get]
 func (h *SessionHandler) GetByUserID(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if spec.Projection, err = projectionSpec(c, repositories.SessionProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "User ID must be a valid number", err))
 		return
 	}
 
-	sesiones, err := h.uc.GetSessionsByUserID(uint(id))
+	sesiones, err := h.uc.GetSessionsByUserID(uint(id), spec)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, sesiones)
+	if err := convertIncludedWeights(c, sesiones.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := sparseList(repositories.SessionProjection, spec.Projection, sesiones)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
 }
 
 // @Summary      Get sessions by date range
-// @Description  Get all sessions within a specific date range
+// @Description  Get a page of the sessions within a specific date range
 // @Tags         sessions
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        start_date query string true "Start date (ISO format: 2024-01-02T15:04:05Z)"
 // @Param        end_date   query string true "End date (ISO format: 2024-01-02T15:04:05Z)"
-// @Success      200 {array}   models.Sesion
-// @Failure      400 {object}  errors.ErrorResponse "Invalid date format"
-// @Failure      500 {object}  errors.ErrorResponse "Internal server error"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: fecha, duracion_min, estado, usuario_id, id (default: -fecha)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: fecha, duracion_min, estado, usuario_id, rutina_id, comentarios, id"  collectionFormat(multi)
+// @Param        fields   query  string  false  "Comma separated fields to return: id, usuario_id, fecha, duracion_min, comentarios, rutina_id, rutina_version_id, estado, iniciada_en, pausada_en, finalizada_en, pausa_total_seg"
+// @Param        include  query  string  false  "Comma separated relations to embed: routine, exercises, exercises.ejercicio"
+// @Param        unit     query  string  false  "Units of the weights of the included exercises: kg, lb, metric or imperial (default: caller preference)"
+// @Success      200 {object}  dto.ListResponse[models.Sesion]
+// @Failure      400 {object}  errors.ProblemDetails "Invalid date format or query"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
 // @Router       /sessions/date-range [get]
 func (h *SessionHandler) GetByDateRange(c *gin.Context) {
+	spec, err := listSpec(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if spec.Projection, err = projectionSpec(c, repositories.SessionProjection); err != nil {
+		c.Error(err)
+		return
+	}
+
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
 
@@ -214,10 +376,140 @@ func (h *SessionHandler) GetByDateRange(c *gin.Context) {
 		return
 	}
 
-	sesiones, err := h.uc.GetSessionsByDateRange(startDate, endDate)
+	sesiones, err := h.uc.GetSessionsByDateRange(startDate, endDate, spec)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, sesiones)
+	if err := convertIncludedWeights(c, sesiones.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	response, err := sparseList(repositories.SessionProjection, spec.Projection, sesiones)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary      Start session
+// @Description  Start a planned session and begin tracking its real duration
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Session ID"
+// @Success      200 {object}  models.Sesion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404 {object}  errors.ProblemDetails "Session not found"
+// @Failure      409 {object}  errors.ProblemDetails "Session is not planned"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /sessions/{id}/start [post]
+func (h *SessionHandler) Start(c *gin.Context) {
+	h.changeState(c, h.uc.StartSession)
+}
+
+// @Summary      Pause session
+// @Description  Pause an in-progress session
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Session ID"
+// @Success      200 {object}  models.Sesion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404 {object}  errors.ProblemDetails "Session not found"
+// @Failure      409 {object}  errors.ProblemDetails "Session is not running"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /sessions/{id}/pause [post]
+func (h *SessionHandler) Pause(c *gin.Context) {
+	h.changeState(c, h.uc.PauseSession)
+}
+
+// @Summary      Resume session
+// @Description  Resume a paused session
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Session ID"
+// @Success      200 {object}  models.Sesion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404 {object}  errors.ProblemDetails "Session not found"
+// @Failure      409 {object}  errors.ProblemDetails "Session is not paused"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /sessions/{id}/resume [post]
+func (h *SessionHandler) Resume(c *gin.Context) {
+	h.changeState(c, h.uc.ResumeSession)
+}
+
+// @Summary      Finish session
+// @Description  Complete an in-progress session and compute its duration automatically
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Session ID"
+// @Success      200 {object}  models.Sesion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404 {object}  errors.ProblemDetails "Session not found"
+// @Failure      409 {object}  errors.ProblemDetails "Session is not in progress"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /sessions/{id}/finish [post]
+func (h *SessionHandler) Finish(c *gin.Context) {
+	h.changeState(c, h.uc.FinishSession)
+}
+
+// @Summary      Abandon session
+// @Description  Mark a planned or in-progress session as abandoned
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      int  true  "Session ID"
+// @Success      200 {object}  models.Sesion
+// @Failure      400 {object}  errors.ProblemDetails "Invalid ID format or query"
+// @Failure      404 {object}  errors.ProblemDetails "Session not found"
+// @Failure      409 {object}  errors.ProblemDetails "Session is already closed"
+// @Failure      500 {object}  errors.ProblemDetails "Internal server error"
+// @Router       /sessions/{id}/abandon [post]
+func (h *SessionHandler) Abandon(c *gin.Context) {
+	h.changeState(c, h.uc.AbandonSession)
+}
+
+// changeState runs a lifecycle transition for the caller's session in the path
+func (h *SessionHandler) changeState(c *gin.Context, transition func(id, userID uint) (*models.Sesion, error)) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "Session ID must be a valid number", err))
+		return
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sesion, err := transition(uint(id), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, sesion)
+}
+
+// convertIncludedWeights converts the weights of the included session exercises to the units of the response
+func convertIncludedWeights(c *gin.Context, sesiones ...*models.Sesion) error {
+	salida, err := requestUnits(c)
+	if err != nil {
+		return err
+	}
+	for _, sesion := range sesiones {
+		for i := range sesion.Ejercicios {
+			sesion.Ejercicios[i].ConvertWeights(salida.WeightFromSI)
+		}
+	}
+	return nil
 }
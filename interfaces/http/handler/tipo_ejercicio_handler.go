@@ -4,18 +4,20 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	"github.com/Diegonr1791/GymBro/interfaces/http/middleware"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
 
 type TypeExerciseHandler struct {
 	uc *usecase.TypeExerciseUsecase
+	tr *usecase.TranslationUsecase
 }
 
-func NewTypeExerciseHandler(router gin.IRouter, uc *usecase.TypeExerciseUsecase) {
-	handler := &TypeExerciseHandler{uc}
+func NewTypeExerciseHandler(router gin.IRouter, uc *usecase.TypeExerciseUsecase, tr *usecase.TranslationUsecase) {
+	handler := &TypeExerciseHandler{uc, tr}
 
 	// Grouping exercise type routes under "exercise-types"
 	exerciseTypeRoutes := router.Group("/exercise-types")
@@ -29,21 +31,37 @@ func NewTypeExerciseHandler(router gin.IRouter, uc *usecase.TypeExerciseUsecase)
 }
 
 // @Summary      Get all exercise types
-// @Description  Get a complete list of exercise types
+// @Description  Get a page of exercise types
 // @Tags         exercise-types
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   models.TipoEjercicio
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, nombre (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, nombre"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[models.TipoEjercicio]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /exercise-types [get]
 func (h *TypeExerciseHandler) GetAll(c *gin.Context) {
-	tiposEjercicio, err := h.uc.GetAllExerciseTypes()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, tiposEjercicio)
+
+	tiposEjercicio, err := h.uc.GetAllExerciseTypes(spec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.tr.LocalizeExerciseTypes(middleware.GetLocale(c), tiposEjercicio.Items...); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewListResponse(tiposEjercicio))
 }
 
 // @Summary      Get exercise type by ID
@@ -53,9 +71,10 @@ func (h *TypeExerciseHandler) GetAll(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Exercise Type ID"
+// @Param        Accept-Language  header  string  false  "Response language (es, en, pt)"
 // @Success      200  {object}  models.TipoEjercicio
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "Exercise type not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Exercise type not found"
 // @Router       /exercise-types/{id} [get]
 func (h *TypeExerciseHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -69,6 +88,10 @@ func (h *TypeExerciseHandler) GetByID(c *gin.Context) {
 		c.Error(err)
 		return
 	}
+	if err := h.tr.LocalizeExerciseTypes(middleware.GetLocale(c), tipoEjercicio); err != nil {
+		c.Error(err)
+		return
+	}
 	c.JSON(http.StatusOK, tipoEjercicio)
 }
 
@@ -78,19 +101,20 @@ func (h *TypeExerciseHandler) GetByID(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        exercise-type body models.TipoEjercicio true "Exercise type data"
+// @Param        exercise-type body dto.ExerciseTypeRequest true "Exercise type data"
 // @Success      201  {object}  models.TipoEjercicio
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /exercise-types [post]
 func (h *TypeExerciseHandler) Create(c *gin.Context) {
-	var tipoEjercicio models.TipoEjercicio
-	if err := c.ShouldBindJSON(&tipoEjercicio); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.ExerciseTypeRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.uc.CreateExerciseType(&tipoEjercicio); err != nil {
+	tipoEjercicio := req.ToModel(0)
+	if err := h.uc.CreateExerciseType(tipoEjercicio); err != nil {
 		c.Error(err)
 		return
 	}
@@ -104,11 +128,11 @@ func (h *TypeExerciseHandler) Create(c *gin.Context) {
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id             path   int  true  "Exercise Type ID"
-// @Param        exercise-type  body   models.TipoEjercicio true "Updated exercise type data"
+// @Param        exercise-type  body   dto.ExerciseTypeRequest true "Updated exercise type data"
 // @Success      200  {object}  models.TipoEjercicio
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /exercise-types/{id} [put]
 func (h *TypeExerciseHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -117,14 +141,14 @@ func (h *TypeExerciseHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var tipoEjercicio models.TipoEjercicio
-	if err := c.ShouldBindJSON(&tipoEjercicio); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.ExerciseTypeRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	tipoEjercicio.ID = uint(id)
-	if err := h.uc.UpdateExerciseType(&tipoEjercicio); err != nil {
+	tipoEjercicio := req.ToModel(uint(id))
+	if err := h.uc.UpdateExerciseType(tipoEjercicio); err != nil {
 		c.Error(err)
 		return
 	}
@@ -137,10 +161,15 @@ func (h *TypeExerciseHandler) Update(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        id  path      int  true  "Exercise Type ID"
+// @Param        id           path      int   true   "Exercise Type ID"
+// @Param        cascade      query     bool  false  "Delete the dependents too (admin only); refused when its exercises have user history"
+// @Param        reassign_to  query     int   false  "Move the dependents to this exercise type before deleting (admin only)"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      403 {object} errors.ProblemDetails "Insufficient permissions"
+// @Failure      404 {object} errors.ProblemDetails "Exercise type not found"
+// @Failure      409 {object} errors.ProblemDetails "Still referenced, or cascading would delete user history; details lists the dependents"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /exercise-types/{id} [delete]
 func (h *TypeExerciseHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -149,7 +178,18 @@ func (h *TypeExerciseHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.uc.DeleteExerciseType(uint(id)); err != nil {
+	opts, err := deleteOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	roleID, err := currentRoleID(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.uc.DeleteExerciseType(uint(id), roleID, opts); err != nil {
 		c.Error(err)
 		return
 	}
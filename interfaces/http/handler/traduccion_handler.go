@@ -0,0 +1,150 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Diegonr1791/GymBro/interfaces/http/dto"
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/Diegonr1791/GymBro/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type TranslationHandler struct {
+	uc *usecase.TranslationUsecase
+}
+
+func NewTranslationHandler(r gin.IRouter, uc *usecase.TranslationUsecase, catalogAdminMiddleware gin.HandlerFunc) {
+	h := &TranslationHandler{uc}
+
+	// Translations of every localizable catalog entity share the same routes
+	entities := []struct {
+		path    string
+		entidad string
+	}{
+		{"/exercises", models.EntidadEjercicio},
+		{"/muscle-groups", models.EntidadGrupoMuscular},
+		{"/exercise-types", models.EntidadTipoEjercicio},
+	}
+	for _, e := range entities {
+		routes := r.Group(e.path)
+		{
+			routes.GET("/:id/translations", h.GetAll(e.entidad))
+
+			// Editing translations requires admin permissions
+			routes.PUT("/:id/translations/:locale", catalogAdminMiddleware, h.Upsert(e.entidad))
+			routes.DELETE("/:id/translations/:locale", catalogAdminMiddleware, h.Delete(e.entidad))
+		}
+	}
+}
+
+// @Summary      Get translations
+// @Description  Get the translations of a catalog entity; the default language (es) is stored in the entity itself
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Entity ID"
+// @Success      200  {array}   models.Traduccion
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "Entity not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/translations [get]
+// @Router       /muscle-groups/{id}/translations [get]
+// @Router       /exercise-types/{id}/translations [get]
+func (h *TranslationHandler) GetAll(entidad string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "ID must be a valid number", err))
+			return
+		}
+
+		traducciones, err := h.uc.GetTranslations(entidad, uint(id))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, traducciones)
+	}
+}
+
+// @Summary      Create or replace translation
+// @Description  Create or replace the translation of a catalog entity for a language (admin only)
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      int                           true  "Entity ID"
+// @Param        locale       path      string                        true  "Language (en, pt)"
+// @Param        translation  body      dto.UpsertTranslationRequest  true  "Translated texts"
+// @Success      200  {object}  models.Traduccion
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID, language or body"
+// @Failure      403  {object}  errors.ProblemDetails "Insufficient permissions"
+// @Failure      404  {object}  errors.ProblemDetails "Entity not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/translations/{locale} [put]
+// @Router       /muscle-groups/{id}/translations/{locale} [put]
+// @Router       /exercise-types/{id}/translations/{locale} [put]
+func (h *TranslationHandler) Upsert(entidad string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "ID must be a valid number", err))
+			return
+		}
+
+		var req dto.UpsertTranslationRequest
+		if err := bindJSON(c, &req); err != nil {
+			c.Error(err)
+			return
+		}
+
+		traduccion := models.Traduccion{
+			Entidad:       entidad,
+			EntidadID:     uint(id),
+			Locale:        c.Param("locale"),
+			Nombre:        req.Nombre,
+			Descripcion:   req.Descripcion,
+			Instrucciones: req.Instrucciones,
+		}
+		if err := h.uc.UpsertTranslation(&traduccion); err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, traduccion)
+	}
+}
+
+// @Summary      Delete translation
+// @Description  Delete the translation of a catalog entity for a language (admin only)
+// @Tags         translations
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path  int     true  "Entity ID"
+// @Param        locale  path  string  true  "Language (en, pt)"
+// @Success      204 "No Content"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID or language"
+// @Failure      403  {object}  errors.ProblemDetails "Insufficient permissions"
+// @Failure      404  {object}  errors.ProblemDetails "Translation not found"
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /exercises/{id}/translations/{locale} [delete]
+// @Router       /muscle-groups/{id}/translations/{locale} [delete]
+// @Router       /exercise-types/{id}/translations/{locale} [delete]
+func (h *TranslationHandler) Delete(entidad string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "ID must be a valid number", err))
+			return
+		}
+
+		if err := h.uc.DeleteTranslation(entidad, uint(id), c.Param("locale")); err != nil {
+			c.Error(err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	dto "github.com/Diegonr1791/GymBro/interfaces/http/dto"
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
-	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	"github.com/Diegonr1791/GymBro/internal/usecase"
 	"github.com/gin-gonic/gin"
 )
@@ -27,6 +26,7 @@ func NewUsuarioHandler(r gin.IRouter, usecase *usecase.UsuarioUsecase) {
 		userRoutes.POST("", handler.Create)
 		userRoutes.GET("/:id", handler.GetByID)
 		userRoutes.PUT("/:id", handler.Update)
+		userRoutes.PATCH("/:id", handler.Patch)
 		userRoutes.DELETE("/:id", handler.Delete)
 		userRoutes.POST("/:id/restore", handler.Restore)
 		userRoutes.DELETE("/:id/permanent", handler.HardDelete)
@@ -47,6 +47,7 @@ func NewUsuarioHandlerWithAuth(r gin.IRouter, usecase *usecase.UsuarioUsecase, a
 		userRoutes.POST("", handler.Create)
 		userRoutes.GET("/:id", handler.GetByID)
 		userRoutes.PUT("/:id", handler.Update)
+		userRoutes.PATCH("/:id", handler.Patch)
 
 		// Rutas de eliminación con autorización especial
 		userRoutes.DELETE("/:id", authMiddleware, handler.Delete)
@@ -65,126 +66,114 @@ func NewUsuarioHandlerWithAuth(r gin.IRouter, usecase *usecase.UsuarioUsecase, a
 // @Security     BearerAuth
 // @Param        user body dto.CreateUserRequest true "User data"
 // @Success      201  {object}  dto.UserResponse
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      409  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /users [post]
 func (h *UsuarioHandler) Create(c *gin.Context) {
-	var u models.User
-	if err := c.ShouldBindJSON(&u); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	var req dto.CreateUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
 		return
 	}
 
-	if err := h.usecase.CreateUsuario(&u); err != nil {
+	u := req.ToModel()
+	if err := h.usecase.CreateUsuario(u); err != nil {
 		c.Error(err)
 		return
 	}
 
-	userResp := dto.UserResponse{
-		ID:        u.ID,
-		Name:      u.Name,
-		Email:     u.Email,
-		RoleID:    u.RoleID,
-		IsActive:  u.IsActive,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
-	}
-
-	c.JSON(http.StatusCreated, userResp)
+	c.JSON(http.StatusCreated, dto.NewUserResponse(u))
 }
 
 // @Summary      Get all active users
-// @Description  Get a complete list of active users (excluding deleted ones)
+// @Description  Get a page of active users (excluding deleted ones)
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   dto.UserResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, name, email, role_id, is_active, created_at (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, name, email, role_id, is_active, created_at, idioma"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[dto.UserResponse]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /users [get]
 func (h *UsuarioHandler) GetAll(c *gin.Context) {
-	usuarios, err := h.usecase.GetAllUsuarios()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	userResps := make([]dto.UserResponse, 0, len(usuarios))
-	for _, u := range usuarios {
-		userResps = append(userResps, dto.UserResponse{
-			ID:        u.ID,
-			Name:      u.Name,
-			Email:     u.Email,
-			RoleID:    u.RoleID,
-			IsActive:  u.IsActive,
-			CreatedAt: u.CreatedAt,
-			UpdatedAt: u.UpdatedAt,
-		})
+	usuarios, err := h.usecase.GetAllUsuarios(spec)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	c.JSON(http.StatusOK, userResps)
+
+	c.JSON(http.StatusOK, dto.NewUserListResponse(usuarios))
 }
 
 // @Summary      Get all users including deleted
-// @Description  Get a complete list of all users including deleted ones
+// @Description  Get a page of all users including deleted ones
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   dto.UserResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, name, email, role_id, is_active, created_at (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, name, email, role_id, is_active, created_at, idioma"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[dto.UserResponse]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /users/all [get]
 func (h *UsuarioHandler) GetAllIncludingDeleted(c *gin.Context) {
-	usuarios, err := h.usecase.GetAllUsuariosIncludingDeleted()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	userResps := make([]dto.UserResponse, 0, len(usuarios))
-	for _, u := range usuarios {
-		userResps = append(userResps, dto.UserResponse{
-			ID:        u.ID,
-			Name:      u.Name,
-			Email:     u.Email,
-			RoleID:    u.RoleID,
-			IsActive:  u.IsActive,
-			CreatedAt: u.CreatedAt,
-			UpdatedAt: u.UpdatedAt,
-		})
+	usuarios, err := h.usecase.GetAllUsuariosIncludingDeleted(spec)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	c.JSON(http.StatusOK, userResps)
+
+	c.JSON(http.StatusOK, dto.NewUserListResponse(usuarios))
 }
 
 // @Summary      Get deleted users
-// @Description  Get a list of all deleted users
+// @Description  Get a page of deleted users
 // @Tags         users
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   dto.UserResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Param        limit   query  int       false  "Page size (default 20, max 100)"
+// @Param        cursor  query  string    false  "next_cursor of the previous page"
+// @Param        sort    query  string    false  "Comma separated fields, - prefix for descending: id, name, email, role_id, is_active, created_at (default: id)"
+// @Param        filter  query  []string  false  "Filters as field:op:value with op eq, ne, gt, gte, lt, lte, like or in: id, name, email, role_id, is_active, created_at, idioma"  collectionFormat(multi)
+// @Success      200  {object}  dto.ListResponse[dto.UserResponse]
+// @Failure      400  {object}  errors.ProblemDetails "Invalid limit, cursor, sort or filter"
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /users/deleted [get]
 func (h *UsuarioHandler) GetDeleted(c *gin.Context) {
-	usuarios, err := h.usecase.GetDeletedUsuarios()
+	spec, err := listSpec(c)
 	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	userResps := make([]dto.UserResponse, 0, len(usuarios))
-	for _, u := range usuarios {
-		userResps = append(userResps, dto.UserResponse{
-			ID:        u.ID,
-			Name:      u.Name,
-			Email:     u.Email,
-			RoleID:    u.RoleID,
-			IsActive:  u.IsActive,
-			CreatedAt: u.CreatedAt,
-			UpdatedAt: u.UpdatedAt,
-		})
+	usuarios, err := h.usecase.GetDeletedUsuarios(spec)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	c.JSON(http.StatusOK, userResps)
+
+	c.JSON(http.StatusOK, dto.NewUserListResponse(usuarios))
 }
 
 // @Summary      Get user by ID
@@ -195,8 +184,8 @@ func (h *UsuarioHandler) GetDeleted(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id   path      int  true  "User ID"
 // @Success      200  {object}  dto.UserResponse
-// @Failure      400  {object}  errors.ErrorResponse "Invalid ID format"
-// @Failure      404  {object}  errors.ErrorResponse "User not found"
+// @Failure      400  {object}  errors.ProblemDetails "Invalid ID format"
+// @Failure      404  {object}  errors.ProblemDetails "User not found"
 // @Router       /users/{id} [get]
 func (h *UsuarioHandler) GetByID(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -211,16 +200,7 @@ func (h *UsuarioHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	userResp := dto.UserResponse{
-		ID:        usuario.ID,
-		Name:      usuario.Name,
-		Email:     usuario.Email,
-		RoleID:    usuario.RoleID,
-		IsActive:  usuario.IsActive,
-		CreatedAt: usuario.CreatedAt,
-		UpdatedAt: usuario.UpdatedAt,
-	}
-	c.JSON(http.StatusOK, userResp)
+	c.JSON(http.StatusOK, dto.NewUserResponse(usuario))
 }
 
 // @Summary      Get user by email
@@ -231,7 +211,7 @@ func (h *UsuarioHandler) GetByID(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        email path     string true "User Email"
 // @Success      200   {object} dto.UserResponse
-// @Failure      404   {object} errors.ErrorResponse "User not found"
+// @Failure      404   {object} errors.ProblemDetails "User not found"
 // @Router       /users/email/{email} [get]
 func (h *UsuarioHandler) GetByEmail(c *gin.Context) {
 	email := c.Param("email")
@@ -242,20 +222,11 @@ func (h *UsuarioHandler) GetByEmail(c *gin.Context) {
 		return
 	}
 
-	userResp := dto.UserResponse{
-		ID:        usuario.ID,
-		Name:      usuario.Name,
-		Email:     usuario.Email,
-		RoleID:    usuario.RoleID,
-		IsActive:  usuario.IsActive,
-		CreatedAt: usuario.CreatedAt,
-		UpdatedAt: usuario.UpdatedAt,
-	}
-	c.JSON(http.StatusOK, userResp)
+	c.JSON(http.StatusOK, dto.NewUserResponse(usuario))
 }
 
 // @Summary      Update user
-// @Description  Update an existing user's data
+// @Description  Update an existing user's profile; users can only update their own account and only admins can change the role or the account status
 // @Tags         users
 // @Accept       json
 // @Produce      json
@@ -263,10 +234,11 @@ func (h *UsuarioHandler) GetByEmail(c *gin.Context) {
 // @Param        id   path      int  true  "User ID"
 // @Param        user body      dto.UpdateUserRequest true "Updated user data"
 // @Success      200  {object}  dto.UserResponse
-// @Failure      400  {object}  errors.ErrorResponse
-// @Failure      404  {object}  errors.ErrorResponse
-// @Failure      409  {object}  errors.ErrorResponse
-// @Failure      500  {object}  errors.ErrorResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      403  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
 // @Router       /users/{id} [put]
 func (h *UsuarioHandler) Update(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -275,29 +247,68 @@ func (h *UsuarioHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var u models.User
-	if err := c.ShouldBindJSON(&u); err != nil {
-		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err))
+	actorID, actorRoleID, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if err := bindJSON(c, &req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	u, err := h.usecase.UpdateUsuario(req.ToModel(uint(id)), req.IsActive, actorID, actorRoleID)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	u.ID = uint(id)
+	c.JSON(http.StatusOK, dto.NewUserResponse(u))
+}
 
-	if err := h.usecase.UpdateUsuario(&u); err != nil {
+// @Summary      Patch user
+// @Description  Partially update a user with a JSON merge patch (RFC 7396): only the fields present are changed and null clears a field. Users can only patch their own account, only admins can change the role or the account status, and a new password requires current_password.
+// @Tags         users
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int  true  "User ID"
+// @Param        user  body      dto.PatchUserRequest true "Fields to change"
+// @Success      200  {object}  dto.UserResponse
+// @Failure      400  {object}  errors.ProblemDetails
+// @Failure      403  {object}  errors.ProblemDetails
+// @Failure      404  {object}  errors.ProblemDetails
+// @Failure      409  {object}  errors.ProblemDetails
+// @Failure      415  {object}  errors.ProblemDetails
+// @Failure      500  {object}  errors.ProblemDetails
+// @Router       /users/{id} [patch]
+func (h *UsuarioHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(domainErrors.NewAppError(http.StatusBadRequest, "INVALID_ID", "User ID must be a valid number", err))
+		return
+	}
+
+	actorID, actorRoleID, ok := currentActor(c)
+	if !ok {
+		return
+	}
+
+	var req dto.PatchUserRequest
+	patch, err := bindMergePatch(c, &req)
+	if err != nil {
 		c.Error(err)
 		return
 	}
 
-	userResp := dto.UserResponse{
-		ID:        u.ID,
-		Name:      u.Name,
-		Email:     u.Email,
-		RoleID:    u.RoleID,
-		IsActive:  u.IsActive,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+	u, err := h.usecase.PatchUsuario(uint(id), patch, actorID, actorRoleID)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	c.JSON(http.StatusOK, userResp)
+
+	c.JSON(http.StatusOK, dto.NewUserResponse(u))
 }
 
 // @Summary      Soft delete user
@@ -308,9 +319,9 @@ func (h *UsuarioHandler) Update(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "User ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format or user already deleted"
-// @Failure      404 {object} errors.ErrorResponse "User not found"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format or user already deleted"
+// @Failure      404 {object} errors.ProblemDetails "User not found"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /users/{id} [delete]
 func (h *UsuarioHandler) Delete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -335,9 +346,9 @@ func (h *UsuarioHandler) Delete(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "User ID"
 // @Success      200 {object} dto.UserResponse "User restored successfully"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format or user not deleted"
-// @Failure      404 {object} errors.ErrorResponse "User not found"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format or user not deleted"
+// @Failure      404 {object} errors.ProblemDetails "User not found"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /users/{id}/restore [post]
 func (h *UsuarioHandler) Restore(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
@@ -358,16 +369,7 @@ func (h *UsuarioHandler) Restore(c *gin.Context) {
 		return
 	}
 
-	userResp := dto.UserResponse{
-		ID:        usuario.ID,
-		Name:      usuario.Name,
-		Email:     usuario.Email,
-		RoleID:    usuario.RoleID,
-		IsActive:  usuario.IsActive,
-		CreatedAt: usuario.CreatedAt,
-		UpdatedAt: usuario.UpdatedAt,
-	}
-	c.JSON(http.StatusOK, userResp)
+	c.JSON(http.StatusOK, dto.NewUserResponse(usuario))
 }
 
 // @Summary      Hard delete user
@@ -378,9 +380,9 @@ func (h *UsuarioHandler) Restore(c *gin.Context) {
 // @Security     BearerAuth
 // @Param        id  path      int  true  "User ID"
 // @Success      204 "No Content"
-// @Failure      400 {object} errors.ErrorResponse "Invalid ID format"
-// @Failure      404 {object} errors.ErrorResponse "User not found"
-// @Failure      500 {object} errors.ErrorResponse "Internal server error"
+// @Failure      400 {object} errors.ProblemDetails "Invalid ID format"
+// @Failure      404 {object} errors.ProblemDetails "User not found"
+// @Failure      500 {object} errors.ProblemDetails "Internal server error"
 // @Router       /users/{id}/permanent [delete]
 func (h *UsuarioHandler) HardDelete(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
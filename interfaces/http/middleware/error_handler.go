@@ -1,44 +1,99 @@
 package middleware
 
 import (
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
 
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	"github.com/Diegonr1791/GymBro/internal/i18n"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 )
 
+const (
+	ProblemContentType = "application/problem+json"
+
+	// problemTypePrefix namespaces the problem type URIs; the suffix is the error code in kebab case
+	problemTypePrefix = "urn:gymbro:problem:"
+)
+
 // ErrorHandler is a middleware to handle errors centrally.
-// It logs the error and returns a standardized JSON response.
+// It logs the error and returns an RFC 7807 problem+json response in the request language, with the
+// error code, the invalid fields of request bodies and the request ID. Clients that accept
+// application/json but not application/problem+json get the legacy {"error": {...}} shape.
 func ErrorHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Next()
 
 		if len(c.Errors) > 0 {
 			err := c.Errors.Last().Err
+			requestID := GetRequestID(c)
 
 			// Log the full error with stack trace
-			slog.Error("An error occurred", "error", fmt.Sprintf("%+v", err))
+			slog.Error("An error occurred", "error", fmt.Sprintf("%+v", err), "request_id", requestID)
 
 			var appErr *domainErrors.AppError
-			if errors.As(err, &appErr) {
-				// The error is a known application error
-				response := domainErrors.ErrorResponse{
-					Code:    appErr.Code,
-					Message: appErr.Message,
-				}
-				c.JSON(appErr.HTTPStatus, gin.H{"error": response})
-			} else {
+			if !errors.As(err, &appErr) {
 				// The error is an unexpected, non-application error
 				// Respond with a generic 500 internal server error
-				genericError := domainErrors.ErrInternalServer
+				appErr = domainErrors.ErrInternalServer
+			}
+			message := i18n.Message(GetLocale(c), appErr.Code, appErr.Message)
+
+			// Binding failures report their invalid fields, as do usecases through field error details
+			var fields []domainErrors.FieldError
+			details := appErr.Details
+			if fe, ok := details.([]domainErrors.FieldError); ok {
+				fields, details = fe, nil
+			}
+			if details == nil && fields == nil {
+				fields = fieldErrors(err)
+			}
+
+			if wantsLegacyError(c) {
 				response := domainErrors.ErrorResponse{
-					Code:    genericError.Code,
-					Message: genericError.Message,
+					Code:    appErr.Code,
+					Message: message,
+					Details: details,
+				}
+				if fields != nil {
+					response.Details = fields
 				}
-				c.JSON(genericError.HTTPStatus, gin.H{"error": response})
+				c.JSON(appErr.HTTPStatus, gin.H{"error": response})
+				return
+			}
+
+			problem := domainErrors.ProblemDetails{
+				Type:      problemType(appErr.Code),
+				Title:     http.StatusText(appErr.HTTPStatus),
+				Status:    appErr.HTTPStatus,
+				Detail:    message,
+				Instance:  c.Request.URL.Path,
+				Code:      appErr.Code,
+				Errors:    fields,
+				Details:   details,
+				RequestID: requestID,
 			}
+			body, err := json.Marshal(problem)
+			if err != nil {
+				c.Status(http.StatusInternalServerError)
+				return
+			}
+			c.Data(appErr.HTTPStatus, ProblemContentType, body)
 		}
 	}
 }
+
+// wantsLegacyError checks if the client negotiated plain JSON errors: it accepts application/json
+// and does not accept application/problem+json. Clients without an Accept header get problem+json.
+func wantsLegacyError(c *gin.Context) bool {
+	accept := c.GetHeader("Accept")
+	return strings.Contains(accept, "application/json") && !strings.Contains(accept, ProblemContentType)
+}
+
+func problemType(code string) string {
+	return problemTypePrefix + strings.ReplaceAll(strings.ToLower(code), "_", "-")
+}
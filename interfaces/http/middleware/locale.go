@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"github.com/Diegonr1791/GymBro/internal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+const localeKey = "locale"
+
+// Locale resolves the response language from the Accept-Language header
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setLocale(c, i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// GetLocale returns the resolved response language, or an empty string when the client expressed none
+func GetLocale(c *gin.Context) string {
+	return c.GetString(localeKey)
+}
+
+func setLocale(c *gin.Context, locale string) {
+	if locale == "" {
+		return
+	}
+	c.Set(localeKey, locale)
+	c.Header("Content-Language", locale)
+}
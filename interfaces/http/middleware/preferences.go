@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"github.com/Diegonr1791/GymBro/internal/auth"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+// UserPreferences loads the authenticated user's preferences once per request: the preferred locale
+// overrides Accept-Language and the preferred units are stored for the handlers to convert values.
+// It must run after the JWT middleware.
+func UserPreferences(preference func(userID uint) (string, models.Unidades)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userID, ok := auth.GetUserIDFromContext(c); ok && userID > 0 {
+			locale, unidades := preference(uint(userID))
+			setLocale(c, locale)
+			c.Set(unitsKey, unidades)
+		}
+		c.Next()
+	}
+}
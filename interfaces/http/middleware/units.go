@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/gin-gonic/gin"
+)
+
+const unitsKey = "units"
+
+// GetUnits returns the caller's preferred units, defaulting to kg and cm
+func GetUnits(c *gin.Context) models.Unidades {
+	if unidades, ok := c.Get(unitsKey); ok {
+		return unidades.(models.Unidades)
+	}
+	return models.UnidadesSI
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+// RegisterValidation makes the request validator report fields by their JSON name
+func RegisterValidation() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return field.Name
+		}
+		return name
+	})
+}
+
+// fieldErrors returns the field level details of a body that failed binding, or nil when the
+// error is not a validation or type error
+func fieldErrors(err error) []domainErrors.FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		details := make([]domainErrors.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			field := fieldPath(fe.Namespace())
+			details = append(details, domainErrors.FieldError{
+				Field:   field,
+				Rule:    fe.Tag(),
+				Param:   fe.Param(),
+				Message: ruleMessage(field, fe.Tag(), fe.Param()),
+			})
+		}
+		return details
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		kind := jsonKind(typeErr.Type)
+		return []domainErrors.FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   kind,
+			Message: fmt.Sprintf("%s must be a JSON %s", typeErr.Field, kind),
+		}}
+	}
+	return nil
+}
+
+// fieldPath drops the request struct name from a validator namespace such as MeasurementRequest.valores[0].clave
+func fieldPath(namespace string) string {
+	if _, path, ok := strings.Cut(namespace, "."); ok {
+		return path
+	}
+	return namespace
+}
+
+func ruleMessage(field, rule, param string) string {
+	switch rule {
+	case "required", "required_without":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email"
+	case "url", "http_url":
+		return field + " must be an absolute http(s) URL"
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.ReplaceAll(param, " ", ", "))
+	case "min":
+		return fmt.Sprintf("%s must have at least %s characters or items", field, param)
+	case "max":
+		return fmt.Sprintf("%s must have at most %s characters or items", field, param)
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s", field, param)
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, param)
+	case "lt":
+		return fmt.Sprintf("%s must be less than %s", field, param)
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, param)
+	case "dive":
+		return field + " has invalid items"
+	}
+	return fmt.Sprintf("%s does not satisfy %s", field, rule)
+}
+
+// jsonKind names the JSON type expected for a Go type
+func jsonKind(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Pointer:
+		return jsonKind(t.Elem())
+	}
+	return "object"
+}
@@ -1,12 +1,14 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 	"strings"
 
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 // RoleRepository define la interfaz para el repositorio de roles
@@ -37,17 +39,21 @@ func JWTAuthMiddleware(cfg JWTConfig) gin.HandlerFunc {
 		// Verificar que el header tenga el formato correcto: "Bearer <token>"
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			c.Error(domainErrors.NewAppError(http.StatusUnauthorized, "INVALID_AUTH_FORMAT", "Formato de autorización inválido. Use: Bearer <token>", nil))
+			c.Error(domainErrors.NewAppError(http.StatusUnauthorized, "INVALID_AUTH_FORMAT", "Invalid authorization format. Use: Bearer <token>", nil))
 			c.Abort()
 			return
 		}
 
 		tokenString := tokenParts[1]
 
-		// Validar el token
+		// Validar el token; el error del parser solo se registra en los logs, nunca se devuelve al cliente
 		claims, err := ValidateJWT(tokenString, cfg)
 		if err != nil {
-			c.Error(domainErrors.NewAppError(http.StatusUnauthorized, "INVALID_TOKEN", "Token inválido: "+err.Error(), err))
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				c.Error(domainErrors.NewAppError(http.StatusUnauthorized, "TOKEN_EXPIRED", "The token has expired.", err))
+			} else {
+				c.Error(domainErrors.NewAppError(http.StatusUnauthorized, "INVALID_TOKEN", "The token is invalid.", err))
+			}
 			c.Abort()
 			return
 		}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// URLSigner genera y valida URLs firmadas con expiración para descargar archivos
+type URLSigner struct {
+	secret   []byte
+	basePath string
+	ttl      time.Duration
+}
+
+// NewURLSigner crea un firmador para las URLs servidas bajo basePath
+func NewURLSigner(secret, basePath string, ttl time.Duration) *URLSigner {
+	return &URLSigner{
+		secret:   []byte(secret),
+		basePath: basePath,
+		ttl:      ttl,
+	}
+}
+
+// Sign devuelve la URL firmada para una clave y su fecha de expiración
+func (s *URLSigner) Sign(key string) (string, time.Time) {
+	expires := time.Now().Add(s.ttl).Truncate(time.Second)
+	exp := strconv.FormatInt(expires.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", exp)
+	query.Set("signature", s.signature(key, exp))
+	return fmt.Sprintf("%s/%s?%s", s.basePath, key, query.Encode()), expires
+}
+
+// Verify valida la firma y que la URL no haya expirado
+func (s *URLSigner) Verify(key, expires, signature string) bool {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > exp {
+		return false
+	}
+	expected := s.signature(key, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (s *URLSigner) signature(key, expires string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(key + "|" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
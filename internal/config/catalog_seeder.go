@@ -0,0 +1,265 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	"github.com/pkg/errors"
+)
+
+// catalogSeed es el catálogo estándar de grupos musculares, tipos de ejercicio y ejercicios
+//
+//go:embed seeds/catalog.json
+var catalogSeed []byte
+
+type seedCatalog struct {
+	GruposMusculares []seedCatalogEntry    `json:"grupos_musculares"`
+	TiposEjercicio   []seedCatalogEntry    `json:"tipos_ejercicio"`
+	Ejercicios       []seedCatalogExercise `json:"ejercicios"`
+}
+
+type seedCatalogEntry struct {
+	Nombre       string            `json:"nombre"`
+	Traducciones map[string]string `json:"traducciones"`
+}
+
+type seedCatalogExercise struct {
+	Nombre            string            `json:"nombre"`
+	Tipo              string            `json:"tipo"`
+	GrupoPrimario     string            `json:"grupo_primario"`
+	GruposSecundarios []string          `json:"grupos_secundarios"`
+	Equipamiento      string            `json:"equipamiento"`
+	Dificultad        string            `json:"dificultad"`
+	Unilateral        bool              `json:"unilateral"`
+	Descripcion       string            `json:"descripcion"`
+	Instrucciones     string            `json:"instrucciones"`
+	Traducciones      map[string]string `json:"traducciones"`
+}
+
+// seedCounts resume el resultado de la carga de un tipo de entidad
+type seedCounts struct {
+	creados, actualizados, sinCambios int
+}
+
+func (c seedCounts) String() string {
+	return fmt.Sprintf("%d creados, %d actualizados, %d sin cambios", c.creados, c.actualizados, c.sinCambios)
+}
+
+// SeedCatalog carga el catálogo estándar embebido. Es idempotente: los registros se buscan por nombre
+// y solo se crean o actualizan cuando faltan o difieren del dataset.
+func (s *Seeder) SeedCatalog() error {
+	log.Println("📚 Cargando catálogo estándar de ejercicios...")
+
+	var catalog seedCatalog
+	if err := json.Unmarshal(catalogSeed, &catalog); err != nil {
+		return errors.Wrap(err, "catálogo embebido inválido")
+	}
+
+	grupos, counts, err := s.seedMuscleGroups(catalog.GruposMusculares)
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Grupos musculares: %s", counts)
+
+	tipos, counts, err := s.seedExerciseTypes(catalog.TiposEjercicio)
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Tipos de ejercicio: %s", counts)
+
+	counts, err = s.seedExercises(catalog.Ejercicios, grupos, tipos)
+	if err != nil {
+		return err
+	}
+	log.Printf("✅ Ejercicios: %s", counts)
+
+	return nil
+}
+
+// seedMuscleGroups crea los grupos musculares que faltan y devuelve sus IDs por nombre
+func (s *Seeder) seedMuscleGroups(entries []seedCatalogEntry) (map[string]uint, seedCounts, error) {
+	ids := make(map[string]uint, len(entries))
+	var counts seedCounts
+	for _, entry := range entries {
+		grupo, err := s.muscleGroupRepo.GetByName(entry.Nombre)
+		switch {
+		case errors.Is(err, domainErrors.ErrNotFound):
+			grupo = &models.GrupoMuscular{Nombre: entry.Nombre}
+			if err := s.muscleGroupRepo.Create(grupo); err != nil {
+				return nil, counts, errors.Wrapf(err, "creando grupo muscular '%s'", entry.Nombre)
+			}
+			counts.creados++
+		case err != nil:
+			return nil, counts, err
+		default:
+			counts.sinCambios++
+		}
+		ids[entry.Nombre] = grupo.ID
+
+		if err := s.seedTranslations(models.EntidadGrupoMuscular, grupo.ID, entry.Traducciones); err != nil {
+			return nil, counts, err
+		}
+	}
+	return ids, counts, nil
+}
+
+// seedExerciseTypes crea los tipos de ejercicio que faltan y devuelve sus IDs por nombre
+func (s *Seeder) seedExerciseTypes(entries []seedCatalogEntry) (map[string]uint, seedCounts, error) {
+	ids := make(map[string]uint, len(entries))
+	var counts seedCounts
+	for _, entry := range entries {
+		tipo, err := s.exerciseTypeRepo.GetByName(entry.Nombre)
+		switch {
+		case errors.Is(err, domainErrors.ErrNotFound):
+			tipo = &models.TipoEjercicio{Nombre: entry.Nombre}
+			if err := s.exerciseTypeRepo.Create(tipo); err != nil {
+				return nil, counts, errors.Wrapf(err, "creando tipo de ejercicio '%s'", entry.Nombre)
+			}
+			counts.creados++
+		case err != nil:
+			return nil, counts, err
+		default:
+			counts.sinCambios++
+		}
+		ids[entry.Nombre] = tipo.ID
+
+		if err := s.seedTranslations(models.EntidadTipoEjercicio, tipo.ID, entry.Traducciones); err != nil {
+			return nil, counts, err
+		}
+	}
+	return ids, counts, nil
+}
+
+// seedExercises crea los ejercicios que faltan en el catálogo compartido y actualiza los que difieren del dataset
+func (s *Seeder) seedExercises(entries []seedCatalogExercise, grupos, tipos map[string]uint) (seedCounts, error) {
+	var counts seedCounts
+	for _, entry := range entries {
+		seeded, err := entry.toModel(grupos, tipos)
+		if err != nil {
+			return counts, err
+		}
+
+		ejercicio, err := s.exerciseRepo.GetSharedByName(entry.Nombre)
+		switch {
+		case errors.Is(err, domainErrors.ErrNotFound):
+			ejercicio = seeded
+			if err := s.exerciseRepo.Create(ejercicio); err != nil {
+				return counts, errors.Wrapf(err, "creando ejercicio '%s'", entry.Nombre)
+			}
+			counts.creados++
+		case err != nil:
+			return counts, err
+		default:
+			if applySeededExercise(ejercicio, seeded) {
+				if err := s.exerciseRepo.Update(ejercicio); err != nil {
+					return counts, errors.Wrapf(err, "actualizando ejercicio '%s'", entry.Nombre)
+				}
+				counts.actualizados++
+			} else {
+				counts.sinCambios++
+			}
+		}
+
+		if err := s.seedTranslations(models.EntidadEjercicio, ejercicio.ID, entry.Traducciones); err != nil {
+			return counts, err
+		}
+	}
+	return counts, nil
+}
+
+// seedTranslations crea las traducciones que faltan; las existentes se respetan
+func (s *Seeder) seedTranslations(entidad string, entidadID uint, traducciones map[string]string) error {
+	if len(traducciones) == 0 {
+		return nil
+	}
+	existentes, err := s.translationRepo.GetByEntity(entidad, entidadID)
+	if err != nil {
+		return err
+	}
+	for locale, nombre := range traducciones {
+		if slices.ContainsFunc(existentes, func(t models.Traduccion) bool { return t.Locale == locale }) {
+			continue
+		}
+		traduccion := models.Traduccion{Entidad: entidad, EntidadID: entidadID, Locale: locale, Nombre: nombre}
+		if err := s.translationRepo.Upsert(&traduccion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toModel resuelve las referencias por nombre del ejercicio del dataset
+func (e seedCatalogExercise) toModel(grupos, tipos map[string]uint) (*models.Ejercicio, error) {
+	tipoID, ok := tipos[e.Tipo]
+	if !ok {
+		return nil, fmt.Errorf("ejercicio '%s': tipo de ejercicio desconocido '%s'", e.Nombre, e.Tipo)
+	}
+	primarioID, ok := grupos[e.GrupoPrimario]
+	if !ok {
+		return nil, fmt.Errorf("ejercicio '%s': grupo muscular desconocido '%s'", e.Nombre, e.GrupoPrimario)
+	}
+
+	ejercicio := &models.Ejercicio{
+		Nombre:          e.Nombre,
+		TipoEjercicioID: tipoID,
+		GrupoMuscularID: primarioID,
+		Equipamiento:    e.Equipamiento,
+		Dificultad:      e.Dificultad,
+		Unilateral:      e.Unilateral,
+		Descripcion:     e.Descripcion,
+		Instrucciones:   e.Instrucciones,
+	}
+	for _, nombre := range e.GruposSecundarios {
+		grupoID, ok := grupos[nombre]
+		if !ok {
+			return nil, fmt.Errorf("ejercicio '%s': grupo muscular desconocido '%s'", e.Nombre, nombre)
+		}
+		ejercicio.GruposMusculares = append(ejercicio.GruposMusculares, models.EjercicioGrupoMuscular{
+			GrupoMuscularID: grupoID,
+			Rol:             models.RolGrupoSecundario,
+		})
+	}
+	ejercicio.NormalizeMuscleGroups()
+	return ejercicio, nil
+}
+
+// applySeededExercise copia en el ejercicio existente los campos del dataset que difieren.
+// Los textos vacíos del dataset no sobrescriben los cargados por los administradores.
+func applySeededExercise(ejercicio, seeded *models.Ejercicio) bool {
+	changed := false
+	set := func(dst *string, value string) {
+		if value != "" && *dst != value {
+			*dst = value
+			changed = true
+		}
+	}
+	set(&ejercicio.Equipamiento, seeded.Equipamiento)
+	set(&ejercicio.Dificultad, seeded.Dificultad)
+	set(&ejercicio.Descripcion, seeded.Descripcion)
+	set(&ejercicio.Instrucciones, seeded.Instrucciones)
+
+	if ejercicio.TipoEjercicioID != seeded.TipoEjercicioID || ejercicio.Unilateral != seeded.Unilateral {
+		ejercicio.TipoEjercicioID = seeded.TipoEjercicioID
+		ejercicio.Unilateral = seeded.Unilateral
+		changed = true
+	}
+
+	sameGroups := ejercicio.GrupoMuscularID == seeded.GrupoMuscularID &&
+		len(ejercicio.GruposMusculares) == len(seeded.GruposMusculares) &&
+		!slices.ContainsFunc(seeded.GruposMusculares, func(g models.EjercicioGrupoMuscular) bool {
+			return !slices.ContainsFunc(ejercicio.GruposMusculares, func(x models.EjercicioGrupoMuscular) bool {
+				return x.GrupoMuscularID == g.GrupoMuscularID && x.Rol == g.Rol
+			})
+		})
+	if !sameGroups {
+		ejercicio.GrupoMuscularID = seeded.GrupoMuscularID
+		ejercicio.GruposMusculares = seeded.GruposMusculares
+		changed = true
+	}
+	return changed
+}
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"log"
+
 	persistence "github.com/Diegonr1791/GymBro/infraestructure/persistence"
+	"github.com/Diegonr1791/GymBro/infraestructure/storage"
+	"github.com/Diegonr1791/GymBro/internal/auth"
 	repository "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	usecase "github.com/Diegonr1791/GymBro/internal/usecase"
 	"gorm.io/gorm"
@@ -12,18 +16,27 @@ type Container struct {
 	DB *gorm.DB
 
 	// Repositories
-	RoleRepo            repository.RoleRepository
-	UsuarioRepo         repository.UsuarioRepository
-	RutinaRepo          repository.RutinaRepository
-	GrupoMuscularRepo   repository.GrupoMuscularRepository
-	RutinaGMRepo        repository.RutinaGrupoMuscularRepository
-	FavoritaRepo        repository.FavoritaRepository
-	MedicionRepo        repository.MedicionRepository
-	TipoEjercicioRepo   repository.TypeExerciseRepository
-	EjercicioRepo       repository.ExerciseRepository
-	SesionRepo          repository.SessionRepository
-	SesionEjercicioRepo repository.SessionExerciseRepository
-	RefreshTokenRepo    repository.RefreshTokenRepository
+	RoleRepo             repository.RoleRepository
+	UsuarioRepo          repository.UsuarioRepository
+	RutinaRepo           repository.RutinaRepository
+	RutinaVersionRepo    repository.RutinaVersionRepository
+	GrupoMuscularRepo    repository.GrupoMuscularRepository
+	RutinaGMRepo         repository.RutinaGrupoMuscularRepository
+	FavoritaRepo         repository.FavoritaRepository
+	MedicionRepo         repository.MedicionRepository
+	MetricaMedicionRepo  repository.MeasurementMetricRepository
+	TipoEjercicioRepo    repository.TypeExerciseRepository
+	EjercicioRepo        repository.ExerciseRepository
+	EjercicioAltRepo     repository.ExerciseAlternativeRepository
+	SesionRepo           repository.SessionRepository
+	SesionEjercicioRepo  repository.SessionExerciseRepository
+	RefreshTokenRepo     repository.RefreshTokenRepository
+	TraduccionRepo       repository.TranslationRepository
+	RecordRepo           repository.PersonalRecordRepository
+	MetaRepo             repository.GoalRepository
+	FotoProgresoRepo     repository.ProgressPhotoRepository
+	AccesoEntrenadorRepo repository.CoachAccessRepository
+	BlobStore            repository.BlobStore
 
 	// Use Cases
 	AuthorizationService   *usecase.AuthorizationUsecase
@@ -39,6 +52,16 @@ type Container struct {
 	SesionService          *usecase.SessionUsecase
 	SesionEjercicioService *usecase.SessionExerciseUsecase
 	RefreshTokenService    *usecase.RefreshTokenUsecase
+	MediaService           *usecase.MediaUsecase
+	TraduccionService      *usecase.TranslationUsecase
+	RecordService          *usecase.PersonalRecordUsecase
+	MetaService            *usecase.GoalUsecase
+	FotoProgresoService    *usecase.ProgressPhotoUsecase
+	EntrenadorService      *usecase.CoachUsecase
+	AnalyticsService       *usecase.AnalyticsUsecase
+
+	// Firmador de URLs de descarga de archivos
+	MediaSigner *auth.URLSigner
 
 	// Seeder
 	Seeder *Seeder
@@ -74,15 +97,29 @@ func (c *Container) initializeRepositories() {
 	c.RoleRepo = persistence.NewRoleGormRepository(c.DB)
 	c.UsuarioRepo = persistence.NewUsuarioGormRepository(c.DB)
 	c.RutinaRepo = persistence.NewRutinaGormRepository(c.DB)
+	c.RutinaVersionRepo = persistence.NewRutinaVersionGormRepository(c.DB)
 	c.GrupoMuscularRepo = persistence.NewGrupoMuscularGormRepository(c.DB)
 	c.RutinaGMRepo = persistence.NewRutinaGrupoMuscularGormRepository(c.DB)
 	c.FavoritaRepo = persistence.NewFavoritaGormRepository(c.DB)
 	c.MedicionRepo = persistence.NewMedicionGormRepository(c.DB)
+	c.MetricaMedicionRepo = persistence.NewMeasurementMetricGormRepository(c.DB)
 	c.TipoEjercicioRepo = persistence.NewTypeExerciseGormRepository(c.DB)
 	c.EjercicioRepo = persistence.NewExerciseGormRepository(c.DB)
+	c.EjercicioAltRepo = persistence.NewExerciseAlternativeGormRepository(c.DB)
 	c.SesionRepo = persistence.NewSessionGormRepository(c.DB)
 	c.SesionEjercicioRepo = persistence.NewSessionExerciseGormRepository(c.DB)
 	c.RefreshTokenRepo = persistence.NewRefreshTokenGormRepository(c.DB)
+	c.TraduccionRepo = persistence.NewTranslationGormRepository(c.DB)
+	c.RecordRepo = persistence.NewPersonalRecordGormRepository(c.DB)
+	c.MetaRepo = persistence.NewGoalGormRepository(c.DB)
+	c.FotoProgresoRepo = persistence.NewProgressPhotoGormRepository(c.DB)
+	c.AccesoEntrenadorRepo = persistence.NewCoachAccessGormRepository(c.DB)
+
+	blobStore, err := storage.NewLocalBlobStore(c.JWTConfig.MediaStoragePath)
+	if err != nil {
+		log.Fatal("Error al inicializar el almacenamiento de archivos: ", err)
+	}
+	c.BlobStore = blobStore
 }
 
 // initializeUseCases configura todos los use cases
@@ -90,17 +127,33 @@ func (c *Container) initializeUseCases() {
 	c.AuthorizationService = usecase.NewAuthorizationUsecase(c.RoleRepo)
 	c.RoleService = usecase.NewRoleUseCase(c.RoleRepo)
 	c.UsuarioService = usecase.NewUsuarioUsecase(c.UsuarioRepo, c.RoleRepo)
-	c.RutinaService = usecase.NewRutinaUsecase(c.RutinaRepo)
-	c.GrupoMuscularService = usecase.NewGrupoMuscularUseCase(c.GrupoMuscularRepo)
-	c.RutinaGMService = usecase.NewRoutineMuscleGroupUsecase(c.RutinaGMRepo)
-	c.FavoritaService = usecase.NewFavoriteUsecase(c.FavoritaRepo)
-	c.MedicionService = usecase.NewMeasurementUsecase(c.MedicionRepo)
-	c.TipoEjercicioService = usecase.NewTypeExerciseUsecase(c.TipoEjercicioRepo)
-	c.EjercicioService = usecase.NewExerciseUsecase(c.EjercicioRepo)
-	c.SesionService = usecase.NewSessionUsecase(c.SesionRepo)
-	c.SesionEjercicioService = usecase.NewSessionExerciseUsecase(c.SesionEjercicioRepo)
+	c.RutinaService = usecase.NewRutinaUsecase(c.RutinaRepo, c.RutinaGMRepo, c.RutinaVersionRepo, c.FavoritaRepo)
+	c.GrupoMuscularService = usecase.NewGrupoMuscularUseCase(c.GrupoMuscularRepo, c.RoleRepo, c.BlobStore)
+	c.RutinaGMService = usecase.NewRoutineMuscleGroupUsecase(c.RutinaGMRepo, c.RutinaRepo, c.RutinaVersionRepo)
+	c.FavoritaService = usecase.NewFavoriteUsecase(c.FavoritaRepo, c.RutinaRepo)
+	c.MedicionService = usecase.NewMeasurementUsecase(c.MedicionRepo, c.MetricaMedicionRepo, c.UsuarioRepo, c.FotoProgresoRepo, c.BlobStore)
+	c.TipoEjercicioService = usecase.NewTypeExerciseUsecase(c.TipoEjercicioRepo, c.RoleRepo, c.BlobStore)
+	mediaLimits := usecase.MediaLimits{
+		MaxImageBytes: c.JWTConfig.GetMaxImageBytes(),
+		MaxVideoBytes: c.JWTConfig.GetMaxVideoBytes(),
+	}
+	c.EjercicioService = usecase.NewExerciseUsecase(c.EjercicioRepo, c.EjercicioAltRepo, c.RoleRepo, c.BlobStore, mediaLimits)
+	c.SesionService = usecase.NewSessionUsecase(c.SesionRepo, c.RutinaRepo, c.RutinaGMRepo, c.RutinaVersionRepo)
+	c.SesionEjercicioService = usecase.NewSessionExerciseUsecase(c.SesionEjercicioRepo, c.SesionRepo, c.EjercicioRepo, c.RecordRepo)
 	c.RefreshTokenService = usecase.NewRefreshTokenUsecase(c.RefreshTokenRepo, c.UsuarioRepo, c.JWTConfig)
+	c.MediaService = usecase.NewMediaUsecase(c.BlobStore)
+	c.EntrenadorService = usecase.NewCoachUsecase(c.AccesoEntrenadorRepo, c.UsuarioRepo)
+	c.FotoProgresoService = usecase.NewProgressPhotoUsecase(c.FotoProgresoRepo, c.MedicionRepo, c.AccesoEntrenadorRepo, c.BlobStore, mediaLimits)
+	c.RecordService = usecase.NewPersonalRecordUsecase(c.RecordRepo, c.EjercicioRepo)
+	c.MetaService = usecase.NewGoalUsecase(c.MetaRepo, c.MedicionRepo, c.SesionEjercicioRepo, c.EjercicioRepo)
+	minSets, maxSets := c.JWTConfig.GetWeeklySetsTargets()
+	c.AnalyticsService = usecase.NewAnalyticsUsecase(c.SesionRepo, c.SesionEjercicioRepo, c.EjercicioRepo, c.GrupoMuscularRepo, usecase.VolumeTargets{
+		MinSets: minSets,
+		MaxSets: maxSets,
+	})
+	c.TraduccionService = usecase.NewTranslationUsecase(c.TraduccionRepo, c.EjercicioRepo, c.GrupoMuscularRepo, c.TipoEjercicioRepo)
+	c.MediaSigner = auth.NewURLSigner(c.JWTConfig.MediaSigningSecret, "/api/v1/media", c.JWTConfig.GetMediaURLTTL())
 
 	// Inicializar seeder
-	c.Seeder = NewSeeder(c.RoleRepo, c.UsuarioRepo)
+	c.Seeder = NewSeeder(c.RoleRepo, c.UsuarioRepo, c.GrupoMuscularRepo, c.TipoEjercicioRepo, c.EjercicioRepo, c.TraduccionRepo)
 }
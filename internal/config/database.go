@@ -26,6 +26,13 @@ func ConnectDB() {
 	fmt.Println("✅ Conexión a la base de datos exitosa")
 	DB = db
 
+	// Eliminar favoritas duplicadas antes de crear el índice único (usuario_id, rutina_id)
+	if db.Migrator().HasTable(&model.Favorita{}) {
+		if err := db.Exec("DELETE FROM favoritas a USING favoritas b WHERE a.id > b.id AND a.usuario_id = b.usuario_id AND a.rutina_id = b.rutina_id").Error; err != nil {
+			log.Fatal("Error al depurar favoritas duplicadas: ", err)
+		}
+	}
+
 	// Auto-migrar las tablas
 	err = db.AutoMigrate(
 		&model.Role{},
@@ -34,10 +41,20 @@ func ConnectDB() {
 		&model.GrupoMuscular{},
 		&model.TipoEjercicio{},
 		&model.Ejercicio{},
+		&model.EjercicioGrupoMuscular{},
+		&model.EjercicioAlternativa{},
+		&model.Traduccion{},
+		&model.RecordPersonal{},
 		&model.SesionEjercicio{},
 		&model.Rutina{},
+		&model.RutinaVersion{},
 		&model.Favorita{},
 		&model.Medicion{},
+		&model.MetricaMedicion{},
+		&model.ValorMedicion{},
+		&model.FotoProgreso{},
+		&model.AccesoEntrenador{},
+		&model.Meta{},
 		&model.Sesion{},
 		&model.RutinaGrupoMuscular{},
 		&model.Login{},
@@ -46,9 +63,72 @@ func ConnectDB() {
 		log.Fatal("Error al migrar las tablas: ", err)
 	}
 
+	// Índice GIN para la búsqueda de texto completo en el catálogo de rutinas públicas
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_rutinas_busqueda ON rutinas USING GIN (to_tsvector('spanish', coalesce(nombre, '') || ' ' || coalesce(objetivo, '')))").Error; err != nil {
+		log.Fatal("Error al crear índices de búsqueda: ", err)
+	}
+
+	// Fechar con la fecha de su sesión los ejercicios registrados sin fecha propia
+	if err := db.Exec("UPDATE sesion_ejercicios SET fecha = sesiones.fecha FROM sesiones WHERE sesiones.id = sesion_ejercicios.sesion_id AND (sesion_ejercicios.fecha IS NULL OR sesion_ejercicios.fecha < '0002-01-01')").Error; err != nil {
+		log.Fatal("Error al fechar los ejercicios de sesión: ", err)
+	}
+
+	// Sincronizar el contador de favoritas de las rutinas, que ordena el catálogo público
+	if err := db.Exec("UPDATE rutinas SET favorite_count = (SELECT COUNT(*) FROM favoritas WHERE favoritas.rutina_id = rutinas.id)").Error; err != nil {
+		log.Fatal("Error al sincronizar el contador de favoritas: ", err)
+	}
+
+	// Claves foráneas del catálogo de ejercicios
+	createForeignKeys(db)
+
 	fmt.Println("✅ Migraciones completadas")
 
 	var tables []string
 	DB.Raw("SELECT tablename FROM pg_tables WHERE schemaname = 'public'").Scan(&tables)
 	fmt.Println("Tablas:", tables)
 }
+
+// foreignKey describe una clave foránea creada tras la migración
+type foreignKey struct {
+	name, table, column, refTable, onDelete string
+}
+
+// catalogForeignKeys protege las referencias al catálogo de ejercicios
+var catalogForeignKeys = []foreignKey{
+	{"fk_ejercicios_tipo_ejercicio", "ejercicios", "tipo_ejercicio_id", "tipo_ejercicio", "RESTRICT"},
+	{"fk_ejercicios_grupo_muscular", "ejercicios", "grupo_muscular_id", "grupos_musculares", "RESTRICT"},
+	{"fk_ejercicio_grupo_muscular_ejercicio", "ejercicio_grupo_muscular", "ejercicio_id", "ejercicios", "CASCADE"},
+	{"fk_ejercicio_grupo_muscular_grupo", "ejercicio_grupo_muscular", "grupo_muscular_id", "grupos_musculares", "RESTRICT"},
+	{"fk_ejercicio_alternativas_ejercicio", "ejercicio_alternativas", "ejercicio_id", "ejercicios", "CASCADE"},
+	{"fk_ejercicio_alternativas_alternativa", "ejercicio_alternativas", "alternativa_id", "ejercicios", "CASCADE"},
+	{"fk_rutina_grupo_muscular_grupo", "rutina_grupo_muscular", "grupo_muscular_id", "grupos_musculares", "RESTRICT"},
+	{"fk_sesion_ejercicios_ejercicio", "sesion_ejercicios", "ejercicio_id", "ejercicios", "RESTRICT"},
+	{"fk_sesion_ejercicios_ejercicio_original", "sesion_ejercicios", "ejercicio_original_id", "ejercicios", "SET NULL"},
+}
+
+// createForeignKeys crea las claves foráneas que faltan. Se crean como NOT VALID para no bloquear el arranque
+// con datos huérfanos existentes y luego se intenta validarlas.
+func createForeignKeys(db *gorm.DB) {
+	for _, fk := range catalogForeignKeys {
+		var exists int64
+		if err := db.Raw("SELECT COUNT(*) FROM pg_constraint WHERE conname = ?", fk.name).Scan(&exists).Error; err != nil {
+			log.Fatal("Error al consultar claves foráneas: ", err)
+		}
+		if exists == 0 {
+			sql := fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s (id) ON DELETE %s NOT VALID",
+				fk.table, fk.name, fk.column, fk.refTable, fk.onDelete)
+			if err := db.Exec(sql).Error; err != nil {
+				log.Fatal("Error al crear la clave foránea "+fk.name+": ", err)
+			}
+		}
+
+		var validated bool
+		db.Raw("SELECT convalidated FROM pg_constraint WHERE conname = ?", fk.name).Scan(&validated)
+		if validated {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s VALIDATE CONSTRAINT %s", fk.table, fk.name)).Error; err != nil {
+			log.Printf("⚠️  La clave foránea %s tiene referencias huérfanas y solo se aplica a nuevos registros: %v", fk.name, err)
+		}
+	}
+}
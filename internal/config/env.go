@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config maneja la configuración de la aplicación
@@ -16,10 +17,18 @@ type Config struct {
 	JWTSecret              string
 	JWTExpirationMinutes   string
 	RefreshExpirationHours string
+	MediaStoragePath       string
+	MediaSigningSecret     string
+	MediaURLTTLMinutes     string
+	MaxImageSizeMB         string
+	MaxVideoSizeMB         string
+	WeeklySetsTargetMin    string
+	WeeklySetsTargetMax    string
 }
 
 // LoadConfig carga la configuración desde variables de entorno
 func LoadConfig() *Config {
+	jwtSecret := getEnv("JWT_SECRET", "supersecreto123")
 	return &Config{
 		DBHost:                 getEnv("DB_HOST", "localhost"),
 		DBPort:                 getEnv("DB_PORT", "5432"),
@@ -27,9 +36,16 @@ func LoadConfig() *Config {
 		DBPassword:             getEnv("DB_PASSWORD", "admini"),
 		DBName:                 getEnv("DB_NAME", "gym"),
 		ServerPort:             getEnv("SERVER_PORT", "8080"),
-		JWTSecret:              getEnv("JWT_SECRET", "supersecreto123"),
+		JWTSecret:              jwtSecret,
 		JWTExpirationMinutes:   getEnv("JWT_EXPIRATION_MINUTES", "60"),
 		RefreshExpirationHours: getEnv("REFRESH_EXPIRATION_HOURS", "7"),
+		MediaStoragePath:       getEnv("MEDIA_STORAGE_PATH", "./uploads"),
+		MediaSigningSecret:     getEnv("MEDIA_SIGNING_SECRET", jwtSecret),
+		MediaURLTTLMinutes:     getEnv("MEDIA_URL_TTL_MINUTES", "15"),
+		MaxImageSizeMB:         getEnv("MAX_IMAGE_SIZE_MB", "5"),
+		MaxVideoSizeMB:         getEnv("MAX_VIDEO_SIZE_MB", "50"),
+		WeeklySetsTargetMin:    getEnv("WEEKLY_SETS_TARGET_MIN", "10"),
+		WeeklySetsTargetMax:    getEnv("WEEKLY_SETS_TARGET_MAX", "20"),
 	}
 }
 
@@ -66,3 +82,43 @@ func (c *Config) GetRefreshExpirationHours() int {
 func (c *Config) GetRefreshMaxAge() int {
 	return c.GetRefreshExpirationHours() * 3600 // Devolver en segundos
 }
+
+// GetMediaURLTTL devuelve la validez de las URLs firmadas de archivos
+func (c *Config) GetMediaURLTTL() time.Duration {
+	minutes, err := strconv.Atoi(c.MediaURLTTLMinutes)
+	if err != nil || minutes <= 0 {
+		minutes = 15 // fallback a 15 minutos
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// GetMaxImageBytes devuelve el tamaño máximo permitido para imágenes subidas
+func (c *Config) GetMaxImageBytes() int64 {
+	return megabytes(c.MaxImageSizeMB, 5)
+}
+
+// GetMaxVideoBytes devuelve el tamaño máximo permitido para videos subidos
+func (c *Config) GetMaxVideoBytes() int64 {
+	return megabytes(c.MaxVideoSizeMB, 50)
+}
+
+// GetWeeklySetsTargets devuelve el rango objetivo de series semanales por grupo muscular
+func (c *Config) GetWeeklySetsTargets() (float64, float64) {
+	minSets, err := strconv.ParseFloat(c.WeeklySetsTargetMin, 64)
+	if err != nil || minSets < 0 {
+		minSets = 10
+	}
+	maxSets, err := strconv.ParseFloat(c.WeeklySetsTargetMax, 64)
+	if err != nil || maxSets < minSets {
+		maxSets = max(20, minSets)
+	}
+	return minSets, maxSets
+}
+
+func megabytes(value string, fallback int) int64 {
+	mb, err := strconv.Atoi(value)
+	if err != nil || mb <= 0 {
+		mb = fallback
+	}
+	return int64(mb) << 20
+}
@@ -30,3 +30,10 @@ func (mf *MiddlewareFactory) CreateUserDeletionAuthMiddleware() gin.HandlerFunc
 func (mf *MiddlewareFactory) CreateJWTAuthMiddleware() gin.HandlerFunc {
 	return auth.JWTAuthMiddleware(mf.container.JWTConfig)
 }
+
+// CreateCatalogAdminMiddleware crea el middleware de autorización para gestionar el catálogo compartido
+func (mf *MiddlewareFactory) CreateCatalogAdminMiddleware() gin.HandlerFunc {
+	roleAdapter := adapters.NewRoleAdapter(mf.container.RoleRepo)
+	allowedRoles := []string{models.RoleAdmin, models.RoleDev}
+	return auth.RequireRoleMiddleware(roleAdapter, allowedRoles...)
+}
@@ -12,15 +12,30 @@ import (
 
 // Seeder maneja la creación de datos iniciales
 type Seeder struct {
-	roleRepo repositories.RoleRepository
-	userRepo repositories.UsuarioRepository
+	roleRepo         repositories.RoleRepository
+	userRepo         repositories.UsuarioRepository
+	muscleGroupRepo  repositories.GrupoMuscularRepository
+	exerciseTypeRepo repositories.TypeExerciseRepository
+	exerciseRepo     repositories.ExerciseRepository
+	translationRepo  repositories.TranslationRepository
 }
 
 // NewSeeder crea una nueva instancia del seeder
-func NewSeeder(roleRepo repositories.RoleRepository, userRepo repositories.UsuarioRepository) *Seeder {
+func NewSeeder(
+	roleRepo repositories.RoleRepository,
+	userRepo repositories.UsuarioRepository,
+	muscleGroupRepo repositories.GrupoMuscularRepository,
+	exerciseTypeRepo repositories.TypeExerciseRepository,
+	exerciseRepo repositories.ExerciseRepository,
+	translationRepo repositories.TranslationRepository,
+) *Seeder {
 	return &Seeder{
-		roleRepo: roleRepo,
-		userRepo: userRepo,
+		roleRepo:         roleRepo,
+		userRepo:         userRepo,
+		muscleGroupRepo:  muscleGroupRepo,
+		exerciseTypeRepo: exerciseTypeRepo,
+		exerciseRepo:     exerciseRepo,
+		translationRepo:  translationRepo,
 	}
 }
 
@@ -38,6 +53,11 @@ func (s *Seeder) Seed() error {
 		return err
 	}
 
+	// Cargar el catálogo estándar de ejercicios
+	if err := s.SeedCatalog(); err != nil {
+		return err
+	}
+
 	log.Println("✅ Seeding completado exitosamente")
 	return nil
 }
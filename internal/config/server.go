@@ -19,9 +19,18 @@ type Server struct {
 func NewServer(container *Container, cfg *Config) *Server {
 	router := gin.Default()
 
+	// Reportar los errores de validación con el nombre JSON de cada campo
+	middleware.RegisterValidation()
+
+	// Identificar cada petición para poder trazar sus errores en los logs
+	router.Use(middleware.RequestID())
+
 	// Registrar el middleware de errores globalmente.
 	router.Use(middleware.ErrorHandler())
 
+	// Resolver el idioma de la respuesta a partir de Accept-Language
+	router.Use(middleware.Locale())
+
 	server := &Server{
 		router:    router,
 		container: container,
@@ -48,16 +57,29 @@ func (s *Server) setupRoutes() {
 	// Rutas públicas (sin autenticación)
 	handler.NewAuthHandler(apiV1, s.container.UsuarioService, s.container.RefreshTokenService, s.config)
 
+	// Descarga de archivos protegida por URLs firmadas
+	handler.NewMediaHandler(apiV1, s.container.MediaService, s.container.MediaSigner)
+
 	// Grupo de rutas protegidas con JWT
 	protected := apiV1.Group("/")
 	protected.Use(middlewareFactory.CreateJWTAuthMiddleware())
 
+	// Preferencias del usuario en una sola consulta: el idioma tiene prioridad sobre Accept-Language
+	// y las unidades se usan para convertir pesos y longitudes
+	protected.Use(middleware.UserPreferences(s.container.UsuarioService.GetPreferences))
+
 	// Configurar handlers protegidos
 	handler.NewRoleHandler(protected, s.container.RoleService)
 
 	// Configurar handler de usuarios con autorización especial para eliminación
 	handler.NewUsuarioHandlerWithAuth(protected, s.container.UsuarioService, middlewareFactory.CreateUserDeletionAuthMiddleware())
 
+	// Configurar handler de ejercicios con autorización para el catálogo compartido
+	handler.NewExerciseHandler(protected, s.container.EjercicioService, s.container.TraduccionService, s.container.MediaSigner, middlewareFactory.CreateCatalogAdminMiddleware())
+
+	// Configurar traducciones del catálogo; solo los administradores pueden editarlas
+	handler.NewTranslationHandler(protected, s.container.TraduccionService, middlewareFactory.CreateCatalogAdminMiddleware())
+
 	// Configurar otros handlers
 	s.setupOtherHandlers(protected)
 }
@@ -65,14 +87,18 @@ func (s *Server) setupRoutes() {
 // setupOtherHandlers configura los handlers que no requieren autorización especial
 func (s *Server) setupOtherHandlers(protected *gin.RouterGroup) {
 	handler.NewRoutineHandler(protected, s.container.RutinaService)
-	handler.NewGrupoMuscularHandler(protected, s.container.GrupoMuscularService)
+	handler.NewGrupoMuscularHandler(protected, s.container.GrupoMuscularService, s.container.TraduccionService)
 	handler.NewRoutineMuscleGroupHandler(protected, s.container.RutinaGMService)
 	handler.NewFavoriteHandler(protected, s.container.FavoritaService)
 	handler.NewMeasurementHandler(protected, s.container.MedicionService)
-	handler.NewTypeExerciseHandler(protected, s.container.TipoEjercicioService)
-	handler.NewExerciseHandler(protected, s.container.EjercicioService)
+	handler.NewProgressPhotoHandler(protected, s.container.FotoProgresoService)
+	handler.NewCoachHandler(protected, s.container.EntrenadorService)
+	handler.NewTypeExerciseHandler(protected, s.container.TipoEjercicioService, s.container.TraduccionService)
 	handler.NewSessionHandler(protected, s.container.SesionService)
 	handler.NewSessionExerciseHandler(protected, s.container.SesionEjercicioService)
+	handler.NewPersonalRecordHandler(protected, s.container.RecordService)
+	handler.NewGoalHandler(protected, s.container.MetaService)
+	handler.NewAnalyticsHandler(protected, s.container.AnalyticsService, s.container.TraduccionService)
 }
 
 // Run inicia el servidor en el puerto especificado
@@ -11,16 +11,40 @@ import (
 // Estos errores son la "lingua franca" entre los casos de uso y los handlers.
 // Representan conceptos de negocio, no fallos de implementación.
 
-// ErrorResponse defines the structure of the JSON error response.
-// ErrorResponse is a standard error response for API endpoints.
+// ErrorResponse defines the structure of the legacy JSON error response, wrapped in {"error": ...}.
+// It is only sent to clients that accept application/json but not application/problem+json.
 // @Description Standard error response
 // @name ErrorResponse
 // @produce json
 // @Success 400 {object} errors.ErrorResponse
 // @Failure 500 {object} errors.ErrorResponse
 type ErrorResponse struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// ProblemDetails is an RFC 7807 error response, sent as application/problem+json.
+// @Description RFC 7807 problem details
+type ProblemDetails struct {
+	Type      string       `json:"type" example:"urn:gymbro:problem:validation-failed"`
+	Title     string       `json:"title" example:"Bad Request"`
+	Status    int          `json:"status" example:"400"`
+	Detail    string       `json:"detail" example:"One or more fields are invalid."`
+	Instance  string       `json:"instance" example:"/api/v1/sessions"`
+	Code      string       `json:"code" example:"VALIDATION_FAILED"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	Details   interface{}  `json:"details,omitempty"`
+	RequestID string       `json:"request_id" example:"4f9c2b7e8a1d4c3b9e6f0a2d5c8b1e7f"`
+}
+
+// FieldError describes a field of the request body that failed validation.
+// @Description Field level validation error
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Rule    string `json:"rule" example:"required"`
+	Param   string `json:"param,omitempty" example:"8"`
+	Message string `json:"message" example:"email is required"`
 }
 
 // AppError represents a custom application error.
@@ -28,6 +52,7 @@ type AppError struct {
 	HTTPStatus int
 	Code       string
 	Message    string
+	Details    interface{}
 	Err        error
 }
 
@@ -54,6 +79,13 @@ func NewAppError(httpStatus int, code, message string, err error) *AppError {
 	}
 }
 
+// WithDetails returns a copy of the error carrying additional details for the client.
+func (e *AppError) WithDetails(details interface{}) *AppError {
+	clone := *e
+	clone.Details = details
+	return &clone
+}
+
 // Standard application errors.
 var (
 	ErrNotFound               = NewAppError(http.StatusNotFound, "NOT_FOUND", "Resource not found.", nil)
@@ -66,6 +98,8 @@ var (
 	ErrInvalidCredentials     = NewAppError(http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid credentials provided.", nil)
 	ErrInvalidRefreshToken    = NewAppError(http.StatusUnauthorized, "INVALID_REFRESH_TOKEN", "The provided refresh token is invalid or has expired.", nil)
 	ErrSystemRoleNotDeletable = NewAppError(http.StatusBadRequest, "SYSTEM_ROLE_NOT_DELETABLE", "System roles cannot be deleted.", nil)
+	ErrInvalidReference       = NewAppError(http.StatusBadRequest, "INVALID_REFERENCE", "A referenced resource does not exist.", nil)
+	ErrValidation             = NewAppError(http.StatusBadRequest, "VALIDATION_FAILED", "One or more fields are invalid.", nil)
 
 	// User validation errors
 	ErrEmailRequired         = NewAppError(http.StatusBadRequest, "EMAIL_REQUIRED", "Email is required.", nil)
@@ -83,4 +117,13 @@ var (
 	ErrUserAlreadyDeleted    = NewAppError(http.StatusBadRequest, "USER_ALREADY_DELETED", "User is already deleted.", nil)
 	ErrUserNotDeleted        = NewAppError(http.StatusBadRequest, "USER_NOT_DELETED", "User is not deleted.", nil)
 	ErrEmailSoftDeleted      = NewAppError(http.StatusConflict, "EMAIL_SOFT_DELETED", "Email belongs to a deleted user.", nil)
+
+	// Session lifecycle errors
+	ErrInvalidSessionTransition = NewAppError(http.StatusConflict, "INVALID_SESSION_TRANSITION", "The session cannot change to the requested state.", nil)
+	ErrSessionClosed            = NewAppError(http.StatusConflict, "SESSION_CLOSED", "The session is already completed or abandoned.", nil)
+	ErrSessionNotInProgress     = NewAppError(http.StatusConflict, "SESSION_NOT_IN_PROGRESS", "The session is not in progress.", nil)
+
+	// Routine errors
+	ErrRoutineNotPublic = NewAppError(http.StatusForbidden, "ROUTINE_NOT_PUBLIC", "The routine is private and belongs to another user.", nil)
+	ErrRoutineNotOwned  = NewAppError(http.StatusForbidden, "ROUTINE_NOT_OWNED", "Only the owner can modify the routine.", nil)
 )
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// AccesoEntrenador authorizes a coach to see the private data of a user, such as their progress photos.
+// Access is granted and revoked by the user.
+type AccesoEntrenador struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UsuarioID    uint      `gorm:"not null;uniqueIndex:idx_acceso_usuario_entrenador" json:"usuario_id"`
+	EntrenadorID uint      `gorm:"not null;uniqueIndex:idx_acceso_usuario_entrenador;index" json:"entrenador_id"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Name and email of the other party, filled when listing coaches or clients
+	Nombre string `gorm:"-" json:"nombre,omitempty" example:"John Doe"`
+	Email  string `gorm:"-" json:"email,omitempty" example:"john@example.com"`
+}
+
+func (AccesoEntrenador) TableName() string {
+	return "accesos_entrenador"
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// One-rep max estimation formulas
+const (
+	FormulaEpley   = "epley"
+	FormulaBrzycki = "brzycki"
+)
+
+// Aggregation periods for analytics
+const (
+	PeriodoSemana = "week"
+	PeriodoMes    = "month"
+)
+
+// ProgresionEjercicio is the estimated strength of a user on an exercise over time
+type ProgresionEjercicio struct {
+	EjercicioID uint              `json:"ejercicio_id"`
+	Formula     string            `json:"formula" example:"epley"`
+	Periodo     string            `json:"periodo" example:"week"`
+	Desde       time.Time         `json:"desde"`
+	Hasta       time.Time         `json:"hasta"`
+	Puntos      []PuntoProgresion `json:"puntos"`
+	// Trend of the estimated 1RM in weight units per period, from a least squares fit
+	Pendiente float64 `json:"pendiente" example:"1.25"`
+	// Change between the first and the last period, in percent; nil with fewer than two periods
+	CambioPorcentual *float64 `json:"cambio_porcentual,omitempty" example:"8.5"`
+}
+
+// PuntoProgresion is the best estimated 1RM of a period
+type PuntoProgresion struct {
+	Inicio       time.Time `json:"inicio"`
+	UnaRepMaxima float64   `json:"una_rep_maxima" example:"120"`
+	Sesiones     int       `json:"sesiones" example:"2"`
+}
+
+// EstimateOneRepMaxWith estimates the one-rep max with the given formula; Brzycki is only defined below 37 reps
+func EstimateOneRepMaxWith(formula string, peso float64, repeticiones int) float64 {
+	if formula != FormulaBrzycki {
+		return EstimateOneRepMax(peso, repeticiones)
+	}
+	if peso <= 0 || repeticiones <= 0 || repeticiones >= 37 {
+		return 0
+	}
+	return round2(peso * 36 / float64(37-repeticiones))
+}
+
+// PeriodStart truncates a date to the start of its ISO week (Monday) or month, in UTC
+func PeriodStart(periodo string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if periodo == PeriodoMes {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// periodsBetween returns how many weeks or months separate two period starts
+func periodsBetween(periodo string, from, to time.Time) float64 {
+	if periodo == PeriodoMes {
+		return float64((to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()))
+	}
+	return to.Sub(from).Hours() / (24 * 7)
+}
+
+// ComputeTrend fills the slope and percentage change of the progression from its points
+func (p *ProgresionEjercicio) ComputeTrend() {
+	p.Pendiente = 0
+	p.CambioPorcentual = nil
+	if len(p.Puntos) < 2 {
+		return
+	}
+
+	first, last := p.Puntos[0], p.Puntos[len(p.Puntos)-1]
+	p.CambioPorcentual = PercentChange(first.UnaRepMaxima, last.UnaRepMaxima)
+
+	xs := make([]float64, len(p.Puntos))
+	ys := make([]float64, len(p.Puntos))
+	for i, punto := range p.Puntos {
+		xs[i] = periodsBetween(p.Periodo, first.Inicio, punto.Inicio)
+		ys[i] = punto.UnaRepMaxima
+	}
+	p.Pendiente = round2(linearSlope(xs, ys))
+}
+
+// linearSlope returns the least-squares slope of the points, or zero when it is undefined
+func linearSlope(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	var sumX, sumY, sumXY, sumXX float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumXX += xs[i] * xs[i]
+	}
+	denominator := n*sumXX - sumX*sumX
+	if math.Abs(denominator) < 1e-9 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// Training load status of a muscle group against the weekly set targets
+const (
+	CargaInsuficiente = "under"
+	CargaOptima       = "optimal"
+	CargaExcesiva     = "over"
+)
+
+// CargaGrupoMuscular is the work a muscle group received in a period. Series counts working sets, every
+// set with at least one rep. Secondary muscle groups count half of the sets and tonnage of the exercise.
+type CargaGrupoMuscular struct {
+	GrupoMuscularID uint    `json:"grupo_muscular_id"`
+	Tonelaje        float64 `json:"tonelaje" example:"8450"`
+	Series          float64 `json:"series" example:"12.5"`
+}
+
+// VolumenGrupoMuscular compares the load of a muscle group with the previous week and the targets
+type VolumenGrupoMuscular struct {
+	GrupoMuscularID  uint     `json:"grupo_muscular_id"`
+	Nombre           string   `json:"nombre"`
+	Tonelaje         float64  `json:"tonelaje" example:"8450"`
+	Series           float64  `json:"series" example:"12.5"`
+	TonelajeAnterior float64  `json:"tonelaje_anterior" example:"7900"`
+	SeriesAnterior   float64  `json:"series_anterior" example:"11"`
+	CambioTonelaje   *float64 `json:"cambio_tonelaje,omitempty" example:"6.96"` // percent; nil without previous tonnage
+	Estado           string   `json:"estado" example:"optimal"`
+}
+
+// DashboardVolumen is the weekly training load per muscle group
+type DashboardVolumen struct {
+	Semana            time.Time              `json:"semana"`
+	SemanaAnterior    time.Time              `json:"semana_anterior"`
+	ObjetivoSeriesMin float64                `json:"objetivo_series_min" example:"10"`
+	ObjetivoSeriesMax float64                `json:"objetivo_series_max" example:"20"`
+	Grupos            []VolumenGrupoMuscular `json:"grupos"`
+}
+
+// LoadStatus classifies a weekly set count against the target range
+func LoadStatus(series, minSeries, maxSeries float64) string {
+	switch {
+	case series < minSeries:
+		return CargaInsuficiente
+	case series > maxSeries:
+		return CargaExcesiva
+	default:
+		return CargaOptima
+	}
+}
+
+// PercentChange returns the change from previous to current in percent, or nil when previous is zero
+func PercentChange(previous, current float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	cambio := round2((current - previous) / previous * 100)
+	return &cambio
+}
+
+// DiaActividad is the training activity of a day, for calendar heatmaps
+type DiaActividad struct {
+	Fecha       time.Time `json:"fecha"`
+	Sesiones    int       `json:"sesiones" example:"1"`
+	DuracionMin int       `json:"duracion_min" example:"65"`
+}
+
+// RachasEntrenamiento are the consecutive days and weeks with at least one session. Current
+// streaks stay alive until a full day or week passes without training.
+type RachasEntrenamiento struct {
+	DiariaActual  int `json:"diaria_actual" example:"3"`
+	DiariaMaxima  int `json:"diaria_maxima" example:"12"`
+	SemanalActual int `json:"semanal_actual" example:"8"`
+	SemanalMaxima int `json:"semanal_maxima" example:"20"`
+}
+
+// ResumenConsistencia summarizes how regularly a user trained over a period
+type ResumenConsistencia struct {
+	Desde                  time.Time           `json:"desde"`
+	Hasta                  time.Time           `json:"hasta"`
+	TotalSesiones          int                 `json:"total_sesiones" example:"120"`
+	DiasActivos            int                 `json:"dias_activos" example:"110"`
+	PromedioSesionesSemana float64             `json:"promedio_sesiones_semana" example:"2.31"`
+	Rachas                 RachasEntrenamiento `json:"rachas"`
+	Dias                   []DiaActividad      `json:"dias"`
+}
+
+// ComputeAverages fills the totals and the sessions-per-week average from the days of the period.
+// The average only counts the weeks elapsed up to the given day, so the current year is not diluted.
+func (r *ResumenConsistencia) ComputeAverages(today time.Time) {
+	r.TotalSesiones, r.DiasActivos = 0, 0
+	for _, d := range r.Dias {
+		r.TotalSesiones += d.Sesiones
+		if d.Sesiones > 0 {
+			r.DiasActivos++
+		}
+	}
+	hasta := r.Hasta
+	if today.Before(hasta) {
+		hasta = today
+	}
+	weeks := (hasta.Sub(r.Desde).Hours()/24 + 1) / 7
+	if weeks > 0 {
+		r.PromedioSesionesSemana = round2(float64(r.TotalSesiones) / weeks)
+	}
+}
@@ -0,0 +1,148 @@
+package models
+
+import "time"
+
+// VentanaMediaMovil is the number of days averaged to smooth the daily noise of the body weight
+const VentanaMediaMovil = 7
+
+// EstadisticaMetrica summarizes a body metric over a period. TendenciaSemanal is the least-squares
+// slope in units per week.
+type EstadisticaMetrica struct {
+	Inicial          float64 `json:"inicial" example:"82.4"`
+	Actual           float64 `json:"actual" example:"80.1"`
+	Minimo           float64 `json:"minimo" example:"79.8"`
+	Maximo           float64 `json:"maximo" example:"82.9"`
+	Cambio           float64 `json:"cambio" example:"-2.3"`
+	TendenciaSemanal float64 `json:"tendencia_semanal" example:"-0.35"`
+}
+
+// PuntoComposicion is a measurement of the summary with its smoothed body weight
+type PuntoComposicion struct {
+	Fecha         time.Time `json:"fecha"`
+	PesoCorporal  float64   `json:"peso_corporal" example:"80.4"`
+	PesoMedia     float64   `json:"peso_media" example:"80.7"` // moving average of the previous VentanaMediaMovil days
+	GrasaCorporal float64   `json:"grasa_corporal,omitempty" example:"18.5"`
+	Musculo       float64   `json:"musculo,omitempty" example:"36.2"`
+	MasaMagra     *float64  `json:"masa_magra,omitempty" example:"64.8"`
+	MasaGrasa     *float64  `json:"masa_grasa,omitempty" example:"15.2"`
+}
+
+// ResumenMediciones summarizes the body composition of a user over a period. IMC and FFMI are the
+// ones of the last measurement and require the height on the profile.
+type ResumenMediciones struct {
+	Desde         time.Time           `json:"desde"`
+	Hasta         time.Time           `json:"hasta"`
+	Mediciones    int                 `json:"mediciones" example:"24"`
+	AlturaCm      float64             `json:"altura_cm,omitempty" example:"180"`
+	IMC           *float64            `json:"imc,omitempty" example:"24.69"`
+	FFMI          *float64            `json:"ffmi,omitempty" example:"20.0"`
+	Peso          *EstadisticaMetrica `json:"peso,omitempty"`
+	PesoMedia     *EstadisticaMetrica `json:"peso_media,omitempty"`
+	GrasaCorporal *EstadisticaMetrica `json:"grasa_corporal,omitempty"`
+	Musculo       *EstadisticaMetrica `json:"musculo,omitempty"`
+	MasaMagra     *EstadisticaMetrica `json:"masa_magra,omitempty"`
+	MasaGrasa     *EstadisticaMetrica `json:"masa_grasa,omitempty"`
+	Puntos        []PuntoComposicion  `json:"puntos"`
+}
+
+// SummarizeMeasurements builds the summary of the measurements in [desde, hasta). The measurements must be
+// sorted by date; earlier ones are only used to warm up the moving average.
+func SummarizeMeasurements(mediciones []Medicion, desde, hasta time.Time, alturaCm float64) *ResumenMediciones {
+	resumen := &ResumenMediciones{
+		Desde:    desde,
+		Hasta:    hasta.AddDate(0, 0, -1),
+		AlturaCm: alturaCm,
+		Puntos:   []PuntoComposicion{},
+	}
+
+	var window []Medicion
+	series := map[string]*metricSeries{}
+	add := func(name string, fecha time.Time, value float64) {
+		if value <= 0 {
+			return
+		}
+		s, ok := series[name]
+		if !ok {
+			s = &metricSeries{}
+			series[name] = s
+		}
+		s.fechas = append(s.fechas, fecha)
+		s.values = append(s.values, value)
+	}
+
+	for i := range mediciones {
+		m := mediciones[i]
+		if m.PesoCorporal <= 0 || !m.Fecha.Before(hasta) {
+			continue
+		}
+		window = append(window, m)
+		for len(window) > 0 && !window[0].Fecha.After(m.Fecha.AddDate(0, 0, -VentanaMediaMovil)) {
+			window = window[1:]
+		}
+		if m.Fecha.Before(desde) {
+			continue
+		}
+
+		var sum float64
+		for _, w := range window {
+			sum += float64(w.PesoCorporal)
+		}
+		m.ComputeComposition(alturaCm)
+		punto := PuntoComposicion{
+			Fecha:         m.Fecha,
+			PesoCorporal:  round2(float64(m.PesoCorporal)),
+			PesoMedia:     round2(sum / float64(len(window))),
+			GrasaCorporal: round2(float64(m.GrasaCorporal)),
+			Musculo:       round2(float64(m.Musculo)),
+			MasaMagra:     m.MasaMagra,
+			MasaGrasa:     m.MasaGrasa,
+		}
+		resumen.Puntos = append(resumen.Puntos, punto)
+		resumen.IMC, resumen.FFMI = m.IMC, m.FFMI
+
+		add("peso", m.Fecha, punto.PesoCorporal)
+		add("peso_media", m.Fecha, punto.PesoMedia)
+		add("grasa", m.Fecha, punto.GrasaCorporal)
+		add("musculo", m.Fecha, punto.Musculo)
+		if m.MasaMagra != nil {
+			add("magra", m.Fecha, *m.MasaMagra)
+			add("masa_grasa", m.Fecha, *m.MasaGrasa)
+		}
+	}
+
+	resumen.Mediciones = len(resumen.Puntos)
+	resumen.Peso = series["peso"].stats()
+	resumen.PesoMedia = series["peso_media"].stats()
+	resumen.GrasaCorporal = series["grasa"].stats()
+	resumen.Musculo = series["musculo"].stats()
+	resumen.MasaMagra = series["magra"].stats()
+	resumen.MasaGrasa = series["masa_grasa"].stats()
+	return resumen
+}
+
+// metricSeries holds the dated values of a metric
+type metricSeries struct {
+	fechas []time.Time
+	values []float64
+}
+
+func (s *metricSeries) stats() *EstadisticaMetrica {
+	if s == nil || len(s.values) == 0 {
+		return nil
+	}
+	stats := &EstadisticaMetrica{
+		Inicial: s.values[0],
+		Actual:  s.values[len(s.values)-1],
+		Minimo:  s.values[0],
+		Maximo:  s.values[0],
+	}
+	weeks := make([]float64, len(s.values))
+	for i, v := range s.values {
+		stats.Minimo = min(stats.Minimo, v)
+		stats.Maximo = max(stats.Maximo, v)
+		weeks[i] = s.fechas[i].Sub(s.fechas[0]).Hours() / (24 * 7)
+	}
+	stats.Cambio = round2(stats.Actual - stats.Inicial)
+	stats.TendenciaSemanal = round2(linearSlope(weeks, s.values))
+	return stats
+}
@@ -1,5 +1,41 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
+// Equipment types
+const (
+	EquipoBarra        = "barbell"
+	EquipoMancuernas   = "dumbbell"
+	EquipoMaquina      = "machine"
+	EquipoPesoCorporal = "bodyweight"
+	EquipoPolea        = "cable"
+	EquipoKettlebell   = "kettlebell"
+	EquipoBanda        = "band"
+	EquipoOtro         = "other"
+)
+
+// Difficulty levels
+const (
+	DificultadPrincipiante = "beginner"
+	DificultadIntermedia   = "intermediate"
+	DificultadAvanzada     = "advanced"
+)
+
+// Muscle group roles for an exercise
+const (
+	RolGrupoPrimario   = "primary"
+	RolGrupoSecundario = "secondary"
+)
+
+// EquiposValidos lists the accepted equipment values
+var EquiposValidos = []string{EquipoBarra, EquipoMancuernas, EquipoMaquina, EquipoPesoCorporal, EquipoPolea, EquipoKettlebell, EquipoBanda, EquipoOtro}
+
+// DificultadesValidas lists the accepted difficulty values
+var DificultadesValidas = []string{DificultadPrincipiante, DificultadIntermedia, DificultadAvanzada}
+
 // Ejercicio representa un ejercicio físico.
 // @Description Exercise model
 // @name Ejercicio
@@ -8,9 +44,129 @@ type Ejercicio struct {
 	ID              uint   `gorm:"primaryKey" json:"id"`
 	Nombre          string `json:"nombre"`
 	TipoEjercicioID uint   `json:"tipo_ejercicio_id"`
-	GrupoMuscularID uint   `json:"grupo_muscular_id"`
+	GrupoMuscularID uint   `json:"grupo_muscular_id"` // Main primary muscle group
+
+	// Owner of a custom exercise; shared catalog exercises have no owner
+	UsuarioID *uint `gorm:"index" json:"usuario_id,omitempty"`
+	IsCustom  bool  `gorm:"default:false;index" json:"is_custom"`
+
+	Descripcion   string `json:"descripcion"`
+	Instrucciones string `json:"instrucciones"`
+	Equipamiento  string `gorm:"index" json:"equipamiento" example:"barbell"`
+	Dificultad    string `gorm:"index" json:"dificultad" example:"intermediate"`
+	Unilateral    bool   `gorm:"default:false" json:"unilateral"`
+	ImagenURL     string `json:"imagen_url,omitempty"`
+	VideoURL      string `json:"video_url,omitempty"`
+
+	// Uploaded media stored in the blob store
+	ImagenKey    string `json:"-"`
+	MiniaturaKey string `json:"-"`
+	VideoKey     string `json:"-"`
+
+	// Signed download URLs for uploaded media, computed per request
+	Media *EjercicioMedia `gorm:"-" json:"media,omitempty"`
+
+	// Primary and secondary muscle groups
+	GruposMusculares []EjercicioGrupoMuscular `gorm:"foreignKey:EjercicioID" json:"grupos_musculares"`
+
+	// Relations embedded on request with ?include=
+	TipoEjercicio *TipoEjercicio `gorm:"foreignKey:TipoEjercicioID;->;-:migration" json:"tipo_ejercicio,omitempty"`
+	GrupoMuscular *GrupoMuscular `gorm:"foreignKey:GrupoMuscularID;->;-:migration" json:"grupo_muscular,omitempty"`
 }
 
 func (e *Ejercicio) TableName() string {
 	return "ejercicios"
 }
+
+// IsOwnedBy checks if the exercise is a custom exercise of the given user
+func (e *Ejercicio) IsOwnedBy(userID uint) bool {
+	return e.IsCustom && e.UsuarioID != nil && *e.UsuarioID == userID
+}
+
+// IsVisibleTo checks if the user can see and use the exercise
+func (e *Ejercicio) IsVisibleTo(userID uint) bool {
+	return !e.IsCustom || e.IsOwnedBy(userID)
+}
+
+// Promote moves a custom exercise into the shared catalog
+func (e *Ejercicio) Promote() {
+	e.IsCustom = false
+	e.UsuarioID = nil
+}
+
+// Similarity scores how good a substitute other is: shared main muscle group, exercise type and equipment
+func (e *Ejercicio) Similarity(other *Ejercicio) int {
+	score := 0
+	if e.GrupoMuscularID != 0 && e.GrupoMuscularID == other.GrupoMuscularID {
+		score += 3
+	}
+	if e.TipoEjercicioID != 0 && e.TipoEjercicioID == other.TipoEjercicioID {
+		score += 2
+	}
+	if e.Equipamiento != "" && e.Equipamiento == other.Equipamiento {
+		score++
+	}
+	return score
+}
+
+// HasMedia checks if the exercise has an image or video, linked or uploaded
+func (e *Ejercicio) HasMedia() bool {
+	return e.ImagenURL != "" || e.VideoURL != "" || e.ImagenKey != "" || e.VideoKey != ""
+}
+
+// HasUploadedMedia checks if the exercise has media stored in the blob store
+func (e *Ejercicio) HasUploadedMedia() bool {
+	return e.ImagenKey != "" || e.VideoKey != ""
+}
+
+// EjercicioMedia holds signed download URLs for an exercise's uploaded media
+type EjercicioMedia struct {
+	ImagenURL    string    `json:"imagen_url,omitempty"`
+	MiniaturaURL string    `json:"miniatura_url,omitempty"`
+	VideoURL     string    `json:"video_url,omitempty"`
+	ExpiraEn     time.Time `json:"expira_en"`
+}
+
+// NormalizeMuscleGroups makes GrupoMuscularID and the primary links agree
+// and removes duplicated muscle groups, keeping the primary role
+func (e *Ejercicio) NormalizeMuscleGroups() {
+	if e.GrupoMuscularID == 0 {
+		for _, g := range e.GruposMusculares {
+			if g.Rol == RolGrupoPrimario {
+				e.GrupoMuscularID = g.GrupoMuscularID
+				break
+			}
+		}
+	}
+
+	grupos := make([]EjercicioGrupoMuscular, 0, len(e.GruposMusculares)+1)
+	if e.GrupoMuscularID != 0 {
+		grupos = append(grupos, EjercicioGrupoMuscular{GrupoMuscularID: e.GrupoMuscularID, Rol: RolGrupoPrimario})
+	}
+	for _, g := range e.GruposMusculares {
+		idx := slices.IndexFunc(grupos, func(x EjercicioGrupoMuscular) bool { return x.GrupoMuscularID == g.GrupoMuscularID })
+		if idx >= 0 {
+			if g.Rol == RolGrupoPrimario {
+				grupos[idx].Rol = RolGrupoPrimario
+			}
+			continue
+		}
+		grupos = append(grupos, EjercicioGrupoMuscular{GrupoMuscularID: g.GrupoMuscularID, Rol: g.Rol})
+	}
+	for i := range grupos {
+		grupos[i].EjercicioID = e.ID
+	}
+	e.GruposMusculares = grupos
+}
+
+// EjercicioGrupoMuscular links an exercise to a primary or secondary muscle group
+type EjercicioGrupoMuscular struct {
+	ID              uint   `gorm:"primaryKey" json:"-"`
+	EjercicioID     uint   `gorm:"not null;uniqueIndex:idx_ejercicio_grupo" json:"-"`
+	GrupoMuscularID uint   `gorm:"not null;uniqueIndex:idx_ejercicio_grupo;index" json:"grupo_muscular_id"`
+	Rol             string `gorm:"not null;default:primary" json:"rol" example:"primary"`
+}
+
+func (EjercicioGrupoMuscular) TableName() string {
+	return "ejercicio_grupo_muscular"
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// EjercicioAlternativa is an admin-curated substitution between two exercises; it applies in both directions
+type EjercicioAlternativa struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	EjercicioID   uint      `gorm:"not null;uniqueIndex:idx_ejercicio_alternativa" json:"ejercicio_id"`
+	AlternativaID uint      `gorm:"not null;uniqueIndex:idx_ejercicio_alternativa;index" json:"alternativa_id"`
+	Nota          string    `json:"nota"`
+	CreadaEn      time.Time `json:"creada_en"`
+}
+
+func (EjercicioAlternativa) TableName() string {
+	return "ejercicio_alternativas"
+}
+
+// NewEjercicioAlternativa stores the pair with the lowest ID first so each pair is unique regardless of direction
+func NewEjercicioAlternativa(ejercicioID, alternativaID uint, nota string) *EjercicioAlternativa {
+	if alternativaID < ejercicioID {
+		ejercicioID, alternativaID = alternativaID, ejercicioID
+	}
+	return &EjercicioAlternativa{
+		EjercicioID:   ejercicioID,
+		AlternativaID: alternativaID,
+		Nota:          nota,
+		CreadaEn:      time.Now(),
+	}
+}
+
+// EjercicioSugerido is an exercise suggested as a substitute, ranked by its similarity score
+type EjercicioSugerido struct {
+	Ejercicio  *Ejercicio `json:"ejercicio"`
+	Puntuacion int        `json:"puntuacion" example:"6"`
+	Curada     bool       `json:"curada"`
+}
@@ -2,10 +2,11 @@ package models
 
 import "time"
 
+// Favorita marks a routine as favorite for a user; each (usuario, rutina) pair is unique
 type Favorita struct {
 	ID        uint      `gorm:"primaryKey" json:"id"`
-	UsuarioID uint      `json:"usuario_id"`
-	RutinaID  uint      `json:"rutina_id"`
+	UsuarioID uint      `gorm:"not null;uniqueIndex:idx_favorita_usuario_rutina" json:"usuario_id"`
+	RutinaID  uint      `gorm:"not null;uniqueIndex:idx_favorita_usuario_rutina;index" json:"rutina_id"`
 	Fecha     time.Time `json:"fecha"`
 }
 
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// Progress photo poses
+const (
+	PoseFrente  = "front"
+	PoseLateral = "side"
+	PoseEspalda = "back"
+)
+
+// PosesFoto lists the accepted poses of a progress photo
+var PosesFoto = []string{PoseFrente, PoseLateral, PoseEspalda}
+
+// IsValidPose checks if the pose is one of the accepted progress photo poses
+func IsValidPose(pose string) bool {
+	return slices.Contains(PosesFoto, pose)
+}
+
+// FotoProgreso is a private physique photo attached to a measurement. The image is stored in the blob
+// store without its metadata and is only served to the owner and the coaches they authorized.
+type FotoProgreso struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	MedicionID   uint      `gorm:"not null;index" json:"medicion_id"`
+	UsuarioID    uint      `gorm:"not null;index" json:"usuario_id"`
+	Pose         string    `gorm:"size:16;not null" json:"pose" example:"front"`
+	ContentType  string    `gorm:"size:32;not null" json:"content_type" example:"image/jpeg"`
+	ImagenKey    string    `gorm:"not null" json:"-"`
+	MiniaturaKey string    `gorm:"not null" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (FotoProgreso) TableName() string {
+	return "fotos_progreso"
+}
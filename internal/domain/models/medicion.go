@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 type Medicion struct {
 	ID            uint      `gorm:"primaryKey" json:"id"`
@@ -9,8 +12,42 @@ type Medicion struct {
 	PesoCorporal  float32   `json:"peso_corporal"`
 	GrasaCorporal float32   `json:"grasa_corporal"`
 	Musculo       float32   `json:"musculo"`
+
+	// Values of the standard and custom metrics; the three fields above mirror their standard metrics
+	Valores []ValorMedicion `gorm:"foreignKey:MedicionID" json:"valores,omitempty"`
+
+	// Body composition derived from the measurement and the user's height; absent when it cannot be computed
+	MasaMagra *float64 `gorm:"-" json:"masa_magra,omitempty" example:"64.8"`
+	MasaGrasa *float64 `gorm:"-" json:"masa_grasa,omitempty" example:"15.2"`
+	IMC       *float64 `gorm:"-" json:"imc,omitempty" example:"24.69"`
+	FFMI      *float64 `gorm:"-" json:"ffmi,omitempty" example:"20.0"`
 }
 
 func (Medicion) TableName() string {
 	return "mediciones"
 }
+
+// ComputeComposition fills the lean and fat mass from the body fat percentage, and the BMI and
+// fat-free mass index when the height in centimeters is known
+func (m *Medicion) ComputeComposition(alturaCm float64) {
+	m.MasaMagra, m.MasaGrasa, m.IMC, m.FFMI = nil, nil, nil, nil
+	peso := float64(m.PesoCorporal)
+	if peso <= 0 {
+		return
+	}
+	if m.GrasaCorporal > 0 && m.GrasaCorporal < 100 {
+		grasa := round2(peso * float64(m.GrasaCorporal) / 100)
+		magra := round2(peso - grasa)
+		m.MasaGrasa, m.MasaMagra = &grasa, &magra
+	}
+	if alturaCm <= 0 {
+		return
+	}
+	alturaM2 := math.Pow(alturaCm/100, 2)
+	imc := round2(peso / alturaM2)
+	m.IMC = &imc
+	if m.MasaMagra != nil {
+		ffmi := round2(*m.MasaMagra / alturaM2)
+		m.FFMI = &ffmi
+	}
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Goal types
+const (
+	MetaPesoCorporal  = "body_weight"  // target body weight
+	MetaGrasaCorporal = "body_fat"     // target body fat percentage
+	MetaUnaRepMaxima  = "exercise_1rm" // target estimated one-rep max on an exercise
+)
+
+// Goal status
+const (
+	MetaEnCamino  = "on_track" // the trend reaches the target before the deadline
+	MetaAtrasada  = "behind"   // the trend does not reach the target in time, or moves away from it
+	MetaAlcanzada = "achieved"
+	MetaSinDatos  = "no_data" // there are no measurements or sets to evaluate it yet
+)
+
+// Meta is a target the user wants to reach, optionally before a deadline. Its progress is computed
+// from the latest measurements or the estimated one-rep max of the exercise.
+type Meta struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UsuarioID    uint       `gorm:"not null;index" json:"usuario_id"`
+	Tipo         string     `gorm:"size:32;not null" json:"tipo" example:"body_weight"`
+	EjercicioID  *uint      `gorm:"index" json:"ejercicio_id,omitempty"`
+	Objetivo     float64    `gorm:"not null" json:"objetivo" example:"75"`
+	ValorInicial *float64   `json:"valor_inicial,omitempty" example:"82"` // value when the goal was set
+	FechaLimite  *time.Time `json:"fecha_limite,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Progress computed when the goal is read
+	ValorActual      *float64   `gorm:"-" json:"valor_actual,omitempty" example:"79.5"`
+	Progreso         *float64   `gorm:"-" json:"progreso,omitempty" example:"35.71"` // percentage of the way from the initial value
+	TendenciaSemanal float64    `gorm:"-" json:"tendencia_semanal" example:"-0.4"`   // change per week over the recent weeks
+	FechaProyectada  *time.Time `gorm:"-" json:"fecha_proyectada,omitempty"`         // when the trend reaches the target
+	Estado           string     `gorm:"-" json:"estado" example:"on_track"`
+}
+
+func (Meta) TableName() string {
+	return "metas"
+}
+
+// PuntoMeta is a dated value used to follow a goal
+type PuntoMeta struct {
+	Fecha time.Time
+	Valor float64
+}
+
+// Evaluate computes the progress of the goal from the current value and the recent points, which
+// must be sorted by date and are used to fit the weekly trend
+func (m *Meta) Evaluate(actual *float64, recientes []PuntoMeta, hoy time.Time) {
+	m.ValorActual, m.Progreso, m.FechaProyectada = actual, nil, nil
+	m.TendenciaSemanal = 0
+	if actual == nil {
+		m.Estado = MetaSinDatos
+		return
+	}
+
+	// Goals can go up (gain weight, lift more) or down (lose weight or fat)
+	increasing := m.ValorInicial == nil || m.Objetivo >= *m.ValorInicial
+	if m.ValorInicial != nil && *m.ValorInicial != m.Objetivo {
+		progreso := round2(math.Max(0, math.Min(100, (*actual-*m.ValorInicial)/(m.Objetivo-*m.ValorInicial)*100)))
+		m.Progreso = &progreso
+	}
+	if (increasing && *actual >= m.Objetivo) || (!increasing && *actual <= m.Objetivo) {
+		m.Estado = MetaAlcanzada
+		return
+	}
+
+	if len(recientes) >= 2 {
+		weeks := make([]float64, len(recientes))
+		values := make([]float64, len(recientes))
+		for i, p := range recientes {
+			weeks[i] = p.Fecha.Sub(recientes[0].Fecha).Hours() / (24 * 7)
+			values[i] = p.Valor
+		}
+		m.TendenciaSemanal = round2(linearSlope(weeks, values))
+	}
+
+	towards := (increasing && m.TendenciaSemanal > 0) || (!increasing && m.TendenciaSemanal < 0)
+	if towards {
+		weeks := (m.Objetivo - *actual) / m.TendenciaSemanal
+		proyectada := hoy.Add(time.Duration(weeks * 7 * 24 * float64(time.Hour))).Truncate(24 * time.Hour)
+		m.FechaProyectada = &proyectada
+	}
+
+	switch {
+	case !towards:
+		m.Estado = MetaAtrasada
+	case m.FechaLimite != nil && m.FechaProyectada.After(*m.FechaLimite):
+		m.Estado = MetaAtrasada
+	default:
+		m.Estado = MetaEnCamino
+	}
+}
+
+// ConvertWeights converts the values of weight and one-rep max goals; body fat goals are percentages
+func (m *Meta) ConvertWeights(convert func(float64) float64) {
+	if m.Tipo == MetaGrasaCorporal {
+		return
+	}
+	m.Objetivo = convert(m.Objetivo)
+	m.ValorInicial = convertOptional(m.ValorInicial, convert)
+	m.ValorActual = convertOptional(m.ValorActual, convert)
+	m.TendenciaSemanal = convert(m.TendenciaSemanal)
+}
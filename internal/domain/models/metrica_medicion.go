@@ -0,0 +1,67 @@
+package models
+
+// Keys of the standard measurement metrics
+const (
+	MetricaPesoCorporal             = "peso_corporal"
+	MetricaGrasaCorporal            = "grasa_corporal"
+	MetricaMusculo                  = "musculo"
+	MetricaCintura                  = "cintura"
+	MetricaPecho                    = "pecho"
+	MetricaBrazo                    = "brazo"
+	MetricaMuslo                    = "muslo"
+	MetricaCadera                   = "cadera"
+	MetricaFrecuenciaCardiacaReposo = "frecuencia_cardiaca_reposo"
+)
+
+// MetricaMedicion defines something a user can measure. Standard metrics are shared by every user
+// (UsuarioID nil); custom metrics belong to the user who created them.
+type MetricaMedicion struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	UsuarioID *uint  `gorm:"uniqueIndex:idx_metrica_usuario_clave" json:"usuario_id,omitempty"`
+	Clave     string `gorm:"size:64;not null;uniqueIndex:idx_metrica_usuario_clave" json:"clave" example:"cintura"`
+	Nombre    string `gorm:"size:100;not null" json:"nombre" example:"Cintura"`
+	Unidad    string `gorm:"size:16;not null" json:"unidad" example:"cm"`
+}
+
+func (MetricaMedicion) TableName() string {
+	return "metricas_medicion"
+}
+
+// IsStandard checks if the metric is shared by every user
+func (m *MetricaMedicion) IsStandard() bool {
+	return m.UsuarioID == nil
+}
+
+// IsVisibleTo checks if the user can record values of the metric
+func (m *MetricaMedicion) IsVisibleTo(userID uint) bool {
+	return m.UsuarioID == nil || *m.UsuarioID == userID
+}
+
+// MetricasEstandar are the metrics available to every user. The first three mirror the
+// legacy fields of Medicion.
+var MetricasEstandar = []MetricaMedicion{
+	{Clave: MetricaPesoCorporal, Nombre: "Peso corporal", Unidad: "kg"},
+	{Clave: MetricaGrasaCorporal, Nombre: "Grasa corporal", Unidad: "%"},
+	{Clave: MetricaMusculo, Nombre: "Músculo", Unidad: "kg"},
+	{Clave: MetricaCintura, Nombre: "Cintura", Unidad: "cm"},
+	{Clave: MetricaPecho, Nombre: "Pecho", Unidad: "cm"},
+	{Clave: MetricaBrazo, Nombre: "Brazo", Unidad: "cm"},
+	{Clave: MetricaMuslo, Nombre: "Muslo", Unidad: "cm"},
+	{Clave: MetricaCadera, Nombre: "Cadera", Unidad: "cm"},
+	{Clave: MetricaFrecuenciaCardiacaReposo, Nombre: "Frecuencia cardíaca en reposo", Unidad: "bpm"},
+}
+
+// ValorMedicion is the value of a metric in a measurement entry. Clients may reference the metric
+// by ID or by key.
+type ValorMedicion struct {
+	ID         uint             `gorm:"primaryKey" json:"id"`
+	MedicionID uint             `gorm:"not null;uniqueIndex:idx_valor_medicion_metrica" json:"medicion_id"`
+	MetricaID  uint             `gorm:"not null;uniqueIndex:idx_valor_medicion_metrica;index" json:"metrica_id"`
+	Clave      string           `gorm:"-" json:"clave,omitempty" example:"cintura"`
+	Valor      float64          `gorm:"not null" json:"valor" example:"82.5"`
+	Metrica    *MetricaMedicion `gorm:"foreignKey:MetricaID" json:"metrica,omitempty"`
+}
+
+func (ValorMedicion) TableName() string {
+	return "valores_medicion"
+}
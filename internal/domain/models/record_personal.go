@@ -0,0 +1,148 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Personal record types
+const (
+	RecordPesoMaximo    = "max_weight"    // heaviest weight lifted
+	RecordRepeticiones  = "max_reps"      // most reps at a given weight
+	RecordUnaRepMaxima  = "estimated_1rm" // best estimated one-rep max
+	RecordVolumenMaximo = "max_volume"    // best series x reps x weight in one entry
+)
+
+// RecordPersonal is a personal record set by a user on an exercise. Every time a record is
+// beaten a new row is stored, so the rows of a user and exercise form its history.
+type RecordPersonal struct {
+	ID                uint      `gorm:"primaryKey" json:"id"`
+	UsuarioID         uint      `gorm:"not null;index:idx_record_usuario_ejercicio" json:"usuario_id"`
+	EjercicioID       uint      `gorm:"not null;index:idx_record_usuario_ejercicio" json:"ejercicio_id"`
+	Tipo              string    `gorm:"size:32;not null" json:"tipo" example:"max_weight"`
+	Valor             float64   `json:"valor" example:"100"`
+	Peso              float64   `json:"peso" example:"100"`
+	Repeticiones      int       `json:"repeticiones" example:"5"`
+	Series            int       `json:"series" example:"3"`
+	SesionID          uint      `gorm:"index" json:"sesion_id"`
+	SesionEjercicioID uint      `gorm:"index" json:"sesion_ejercicio_id"`
+	Fecha             time.Time `json:"fecha"`
+	Anterior          *float64  `json:"anterior,omitempty"` // value of the record it beat
+}
+
+func (RecordPersonal) TableName() string {
+	return "records_personales"
+}
+
+// EstimateOneRepMax estimates the one-rep max with the Epley formula
+func EstimateOneRepMax(peso float64, repeticiones int) float64 {
+	if peso <= 0 || repeticiones <= 0 {
+		return 0
+	}
+	if repeticiones == 1 {
+		return peso
+	}
+	return round2(peso * (1 + float64(repeticiones)/30))
+}
+
+// Volume returns the load moved in the entry: series x reps x weight
+func (s *SesionEjercicio) Volume() float64 {
+	series := s.Series
+	if series <= 0 {
+		series = 1
+	}
+	return round2(float64(series) * float64(s.Repeticiones) * s.Peso)
+}
+
+// ComputePersonalRecords replays the entries of a user on an exercise in chronological order
+// and returns every record set along the way
+func ComputePersonalRecords(usuarioID uint, entries []*SesionEjercicio) []RecordPersonal {
+	var records []RecordPersonal
+	best := map[string]float64{}
+	bestReps := map[float64]int{}
+
+	for _, e := range entries {
+		if e.Peso <= 0 || e.Repeticiones <= 0 {
+			continue
+		}
+		newRecord := func(tipo string, valor float64, anterior *float64) {
+			records = append(records, RecordPersonal{
+				UsuarioID:         usuarioID,
+				EjercicioID:       e.EjercicioID,
+				Tipo:              tipo,
+				Valor:             valor,
+				Peso:              e.Peso,
+				Repeticiones:      e.Repeticiones,
+				Series:            e.Series,
+				SesionID:          e.SesionID,
+				SesionEjercicioID: e.ID,
+				Fecha:             e.Fecha,
+				Anterior:          anterior,
+			})
+		}
+
+		candidates := []struct {
+			tipo  string
+			valor float64
+		}{
+			{RecordPesoMaximo, e.Peso},
+			{RecordUnaRepMaxima, EstimateOneRepMax(e.Peso, e.Repeticiones)},
+			{RecordVolumenMaximo, e.Volume()},
+		}
+		for _, c := range candidates {
+			previous, ok := best[c.tipo]
+			if ok && c.valor <= previous {
+				continue
+			}
+			best[c.tipo] = c.valor
+			if ok {
+				newRecord(c.tipo, c.valor, &previous)
+			} else {
+				newRecord(c.tipo, c.valor, nil)
+			}
+		}
+
+		previousReps, ok := bestReps[e.Peso]
+		if !ok || e.Repeticiones > previousReps {
+			bestReps[e.Peso] = e.Repeticiones
+			if ok {
+				anterior := float64(previousReps)
+				newRecord(RecordRepeticiones, float64(e.Repeticiones), &anterior)
+			} else {
+				newRecord(RecordRepeticiones, float64(e.Repeticiones), nil)
+			}
+		}
+	}
+	return records
+}
+
+// CurrentPersonalRecords returns the standing records among the records of an exercise computed by
+// ComputePersonalRecords: the latest of each type, and per weight for max_reps
+func CurrentPersonalRecords(records []RecordPersonal) []RecordPersonal {
+	type recordKey struct {
+		tipo string
+		peso float64
+	}
+	keyOf := func(r RecordPersonal) recordKey {
+		if r.Tipo == RecordRepeticiones {
+			return recordKey{r.Tipo, r.Peso}
+		}
+		return recordKey{tipo: r.Tipo}
+	}
+
+	latest := map[recordKey]int{}
+	for i, r := range records {
+		latest[keyOf(r)] = i
+	}
+	var current []RecordPersonal
+	for i, r := range records {
+		if latest[keyOf(r)] == i {
+			current = append(current, r)
+		}
+	}
+	return current
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -9,8 +9,31 @@ type Rutina struct {
 	FechaCreacion time.Time `json:"fecha_creacion"`
 	Publica       bool      `json:"publica"`
 	UsuarioID     uint      `json:"usuario_id"`
+
+	// Attribution for cloned routines
+	OrigenRutinaID  *uint `gorm:"index" json:"origen_rutina_id,omitempty"`
+	OrigenUsuarioID *uint `json:"origen_usuario_id,omitempty"`
+	OrigenVersionID *uint `json:"origen_version_id,omitempty"`
+
+	// Current immutable version number
+	VersionActual int `gorm:"default:0" json:"version_actual"`
+
+	// Muscle groups embedded on request with ?include=
+	GruposMusculares []GrupoMuscular `gorm:"many2many:rutina_grupo_muscular;joinForeignKey:RutinaID;joinReferences:GrupoMuscularID;->;-:migration" json:"grupos_musculares,omitempty"`
+
+	// Number of favorites, kept up to date by the favorites repository so the catalog can be sorted by it
+	FavoriteCount int64 `gorm:"not null;default:0;index;<-:false" json:"favorite_count"`
+
+	// Computed per request, not persisted
+	IsFavorited bool `gorm:"-" json:"is_favorited"`
 }
 
 func (Rutina) TableName() string {
 	return "rutinas"
 }
+
+// IsVisibleTo checks if the user can see and use the routine: public routines are visible to everyone,
+// private ones only to their owner
+func (r *Rutina) IsVisibleTo(userID uint) bool {
+	return r.Publica || r.UsuarioID == userID
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// RutinaVersion is an immutable snapshot of a routine's content.
+// Sessions reference the version they were performed with, so later
+// edits to the routine do not rewrite history.
+type RutinaVersion struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	RutinaID         uint      `gorm:"not null;uniqueIndex:idx_rutina_version" json:"rutina_id"`
+	Version          int       `gorm:"not null;uniqueIndex:idx_rutina_version" json:"version"`
+	Nombre           string    `json:"nombre"`
+	Objetivo         string    `json:"objetivo"`
+	GrupoMuscularIDs []uint    `gorm:"serializer:json" json:"grupo_muscular_ids"`
+	CreadaEn         time.Time `json:"creada_en"`
+}
+
+func (RutinaVersion) TableName() string {
+	return "rutina_versiones"
+}
+
+// SameContent checks if the snapshot matches the given routine content
+func (v *RutinaVersion) SameContent(nombre, objetivo string, grupoMuscularIDs []uint) bool {
+	if v.Nombre != nombre || v.Objetivo != objetivo {
+		return false
+	}
+	a := slices.Clone(v.GrupoMuscularIDs)
+	b := slices.Clone(grupoMuscularIDs)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
@@ -1,6 +1,18 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+)
+
+// Session lifecycle states
+const (
+	SesionPlanificada = "planned"
+	SesionEnCurso     = "in_progress"
+	SesionCompletada  = "completed"
+	SesionAbandonada  = "abandoned"
+)
 
 type Sesion struct {
 	ID          uint      `gorm:"primaryKey" json:"id"`
@@ -8,8 +20,122 @@ type Sesion struct {
 	Fecha       time.Time `json:"fecha"`
 	DuracionMin int       `json:"duracion_min"`
 	Comentarios string    `json:"comentarios"`
+
+	// Routine performed and the immutable version it was performed with
+	RutinaID        *uint `gorm:"index" json:"rutina_id,omitempty"`
+	RutinaVersionID *uint `json:"rutina_version_id,omitempty"`
+
+	// Lifecycle fields
+	Estado        string     `gorm:"default:planned;index" json:"estado" example:"planned"`
+	IniciadaEn    *time.Time `json:"iniciada_en,omitempty"`
+	PausadaEn     *time.Time `json:"pausada_en,omitempty"`
+	FinalizadaEn  *time.Time `json:"finalizada_en,omitempty"`
+	PausaTotalSeg int        `gorm:"default:0" json:"pausa_total_seg"`
+
+	// Relations embedded on request with ?include=; read-only and without database constraints
+	Rutina     *Rutina           `gorm:"foreignKey:RutinaID;->;-:migration" json:"rutina,omitempty"`
+	Ejercicios []SesionEjercicio `gorm:"foreignKey:SesionID;->;-:migration" json:"ejercicios,omitempty"`
 }
 
 func (s *Sesion) TableName() string {
 	return "sesiones"
 }
+
+// Start moves a planned session to in_progress
+func (s *Sesion) Start(now time.Time) error {
+	if s.Estado != "" && s.Estado != SesionPlanificada {
+		return domainErrors.ErrInvalidSessionTransition
+	}
+	s.Estado = SesionEnCurso
+	s.IniciadaEn = &now
+	s.PausadaEn = nil
+	s.PausaTotalSeg = 0
+	return nil
+}
+
+// Pause pauses an in-progress session
+func (s *Sesion) Pause(now time.Time) error {
+	if s.Estado != SesionEnCurso || s.IsPaused() {
+		return domainErrors.ErrInvalidSessionTransition
+	}
+	s.PausadaEn = &now
+	return nil
+}
+
+// Resume resumes a paused session, accumulating the paused time
+func (s *Sesion) Resume(now time.Time) error {
+	if s.Estado != SesionEnCurso || !s.IsPaused() {
+		return domainErrors.ErrInvalidSessionTransition
+	}
+	s.closePause(now)
+	return nil
+}
+
+// Finish completes an in-progress session and computes its duration
+func (s *Sesion) Finish(now time.Time) error {
+	if s.Estado != SesionEnCurso {
+		return domainErrors.ErrInvalidSessionTransition
+	}
+	s.closePause(now)
+	s.Estado = SesionCompletada
+	s.FinalizadaEn = &now
+	s.DuracionMin = s.ActiveDuration(now) / 60
+	return nil
+}
+
+// Abandon marks a planned or in-progress session as abandoned
+func (s *Sesion) Abandon(now time.Time) error {
+	if s.IsClosed() {
+		return domainErrors.ErrInvalidSessionTransition
+	}
+	if s.Estado == SesionEnCurso {
+		s.closePause(now)
+		s.DuracionMin = s.ActiveDuration(now) / 60
+	}
+	s.Estado = SesionAbandonada
+	s.FinalizadaEn = &now
+	return nil
+}
+
+// ActiveDuration returns the tracked workout time in seconds, excluding pauses
+func (s *Sesion) ActiveDuration(now time.Time) int {
+	if s.IniciadaEn == nil {
+		return 0
+	}
+	end := now
+	if s.FinalizadaEn != nil {
+		end = *s.FinalizadaEn
+	}
+	paused := s.PausaTotalSeg
+	if s.PausadaEn != nil {
+		paused += int(end.Sub(*s.PausadaEn).Seconds())
+	}
+	active := int(end.Sub(*s.IniciadaEn).Seconds()) - paused
+	if active < 0 {
+		return 0
+	}
+	return active
+}
+
+// IsPaused checks if the session is currently paused
+func (s *Sesion) IsPaused() bool {
+	return s.PausadaEn != nil
+}
+
+// IsClosed checks if the session reached a terminal state
+func (s *Sesion) IsClosed() bool {
+	return s.Estado == SesionCompletada || s.Estado == SesionAbandonada
+}
+
+// IsTracked checks if the session lifecycle has been started
+func (s *Sesion) IsTracked() bool {
+	return s.IniciadaEn != nil
+}
+
+func (s *Sesion) closePause(now time.Time) {
+	if s.PausadaEn == nil {
+		return
+	}
+	s.PausaTotalSeg += int(now.Sub(*s.PausadaEn).Seconds())
+	s.PausadaEn = nil
+}
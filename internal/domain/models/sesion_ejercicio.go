@@ -12,6 +12,33 @@ type SesionEjercicio struct {
 	Orden        int       `json:"orden"`
 	Peso         float64   `json:"peso"`
 	Observacion  string    `json:"observacion"`
+
+	// Exercise originally planned when it was swapped during the session
+	EjercicioOriginalID *uint `json:"ejercicio_original_id,omitempty"`
+
+	// Exercise embedded on request with ?include=
+	Ejercicio *Ejercicio `gorm:"foreignKey:EjercicioID;->;-:migration" json:"ejercicio,omitempty"`
+
+	// Standing personal records set by this entry, computed when it is saved
+	NuevosRecords []RecordPersonal `gorm:"-" json:"nuevos_records,omitempty"`
+}
+
+// Swap replaces the exercise, remembering the one originally planned
+func (s *SesionEjercicio) Swap(ejercicioID uint) {
+	if s.EjercicioOriginalID == nil {
+		original := s.EjercicioID
+		s.EjercicioOriginalID = &original
+	} else if *s.EjercicioOriginalID == ejercicioID {
+		s.EjercicioOriginalID = nil
+	}
+	s.EjercicioID = ejercicioID
+}
+
+// InheritDate dates the entry with its session when it has no date of its own
+func (s *SesionEjercicio) InheritDate(sesion *Sesion) {
+	if s.Fecha.IsZero() {
+		s.Fecha = sesion.Fecha
+	}
 }
 
 func (s *SesionEjercicio) TableName() string {
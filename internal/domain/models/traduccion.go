@@ -0,0 +1,23 @@
+package models
+
+// Translatable catalog entities
+const (
+	EntidadEjercicio     = "ejercicio"
+	EntidadGrupoMuscular = "grupo_muscular"
+	EntidadTipoEjercicio = "tipo_ejercicio"
+)
+
+// Traduccion holds the localized texts of a catalog entity; the entity itself stores the default locale
+type Traduccion struct {
+	ID            uint   `gorm:"primaryKey" json:"id"`
+	Entidad       string `gorm:"size:32;not null;uniqueIndex:idx_traduccion" json:"entidad" example:"ejercicio"`
+	EntidadID     uint   `gorm:"not null;uniqueIndex:idx_traduccion" json:"entidad_id"`
+	Locale        string `gorm:"size:8;not null;uniqueIndex:idx_traduccion" json:"locale" example:"en"`
+	Nombre        string `json:"nombre" example:"Bench press"`
+	Descripcion   string `json:"descripcion,omitempty"`
+	Instrucciones string `json:"instrucciones,omitempty"`
+}
+
+func (Traduccion) TableName() string {
+	return "traducciones"
+}
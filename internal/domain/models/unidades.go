@@ -0,0 +1,195 @@
+package models
+
+// Units of the weights and lengths sent and received by clients
+const (
+	UnidadKg = "kg"
+	UnidadLb = "lb"
+	UnidadCm = "cm"
+	UnidadIn = "in"
+
+	// Unit systems accepted as an explicit unit; they set both weights and lengths
+	SistemaMetrico  = "metric"
+	SistemaImperial = "imperial"
+)
+
+const (
+	kgPorLibra   = 0.45359237
+	cmPorPulgada = 2.54
+)
+
+// Unidades are the units a client works with. The database always stores kilograms and centimeters.
+type Unidades struct {
+	Peso     string `json:"peso" example:"kg"`
+	Longitud string `json:"longitud" example:"cm"`
+}
+
+// UnidadesSI are the canonical units stored in the database
+var UnidadesSI = Unidades{Peso: UnidadKg, Longitud: UnidadCm}
+
+// IsSI checks if no conversion is needed
+func (u Unidades) IsSI() bool {
+	return u.Peso != UnidadLb && u.Longitud != UnidadIn
+}
+
+// WithUnit applies an explicit unit: kg or lb set the weights, cm or in the lengths, and metric or
+// imperial both. Unknown units return false.
+func (u Unidades) WithUnit(unit string) (Unidades, bool) {
+	switch unit {
+	case UnidadKg, UnidadLb:
+		u.Peso = unit
+	case UnidadCm, UnidadIn:
+		u.Longitud = unit
+	case SistemaMetrico:
+		u = UnidadesSI
+	case SistemaImperial:
+		u = Unidades{Peso: UnidadLb, Longitud: UnidadIn}
+	default:
+		return u, false
+	}
+	return u, true
+}
+
+// ToSI converts a value sent in the client's units to the canonical unit (kg or cm) it is stored in.
+// Values of other units are returned unchanged.
+func (u Unidades) ToSI(valor float64, unidad string) float64 {
+	switch {
+	case unidad == UnidadKg && u.Peso == UnidadLb:
+		return valor * kgPorLibra
+	case unidad == UnidadCm && u.Longitud == UnidadIn:
+		return valor * cmPorPulgada
+	}
+	return valor
+}
+
+// FromSI converts a value stored in a canonical unit (kg or cm) to the client's units
+func (u Unidades) FromSI(valor float64, unidad string) float64 {
+	switch {
+	case unidad == UnidadKg && u.Peso == UnidadLb:
+		return round2(valor / kgPorLibra)
+	case unidad == UnidadCm && u.Longitud == UnidadIn:
+		return round2(valor / cmPorPulgada)
+	}
+	return valor
+}
+
+// Display returns the unit the client sees for values stored in the canonical unit
+func (u Unidades) Display(unidad string) string {
+	switch {
+	case unidad == UnidadKg && u.Peso == UnidadLb:
+		return UnidadLb
+	case unidad == UnidadCm && u.Longitud == UnidadIn:
+		return UnidadIn
+	}
+	return unidad
+}
+
+// WeightToSI converts a weight sent in the client's units to kilograms
+func (u Unidades) WeightToSI(valor float64) float64 {
+	return u.ToSI(valor, UnidadKg)
+}
+
+// WeightFromSI converts a weight in kilograms to the client's units
+func (u Unidades) WeightFromSI(valor float64) float64 {
+	return u.FromSI(valor, UnidadKg)
+}
+
+// ConvertWeights converts the weight of the entry and of the records it set
+func (s *SesionEjercicio) ConvertWeights(convert func(float64) float64) {
+	s.Peso = convert(s.Peso)
+	for i := range s.NuevosRecords {
+		s.NuevosRecords[i].ConvertWeights(convert)
+	}
+}
+
+// ConvertWeights converts the weights of the record; repetition records only convert the weight lifted
+func (r *RecordPersonal) ConvertWeights(convert func(float64) float64) {
+	r.Peso = convert(r.Peso)
+	if r.Tipo == RecordRepeticiones {
+		return
+	}
+	r.Valor = convert(r.Valor)
+	if r.Anterior != nil {
+		anterior := convert(*r.Anterior)
+		r.Anterior = &anterior
+	}
+}
+
+// ToSI converts the measurement sent in the client's units. unitOf returns the canonical unit of the
+// metric of a value.
+func (m *Medicion) ToSI(u Unidades, unitOf func(ValorMedicion) string) {
+	m.PesoCorporal = float32(u.WeightToSI(float64(m.PesoCorporal)))
+	m.Musculo = float32(u.WeightToSI(float64(m.Musculo)))
+	for i := range m.Valores {
+		m.Valores[i].Valor = u.ToSI(m.Valores[i].Valor, unitOf(m.Valores[i]))
+	}
+}
+
+// FromSI converts the measurement, including its body composition, to the client's units. The
+// definitions of the values are copied to show the converted unit.
+func (m *Medicion) FromSI(u Unidades) {
+	m.PesoCorporal = float32(u.WeightFromSI(float64(m.PesoCorporal)))
+	m.Musculo = float32(u.WeightFromSI(float64(m.Musculo)))
+	m.MasaMagra = convertOptional(m.MasaMagra, u.WeightFromSI)
+	m.MasaGrasa = convertOptional(m.MasaGrasa, u.WeightFromSI)
+	for i := range m.Valores {
+		v := &m.Valores[i]
+		if v.Metrica == nil {
+			continue
+		}
+		v.Valor = u.FromSI(v.Valor, v.Metrica.Unidad)
+		metrica := *v.Metrica
+		metrica.Unidad = u.Display(metrica.Unidad)
+		v.Metrica = &metrica
+	}
+}
+
+// ConvertWeights converts the weights of the summary; percentages, heights and indexes are kept
+func (r *ResumenMediciones) ConvertWeights(convert func(float64) float64) {
+	for _, stats := range []*EstadisticaMetrica{r.Peso, r.PesoMedia, r.Musculo, r.MasaMagra, r.MasaGrasa} {
+		stats.convert(convert)
+	}
+	for i := range r.Puntos {
+		p := &r.Puntos[i]
+		p.PesoCorporal = convert(p.PesoCorporal)
+		p.PesoMedia = convert(p.PesoMedia)
+		p.Musculo = convert(p.Musculo)
+		p.MasaMagra = convertOptional(p.MasaMagra, convert)
+		p.MasaGrasa = convertOptional(p.MasaGrasa, convert)
+	}
+}
+
+func (s *EstadisticaMetrica) convert(convert func(float64) float64) {
+	if s == nil {
+		return
+	}
+	s.Inicial = convert(s.Inicial)
+	s.Actual = convert(s.Actual)
+	s.Minimo = convert(s.Minimo)
+	s.Maximo = convert(s.Maximo)
+	s.Cambio = convert(s.Cambio)
+	s.TendenciaSemanal = convert(s.TendenciaSemanal)
+}
+
+// ConvertWeights converts the estimated one-rep maxes and the trend slope
+func (p *ProgresionEjercicio) ConvertWeights(convert func(float64) float64) {
+	p.Pendiente = convert(p.Pendiente)
+	for i := range p.Puntos {
+		p.Puntos[i].UnaRepMaxima = convert(p.Puntos[i].UnaRepMaxima)
+	}
+}
+
+// ConvertWeights converts the tonnage of every muscle group
+func (d *DashboardVolumen) ConvertWeights(convert func(float64) float64) {
+	for i := range d.Grupos {
+		d.Grupos[i].Tonelaje = convert(d.Grupos[i].Tonelaje)
+		d.Grupos[i].TonelajeAnterior = convert(d.Grupos[i].TonelajeAnterior)
+	}
+}
+
+func convertOptional(v *float64, convert func(float64) float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	converted := convert(*v)
+	return &converted
+}
@@ -9,13 +9,19 @@ import (
 // User represents a user in the system
 // @Description User entity for authentication and profile management
 type User struct {
-	ID        uint   `gorm:"primaryKey" json:"id" example:"1"`
-	Name      string `gorm:"not null" json:"name" example:"John Doe"`
-	Email     string `gorm:"unique;not null" json:"email" example:"john@example.com"`
-	Password  string `gorm:"not null" json:"password" example:"password123"`
-	RoleID    uint   `gorm:"not null" json:"role_id" example:"1"`
-	IsActive  bool   `gorm:"default:true" json:"is_active" example:"true"`
-	IsDeleted bool   `gorm:"default:false" json:"is_deleted" example:"false"` // Soft delete
+	ID        uint    `gorm:"primaryKey" json:"id" example:"1"`
+	Name      string  `gorm:"not null" json:"name" example:"John Doe"`
+	Email     string  `gorm:"unique;not null" json:"email" example:"john@example.com"`
+	Password  string  `gorm:"not null" json:"password" example:"password123"`
+	RoleID    uint    `gorm:"not null" json:"role_id" example:"1"`
+	IsActive  bool    `gorm:"default:true" json:"is_active" example:"true"`
+	IsDeleted bool    `gorm:"default:false" json:"is_deleted" example:"false"` // Soft delete
+	Idioma    string  `gorm:"size:8" json:"idioma,omitempty" example:"en"`     // Preferred locale
+	AlturaCm  float64 `json:"altura_cm,omitempty" example:"180"`               // Height, used for body composition
+
+	// Preferred units for weights (kg, lb) and lengths (cm, in); values are always stored in kg and cm
+	UnidadPeso     string `gorm:"size:2" json:"unidad_peso,omitempty" example:"kg"`
+	UnidadLongitud string `gorm:"size:2" json:"unidad_longitud,omitempty" example:"cm"`
 
 	// Relations
 	Role         Role           `gorm:"foreignKey:RoleID" json:"role,omitempty" swaggerignore:"true"`
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type CoachAccessRepository interface {
+	// Grant stores the access unless the coach is already authorized and reports whether it was created
+	Grant(acceso *model.AccesoEntrenador) (bool, error)
+	// Revoke removes the access and reports whether it existed
+	Revoke(usuarioID, entrenadorID uint) (bool, error)
+	IsAuthorized(usuarioID, entrenadorID uint) (bool, error)
+	GetCoaches(usuarioID uint) ([]model.AccesoEntrenador, error)
+	GetClients(entrenadorID uint) ([]model.AccesoEntrenador, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "io"
+
+// BlobStore stores binary objects (images, videos) by key.
+// Implementations: local filesystem today, S3-compatible storage later.
+type BlobStore interface {
+	// Put stores the content under the given key, replacing any existing object
+	Put(key string, content io.Reader, contentType string) error
+
+	// Get opens the object stored under the given key and returns its content type
+	Get(key string) (io.ReadCloser, string, error)
+
+	// Delete removes the object; deleting a missing key is not an error
+	Delete(key string) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+// DeleteOptions controls how the records referencing a deleted catalog entity are handled
+type DeleteOptions struct {
+	Cascade    bool // delete the dependent records too
+	ReassignTo uint // move the dependent records to another entity before deleting
+}
+
+// IsSet checks if any dependent handling was requested
+func (o DeleteOptions) IsSet() bool {
+	return o.Cascade || o.ReassignTo != 0
+}
+
+// DependentCounts counts the records still referencing an entity, by kind
+type DependentCounts map[string]int64
+
+// DependentExerciseHistory counts the session entries and goals of users on the exercises of a catalog entity,
+// which a cascade would delete with the exercises
+const DependentExerciseHistory = "exercise_history"
+
+// Total returns the number of dependent records
+func (d DependentCounts) Total() int64 {
+	var total int64
+	for _, n := range d {
+		total += n
+	}
+	return total
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type ExerciseAlternativeRepository interface {
+	CreateIfNotExists(alternativa *model.EjercicioAlternativa) (bool, error)
+	Delete(ejercicioID, alternativaID uint) (bool, error)
+	GetAlternativeIDs(ejercicioID uint) ([]uint, error)
+}
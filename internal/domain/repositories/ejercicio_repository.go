@@ -4,11 +4,45 @@ import (
 	model "github.com/Diegonr1791/GymBro/internal/domain/models"
 )
 
+// ExerciseFilter defines the optional filters for the exercise catalog; zero values are ignored
+type ExerciseFilter struct {
+	ViewerID                 uint  // shared catalog plus the viewer's custom exercises
+	Custom                   *bool // only custom (true) or only shared (false) exercises
+	Search                   string
+	TipoEjercicioID          uint
+	GrupoMuscularID          uint // any role
+	PrimaryGrupoMuscularID   uint
+	SecondaryGrupoMuscularID uint
+	Equipamiento             string
+	Dificultad               string
+	Unilateral               *bool
+	HasMedia                 *bool
+}
+
+// ExerciseProjection lists the fields and relations of an exercise that can be requested. The visibility
+// and media columns are always read; the muscle groups are always embedded.
+var ExerciseProjection = ProjectionSchema{
+	Fields: []string{"id", "nombre", "tipo_ejercicio_id", "grupo_muscular_id", "usuario_id", "is_custom", "descripcion",
+		"instrucciones", "equipamiento", "dificultad", "unilateral", "imagen_url", "video_url"},
+	Required: []string{"usuario_id", "is_custom", "imagen_key", "miniatura_key", "video_key"},
+	Includes: map[string]string{
+		"type":         "TipoEjercicio",
+		"muscle_group": "GrupoMuscular",
+	},
+}
+
 type ExerciseRepository interface {
-	GetAll() ([]*model.Ejercicio, error)
 	GetById(id uint) (*model.Ejercicio, error)
+	GetByIDProjected(id uint, proj Projection) (*model.Ejercicio, error)
+	GetByIDs(ids []uint) ([]*model.Ejercicio, error)
+	GetSharedByName(nombre string) (*model.Ejercicio, error)
 	Create(ejercicio *model.Ejercicio) error
 	Update(ejercicio *model.Ejercicio) error
 	Delete(id uint) error
-	GetByMuscleGroup(muscleGroupID uint) ([]*model.Ejercicio, error)
+	Search(filter ExerciseFilter) ([]*model.Ejercicio, error)
+	SearchPage(filter ExerciseFilter, spec QuerySpec) (*Page[*model.Ejercicio], error)
+	UpdateMedia(ejercicio *model.Ejercicio) error
+	Promote(id uint) error
+	CountDependents(id uint) (DependentCounts, error)
+	DeleteWith(id uint, opts DeleteOptions) error
 }
@@ -3,10 +3,10 @@ package repository
 import model "github.com/Diegonr1791/GymBro/internal/domain/models"
 
 type FavoritaRepository interface {
-	GetAll() ([]model.Favorita, error)
-	GetByID(id uint) (*model.Favorita, error)
-	Create(favorita *model.Favorita) error
-	Update(favorita *model.Favorita) error
-	Delete(id uint) error
-	GetFavoritasByUsuarioID(usuarioID uint) ([]model.Favorita, error)
+	// GetFavoritasByUsuarioID returns a page of the user's favorites
+	GetFavoritasByUsuarioID(usuarioID uint, spec QuerySpec) (*Page[model.Favorita], error)
+	GetByUserAndRoutine(usuarioID, rutinaID uint) (*model.Favorita, error)
+	CreateIfNotExists(favorita *model.Favorita) (bool, error)
+	DeleteByUserAndRoutine(usuarioID, rutinaID uint) error
+	GetFavoritedRoutineIDs(usuarioID uint, rutinaIDs []uint) (map[uint]bool, error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type ProgressPhotoRepository interface {
+	Create(foto *model.FotoProgreso) error
+	GetByID(id uint) (*model.FotoProgreso, error)
+	// GetByMeasurement returns the photos of a measurement sorted by pose
+	GetByMeasurement(medicionID uint) ([]model.FotoProgreso, error)
+	// GetPageByMeasurement returns a page of the photos of a measurement
+	GetPageByMeasurement(medicionID uint, spec QuerySpec) (*Page[model.FotoProgreso], error)
+	Delete(id uint) error
+}
@@ -5,7 +5,12 @@ import model "github.com/Diegonr1791/GymBro/internal/domain/models"
 type GrupoMuscularRepository interface {
 	Create(g *model.GrupoMuscular) error
 	GetAll() ([]model.GrupoMuscular, error)
+	List(spec QuerySpec) (*Page[model.GrupoMuscular], error)
 	GetByID(id uint) (*model.GrupoMuscular, error)
+	GetByName(nombre string) (*model.GrupoMuscular, error)
 	Update(g *model.GrupoMuscular) error
 	Delete(id uint) error
+	CountDependents(id uint) (DependentCounts, error)
+	// DeleteWith deletes the muscle group with its dependents and returns the stored media keys of the deleted exercises
+	DeleteWith(id uint, opts DeleteOptions) ([]string, error)
 }
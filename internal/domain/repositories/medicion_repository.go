@@ -1,14 +1,22 @@
 package repository
 
 import (
+	"time"
+
 	model "github.com/Diegonr1791/GymBro/internal/domain/models"
 )
 
 type MedicionRepository interface {
-	GetAll() ([]model.Medicion, error)
+	GetAll(spec QuerySpec) (*Page[model.Medicion], error)
 	GetByID(id uint) (*model.Medicion, error)
 	Create(medicion *model.Medicion) error
 	Update(medicion *model.Medicion) error
+	// Patch updates only the given columns of the measurement; the valores column replaces its values
+	Patch(medicion *model.Medicion, columns []string) error
 	Delete(id uint) error
 	GetMesurementsByUserID(usuarioID uint) ([]model.Medicion, error)
+	// GetPageByUser returns a page of the user's measurements
+	GetPageByUser(usuarioID uint, spec QuerySpec) (*Page[model.Medicion], error)
+	// GetByUserAndRange returns the user's measurements in [from, to) sorted by date
+	GetByUserAndRange(usuarioID uint, from, to time.Time) ([]model.Medicion, error)
 }
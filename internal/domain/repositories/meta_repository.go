@@ -0,0 +1,14 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type GoalRepository interface {
+	Create(meta *model.Meta) error
+	GetByID(id uint) (*model.Meta, error)
+	// GetByUser returns a page of the user's goals
+	GetByUser(userID uint, spec QuerySpec) (*Page[model.Meta], error)
+	Update(meta *model.Meta) error
+	Delete(id uint) error
+}
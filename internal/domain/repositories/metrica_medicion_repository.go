@@ -0,0 +1,19 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type MeasurementMetricRepository interface {
+	// GetAvailable returns the standard metrics followed by the user's custom ones
+	GetAvailable(userID uint) ([]model.MetricaMedicion, error)
+	// GetPageAvailable returns a page of the standard metrics and the user's custom ones
+	GetPageAvailable(userID uint, spec QuerySpec) (*Page[model.MetricaMedicion], error)
+	GetStandard() ([]model.MetricaMedicion, error)
+	GetByID(id uint) (*model.MetricaMedicion, error)
+	// GetByKey returns the standard metric or the user's custom metric with the key
+	GetByKey(userID uint, clave string) (*model.MetricaMedicion, error)
+	Create(metrica *model.MetricaMedicion) error
+	// Delete removes the metric along with its values in every measurement
+	Delete(id uint) error
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Page size limits of the list endpoints
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// Filter operators
+const (
+	FilterEq   = "eq"
+	FilterNe   = "ne"
+	FilterGt   = "gt"
+	FilterGte  = "gte"
+	FilterLt   = "lt"
+	FilterLte  = "lte"
+	FilterLike = "like" // case-insensitive substring match, text fields only
+	FilterIn   = "in"   // comma separated values
+)
+
+// QuerySpec describes the page of a list to read: its size, the cursor it starts after, the sort
+// order and the filters. Fields are named as in the JSON of the resource; each repository decides
+// which of them can be sorted and filtered.
+type QuerySpec struct {
+	Limit   int
+	Cursor  string // opaque position returned as NextCursor by the previous page
+	Sort    []SortField
+	Filters []Filter
+	Projection
+}
+
+// SortField orders the list by a field
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Filter keeps the items whose field compares to the value with the operator
+type Filter struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// PageLimit returns the page size, applying the default and the maximum
+func (s QuerySpec) PageLimit() int {
+	switch {
+	case s.Limit <= 0:
+		return DefaultPageLimit
+	case s.Limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return s.Limit
+	}
+}
+
+// WithFilters returns a copy of the spec with additional filters
+func (s QuerySpec) WithFilters(filters ...Filter) QuerySpec {
+	s.Filters = append(append([]Filter{}, s.Filters...), filters...)
+	return s
+}
+
+// Projection selects the fields of a resource to read and the relations to embed in it. Fields are
+// named as in the JSON of the resource; no fields means all of them.
+type Projection struct {
+	Fields  []string
+	Include []string
+}
+
+// ProjectionSchema is the whitelist of the fields and relations of a resource that a projection can request
+type ProjectionSchema struct {
+	Fields   []string          // selectable fields; the id is always read
+	Required []string          // columns always read because the usecases depend on them
+	Includes map[string]string // relation name to its path in the model, nested relations separated by dots
+}
+
+// Validate checks that the projection only requests whitelisted fields and relations
+func (s ProjectionSchema) Validate(p Projection) error {
+	for _, field := range p.Fields {
+		if !slices.Contains(s.Fields, field) {
+			return &InvalidQueryError{Param: "fields", Message: fmt.Sprintf("unknown field '%s'", field)}
+		}
+	}
+	for _, include := range p.Include {
+		if _, ok := s.Includes[include]; !ok {
+			return &InvalidQueryError{Param: "include", Message: fmt.Sprintf("cannot include '%s'", include)}
+		}
+	}
+	return nil
+}
+
+// Page is a page of a list. NextCursor is empty on the last page; Total counts every item matching
+// the filters, not only the ones in the page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+	Total      int64
+}
+
+// InvalidQueryError reports a query spec that cannot be applied, such as an unknown field or a malformed cursor
+type InvalidQueryError struct {
+	Param   string // query parameter at fault: sort, filter, cursor, fields or include
+	Message string
+}
+
+func (e *InvalidQueryError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Param, e.Message)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type PersonalRecordRepository interface {
+	// ReplaceForExercise replaces the record history of a user on an exercise
+	ReplaceForExercise(userID, exerciseID uint, records []model.RecordPersonal) error
+	// GetCurrent returns a page of the standing records of a user: the latest of each type per exercise,
+	// and per weight for max_reps
+	GetCurrent(userID uint, spec QuerySpec) (*Page[model.RecordPersonal], error)
+	// GetHistory returns a page of the records of a user on an exercise
+	GetHistory(userID, exerciseID uint, spec QuerySpec) (*Page[model.RecordPersonal], error)
+}
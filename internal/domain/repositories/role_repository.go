@@ -18,11 +18,11 @@ type RoleRepository interface {
 	// GetByName retrieves a role by its name
 	GetByName(ctx context.Context, name string) (*model.Role, error)
 
-	// GetAll retrieves all active roles
-	GetAll(ctx context.Context) ([]model.Role, error)
+	// GetAll retrieves a page of active roles
+	GetAll(ctx context.Context, spec QuerySpec) (*Page[model.Role], error)
 
-	// GetAllWithDeleted retrieves all roles including deleted ones
-	GetAllWithDeleted(ctx context.Context) ([]model.Role, error)
+	// GetAllWithDeleted retrieves a page of roles including deleted ones
+	GetAllWithDeleted(ctx context.Context, spec QuerySpec) (*Page[model.Role], error)
 
 	// Update updates an existing role
 	Update(ctx context.Context, role *model.Role) error
@@ -3,7 +3,7 @@ package repository
 import model "github.com/Diegonr1791/GymBro/internal/domain/models"
 
 type RutinaGrupoMuscularRepository interface {
-	GetAll() ([]model.RutinaGrupoMuscular, error)
+	GetAll(spec QuerySpec) (*Page[model.RutinaGrupoMuscular], error)
 	GetByID(id uint) (*model.RutinaGrupoMuscular, error)
 	Create(rutina *model.RutinaGrupoMuscular) error
 	Update(rutina *model.RutinaGrupoMuscular) error
@@ -2,10 +2,32 @@ package repository
 
 import model "github.com/Diegonr1791/GymBro/internal/domain/models"
 
+// PublicRoutineFilter defines the full-text search and the filters of the public catalog
+type PublicRoutineFilter struct {
+	Search          string
+	GrupoMuscularID uint
+	UsuarioID       uint
+}
+
+// RoutineProjection lists the fields and relations of a routine that can be requested
+var RoutineProjection = ProjectionSchema{
+	Fields: []string{"id", "nombre", "objetivo", "fecha_creacion", "publica", "usuario_id", "origen_rutina_id",
+		"origen_usuario_id", "origen_version_id", "version_actual"},
+	Required: []string{"favorite_count"},
+	Includes: map[string]string{
+		"muscle_groups": "GruposMusculares",
+	},
+}
+
 type RutinaRepository interface {
-	GetAll() ([]model.Rutina, error)
 	GetByID(id uint) (*model.Rutina, error)
+	GetByIDProjected(id uint, proj Projection) (*model.Rutina, error)
 	Create(rutina *model.Rutina) error
 	Update(rutina *model.Rutina) error
+	// Patch updates only the given columns of the routine
+	Patch(rutina *model.Rutina, columns []string) error
 	Delete(id uint) error
+	CreateWithMuscleGroups(rutina *model.Rutina, grupoMuscularIDs []uint) error
+	GetByUserID(userID uint, spec QuerySpec) (*Page[model.Rutina], error)
+	SearchPublic(filter PublicRoutineFilter, spec QuerySpec) (*Page[model.Rutina], error)
 }
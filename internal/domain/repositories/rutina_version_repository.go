@@ -0,0 +1,12 @@
+package repository
+
+import model "github.com/Diegonr1791/GymBro/internal/domain/models"
+
+// RutinaVersionRepository stores immutable routine versions; there is no update or delete
+type RutinaVersionRepository interface {
+	Create(version *model.RutinaVersion) error
+	GetByID(id uint) (*model.RutinaVersion, error)
+	GetLatest(rutinaID uint) (*model.RutinaVersion, error)
+	GetByRutinaID(rutinaID uint) ([]model.RutinaVersion, error)
+	GetByVersion(rutinaID uint, version int) (*model.RutinaVersion, error)
+}
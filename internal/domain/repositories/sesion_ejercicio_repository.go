@@ -8,9 +8,16 @@ import (
 
 type SessionExerciseRepository interface {
 	Create(sesionEjercicio *model.SesionEjercicio) error
-	GetAll() ([]*model.SesionEjercicio, error)
+	GetAll(spec QuerySpec) (*Page[*model.SesionEjercicio], error)
 	GetById(id uint) (*model.SesionEjercicio, error)
 	Update(sesionEjercicio *model.SesionEjercicio) error
+	// Patch updates only the given columns of the session exercise
+	Patch(sesionEjercicio *model.SesionEjercicio, columns []string) error
 	Delete(id uint) error
-	GetBySessionID(sessionID uint, fechaDesde, fechaHasta time.Time) ([]*model.SesionEjercicio, error)
+	GetBySessionID(sessionID uint, fechaDesde, fechaHasta time.Time, spec QuerySpec) (*Page[*model.SesionEjercicio], error)
+	GetByUserAndExercise(userID, exerciseID uint) ([]*model.SesionEjercicio, error)
+	// GetTrainedByUserAndExercise is GetByUserAndExercise restricted to the sessions the user trained
+	GetTrainedByUserAndExercise(userID, exerciseID uint) ([]*model.SesionEjercicio, error)
+	// GetMuscleGroupLoad aggregates the tonnage and sets per muscle group of the user's trained sessions in [from, to)
+	GetMuscleGroupLoad(userID uint, from, to time.Time) ([]model.CargaGrupoMuscular, error)
 }
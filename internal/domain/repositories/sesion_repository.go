@@ -6,12 +6,30 @@ import (
 	model "github.com/Diegonr1791/GymBro/internal/domain/models"
 )
 
+// SessionProjection lists the fields and relations of a session that can be requested
+var SessionProjection = ProjectionSchema{
+	Fields: []string{"id", "usuario_id", "fecha", "duracion_min", "comentarios", "rutina_id", "rutina_version_id",
+		"estado", "iniciada_en", "pausada_en", "finalizada_en", "pausa_total_seg"},
+	Includes: map[string]string{
+		"routine":             "Rutina",
+		"exercises":           "Ejercicios",
+		"exercises.ejercicio": "Ejercicios.Ejercicio",
+	},
+}
+
 type SessionRepository interface {
 	Create(sesion *model.Sesion) error
-	GetAll() ([]*model.Sesion, error)
+	GetAll(spec QuerySpec) (*Page[*model.Sesion], error)
 	GetById(id uint) (*model.Sesion, error)
+	GetByIDProjected(id uint, proj Projection) (*model.Sesion, error)
 	Update(sesion *model.Sesion) error
+	// Patch updates only the given columns of the session
+	Patch(sesion *model.Sesion, columns []string) error
 	Delete(id uint) error
-	GetByUserID(userID uint) ([]*model.Sesion, error)
-	GetByDateRange(startDate, endDate time.Time) ([]*model.Sesion, error)
+	GetByUserID(userID uint, spec QuerySpec) (*Page[*model.Sesion], error)
+	GetByDateRange(startDate, endDate time.Time, spec QuerySpec) (*Page[*model.Sesion], error)
+	// GetActivityByDay counts the user's sessions and minutes per day in [from, to)
+	GetActivityByDay(userID uint, from, to time.Time) ([]model.DiaActividad, error)
+	// GetStreaks computes the user's current and longest daily and weekly streaks as of today
+	GetStreaks(userID uint, today time.Time) (model.RachasEntrenamiento, error)
 }
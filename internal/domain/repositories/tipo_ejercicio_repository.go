@@ -5,9 +5,13 @@ import (
 )
 
 type TypeExerciseRepository interface {
-	GetAll() ([]*model.TipoEjercicio, error)
+	GetAll(spec QuerySpec) (*Page[*model.TipoEjercicio], error)
 	GetById(id uint) (*model.TipoEjercicio, error)
+	GetByName(nombre string) (*model.TipoEjercicio, error)
 	Create(tipoEjercicio *model.TipoEjercicio) error
 	Update(tipoEjercicio *model.TipoEjercicio) error
 	Delete(id uint) error
+	CountDependents(id uint) (DependentCounts, error)
+	// DeleteWith deletes the exercise type with its dependents and returns the stored media keys of the deleted exercises
+	DeleteWith(id uint, opts DeleteOptions) ([]string, error)
 }
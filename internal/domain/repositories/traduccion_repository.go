@@ -0,0 +1,12 @@
+package repository
+
+import (
+	model "github.com/Diegonr1791/GymBro/internal/domain/models"
+)
+
+type TranslationRepository interface {
+	Upsert(traduccion *model.Traduccion) error
+	GetByEntity(entidad string, entidadID uint) ([]model.Traduccion, error)
+	GetForEntities(entidad string, entidadIDs []uint, locale string) (map[uint]model.Traduccion, error)
+	Delete(entidad string, entidadID uint, locale string) (bool, error)
+}
@@ -3,8 +3,8 @@ package repository
 import model "github.com/Diegonr1791/GymBro/internal/domain/models"
 
 type UsuarioRepository interface {
-	GetAll() ([]model.User, error)
-	GetAllIncludingDeleted() ([]model.User, error)
+	GetAll(spec QuerySpec) (*Page[model.User], error)
+	GetAllIncludingDeleted(spec QuerySpec) (*Page[model.User], error)
 	GetByID(id uint) (*model.User, error)
 	GetByIDIncludingDeleted(id uint) (*model.User, error)
 	GetByEmail(email string) (*model.User, error)
@@ -12,8 +12,10 @@ type UsuarioRepository interface {
 	GetByEmailWithRole(email string) (*model.User, error)
 	Create(usuario *model.User) error
 	Update(usuario *model.User) error
+	// Patch updates only the given columns of the user
+	Patch(usuario *model.User, columns []string) error
 	Delete(id uint) error
 	Restore(id uint) error
 	HardDelete(id uint) error
-	GetDeletedUsers() ([]model.User, error)
+	GetDeletedUsers(spec QuerySpec) (*Page[model.User], error)
 }
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Supported locales; the catalog is stored in the default locale
+const (
+	LocaleES      = "es"
+	LocaleEN      = "en"
+	LocalePT      = "pt"
+	DefaultLocale = LocaleES
+)
+
+// SupportedLocales lists the locales the API can answer in
+var SupportedLocales = []string{LocaleES, LocaleEN, LocalePT}
+
+// Normalize reduces a language tag such as "pt-BR" to a supported locale
+func Normalize(tag string) (string, bool) {
+	base, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	base, _, _ = strings.Cut(base, "_")
+	base = strings.ToLower(base)
+	if slices.Contains(SupportedLocales, base) {
+		return base, true
+	}
+	return "", false
+}
+
+// IsSupported checks if the tag resolves to a supported locale
+func IsSupported(tag string) bool {
+	_, ok := Normalize(tag)
+	return ok
+}
+
+// ParseAcceptLanguage returns the supported locale with the highest weight in an Accept-Language header,
+// or an empty string when none is supported
+func ParseAcceptLanguage(header string) string {
+	best, bestWeight := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		locale, ok := Normalize(tag)
+		if ok && weight > bestWeight {
+			best, bestWeight = locale, weight
+		}
+	}
+	return best
+}
@@ -0,0 +1,148 @@
+package i18n
+
+// messages holds the translations of error messages by error code. English is the source language,
+// so codes without a translation keep their original message.
+var messages = map[string]map[string]string{
+	LocaleES: {
+		"NOT_FOUND":                  "Recurso no encontrado.",
+		"INTERNAL_SERVER_ERROR":      "Se produjo un error interno del servidor.",
+		"BAD_REQUEST":                "La solicitud no es válida.",
+		"UNAUTHORIZED":               "La solicitud no está autorizada.",
+		"FORBIDDEN":                  "Acceso prohibido. Permisos insuficientes.",
+		"EMAIL_ALREADY_EXISTS":       "El email indicado ya está en uso.",
+		"INVALID_CREDENTIALS":        "Credenciales inválidas.",
+		"INVALID_REFRESH_TOKEN":      "El refresh token es inválido o ha expirado.",
+		"SYSTEM_ROLE_NOT_DELETABLE":  "Los roles del sistema no se pueden eliminar.",
+		"INVALID_REFERENCE":          "Un recurso referenciado no existe.",
+		"EMAIL_REQUIRED":             "El email es obligatorio.",
+		"INVALID_EMAIL_FORMAT":       "El formato del email no es válido.",
+		"PASSWORD_REQUIRED":          "La contraseña es obligatoria.",
+		"PASSWORD_TOO_SHORT":         "La contraseña debe tener al menos 8 caracteres.",
+		"PASSWORD_TOO_LONG":          "La contraseña no puede superar los 128 caracteres.",
+		"PASSWORD_WEAK":              "La contraseña debe contener al menos una mayúscula, una minúscula y un número.",
+		"NAME_TOO_SHORT":             "El nombre debe tener al menos 2 caracteres.",
+		"NAME_TOO_LONG":              "El nombre no puede superar los 100 caracteres.",
+		"INVALID_NAME_CHARACTERS":    "El nombre contiene caracteres no válidos.",
+		"NAME_REQUIRED":              "El nombre es obligatorio.",
+		"ROLE_REQUIRED":              "El rol es obligatorio.",
+		"USER_INACTIVE":              "La cuenta de usuario está inactiva.",
+		"USER_ALREADY_DELETED":       "El usuario ya está eliminado.",
+		"USER_NOT_DELETED":           "El usuario no está eliminado.",
+		"EMAIL_SOFT_DELETED":         "El email pertenece a un usuario eliminado.",
+		"INVALID_SESSION_TRANSITION": "La sesión no puede cambiar al estado solicitado.",
+		"SESSION_CLOSED":             "La sesión ya está completada o abandonada.",
+		"SESSION_NOT_IN_PROGRESS":    "La sesión no está en curso.",
+		"ROUTINE_NOT_PUBLIC":         "La rutina es privada y pertenece a otro usuario.",
+		"ROUTINE_NOT_OWNED":          "Solo el propietario puede modificar la rutina.",
+		"INVALID_JSON":               "El cuerpo JSON no es válido.",
+		"VALIDATION_FAILED":          "Uno o más campos no son válidos.",
+		"INVALID_MERGE_PATCH":        "El cuerpo debe ser un objeto JSON merge patch.",
+		"INVALID_CONTENT_TYPE":       "El tipo de contenido de la petición no está soportado.",
+		"CURRENT_PASSWORD_REQUIRED":  "La contraseña actual es obligatoria para cambiar la contraseña.",
+		"INVALID_CURRENT_PASSWORD":   "La contraseña actual es incorrecta.",
+		"ROUTINE_NAME_REQUIRED":      "El nombre de la rutina es obligatorio.",
+		"INVALID_LANGUAGE":           "El idioma no está soportado.",
+		"INSUFFICIENT_PERMISSIONS":   "Permisos insuficientes para realizar esta acción.",
+		"CATALOG_READ_ONLY":          "Solo los administradores pueden modificar el catálogo compartido.",
+		"CASCADE_DELETES_HISTORY":    "Los ejercicios tienen historial de usuarios; reasígnalos con reassign_to.",
+		"UNSUPPORTED_MEDIA_TYPE":     "Tipo de archivo no soportado.",
+		"INVALID_SIGNATURE":          "La URL de descarga no es válida o ha expirado.",
+		"EXERCISE_TYPE_REQUIRED":     "El tipo de ejercicio es obligatorio.",
+		"MUSCLE_GROUP_REQUIRED":      "Se requiere un grupo muscular principal.",
+		"TRANSLATION_NAME_REQUIRED":  "El nombre traducido es obligatorio.",
+		"DEFAULT_LOCALE_TRANSLATION": "Los textos del idioma por defecto se editan en el propio recurso.",
+		"EXERCISE_NOT_CUSTOM":        "El ejercicio ya pertenece al catálogo compartido.",
+		"ROLE_INFO_UNAVAILABLE":      "La información del rol no está disponible.",
+		"INVALID_ROLE_INFO":          "La información del rol no es válida.",
+		"FILE_REQUIRED":              "Se requiere un archivo en el campo 'file'.",
+		"INVALID_IMAGE":              "La imagen subida no se pudo procesar.",
+		"INVALID_UPLOAD":             "No se pudo leer el archivo subido.",
+		"BLOB_STORE_FAILED":          "No se pudo acceder al almacenamiento de archivos.",
+		"INVALID_SESSION":            "La sesión indicada no existe.",
+		"INVALID_EXERCISE":           "El ejercicio indicado no existe.",
+		"INVALID_ROUTINE":            "La rutina indicada no existe.",
+		"INVALID_EQUIPMENT":          "El equipamiento debe ser uno de los valores soportados.",
+		"INVALID_DIFFICULTY":         "La dificultad debe ser beginner, intermediate o advanced.",
+		"INVALID_MUSCLE_GROUP":       "El ID del grupo muscular es obligatorio.",
+		"INVALID_MUSCLE_GROUP_ROLE":  "El rol del grupo muscular debe ser primary o secondary.",
+		"INVALID_MEDIA_URL":          "Las URLs de medios deben ser URLs http(s) absolutas.",
+		"INVALID_TOKEN":              "El token no es válido.",
+		"TOKEN_EXPIRED":              "El token ha expirado.",
+		"INVALID_AUTH_FORMAT":        "Formato de autorización inválido. Use: Bearer <token>",
+	},
+	LocalePT: {
+		"NOT_FOUND":                  "Recurso não encontrado.",
+		"INTERNAL_SERVER_ERROR":      "Ocorreu um erro interno do servidor.",
+		"BAD_REQUEST":                "A requisição é inválida.",
+		"UNAUTHORIZED":               "A requisição não está autorizada.",
+		"FORBIDDEN":                  "Acesso proibido. Permissões insuficientes.",
+		"EMAIL_ALREADY_EXISTS":       "O email informado já está em uso.",
+		"INVALID_CREDENTIALS":        "Credenciais inválidas.",
+		"INVALID_REFRESH_TOKEN":      "O refresh token é inválido ou expirou.",
+		"SYSTEM_ROLE_NOT_DELETABLE":  "Os papéis do sistema não podem ser excluídos.",
+		"INVALID_REFERENCE":          "Um recurso referenciado não existe.",
+		"EMAIL_REQUIRED":             "O email é obrigatório.",
+		"INVALID_EMAIL_FORMAT":       "O formato do email é inválido.",
+		"PASSWORD_REQUIRED":          "A senha é obrigatória.",
+		"PASSWORD_TOO_SHORT":         "A senha deve ter pelo menos 8 caracteres.",
+		"PASSWORD_TOO_LONG":          "A senha não pode exceder 128 caracteres.",
+		"PASSWORD_WEAK":              "A senha deve conter pelo menos uma letra maiúscula, uma minúscula e um número.",
+		"NAME_TOO_SHORT":             "O nome deve ter pelo menos 2 caracteres.",
+		"NAME_TOO_LONG":              "O nome não pode exceder 100 caracteres.",
+		"INVALID_NAME_CHARACTERS":    "O nome contém caracteres inválidos.",
+		"NAME_REQUIRED":              "O nome é obrigatório.",
+		"ROLE_REQUIRED":              "O papel é obrigatório.",
+		"USER_INACTIVE":              "A conta de usuário está inativa.",
+		"USER_ALREADY_DELETED":       "O usuário já foi excluído.",
+		"USER_NOT_DELETED":           "O usuário não está excluído.",
+		"EMAIL_SOFT_DELETED":         "O email pertence a um usuário excluído.",
+		"INVALID_SESSION_TRANSITION": "A sessão não pode mudar para o estado solicitado.",
+		"SESSION_CLOSED":             "A sessão já foi concluída ou abandonada.",
+		"SESSION_NOT_IN_PROGRESS":    "A sessão não está em andamento.",
+		"ROUTINE_NOT_PUBLIC":         "A rotina é privada e pertence a outro usuário.",
+		"ROUTINE_NOT_OWNED":          "Somente o proprietário pode modificar a rotina.",
+		"INVALID_JSON":               "O corpo JSON é inválido.",
+		"VALIDATION_FAILED":          "Um ou mais campos são inválidos.",
+		"INVALID_MERGE_PATCH":        "O corpo deve ser um objeto JSON merge patch.",
+		"INVALID_CONTENT_TYPE":       "O tipo de conteúdo da requisição não é suportado.",
+		"CURRENT_PASSWORD_REQUIRED":  "A senha atual é obrigatória para alterar a senha.",
+		"INVALID_CURRENT_PASSWORD":   "A senha atual está incorreta.",
+		"ROUTINE_NAME_REQUIRED":      "O nome da rotina é obrigatório.",
+		"INVALID_LANGUAGE":           "O idioma não é suportado.",
+		"INSUFFICIENT_PERMISSIONS":   "Permissões insuficientes para realizar esta ação.",
+		"CATALOG_READ_ONLY":          "Apenas administradores podem modificar o catálogo compartilhado.",
+		"CASCADE_DELETES_HISTORY":    "Os exercícios têm histórico de usuários; reatribua-os com reassign_to.",
+		"UNSUPPORTED_MEDIA_TYPE":     "Tipo de arquivo não suportado.",
+		"INVALID_SIGNATURE":          "A URL de download é inválida ou expirou.",
+		"EXERCISE_TYPE_REQUIRED":     "O tipo de exercício é obrigatório.",
+		"MUSCLE_GROUP_REQUIRED":      "É necessário um grupo muscular principal.",
+		"TRANSLATION_NAME_REQUIRED":  "O nome traduzido é obrigatório.",
+		"DEFAULT_LOCALE_TRANSLATION": "Os textos do idioma padrão são editados no próprio recurso.",
+		"EXERCISE_NOT_CUSTOM":        "O exercício já pertence ao catálogo compartilhado.",
+		"ROLE_INFO_UNAVAILABLE":      "A informação do papel não está disponível.",
+		"INVALID_ROLE_INFO":          "A informação do papel é inválida.",
+		"FILE_REQUIRED":              "É necessário um arquivo no campo 'file'.",
+		"INVALID_IMAGE":              "A imagem enviada não pôde ser processada.",
+		"INVALID_UPLOAD":             "Não foi possível ler o arquivo enviado.",
+		"BLOB_STORE_FAILED":          "Não foi possível acessar o armazenamento de arquivos.",
+		"INVALID_SESSION":            "A sessão informada não existe.",
+		"INVALID_EXERCISE":           "O exercício informado não existe.",
+		"INVALID_ROUTINE":            "A rotina informada não existe.",
+		"INVALID_EQUIPMENT":          "O equipamento deve ser um dos valores suportados.",
+		"INVALID_DIFFICULTY":         "A dificuldade deve ser beginner, intermediate ou advanced.",
+		"INVALID_MUSCLE_GROUP":       "O ID do grupo muscular é obrigatório.",
+		"INVALID_MUSCLE_GROUP_ROLE":  "O papel do grupo muscular deve ser primary ou secondary.",
+		"INVALID_MEDIA_URL":          "As URLs de mídia devem ser URLs http(s) absolutas.",
+		"INVALID_TOKEN":              "O token é inválido.",
+		"TOKEN_EXPIRED":              "O token expirou.",
+		"INVALID_AUTH_FORMAT":        "Formato de autorização inválido. Use: Bearer <token>",
+	},
+}
+
+// Message returns the localized message for an error code, or fallback when there is no translation
+func Message(locale, code, fallback string) string {
+	if translated, ok := messages[locale][code]; ok {
+		return translated
+	}
+	return fallback
+}
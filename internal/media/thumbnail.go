@@ -0,0 +1,71 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"io"
+
+	// Registered decoders for uploaded images
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/pkg/errors"
+)
+
+// ThumbnailSize is the maximum width/height of generated thumbnails
+const ThumbnailSize = 320
+
+// Thumbnail decodes an image and returns a JPEG thumbnail that fits in maxSize x maxSize
+func Thumbnail(r io.Reader, maxSize int) ([]byte, error) {
+	src, _, err := image.Decode(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "media.Thumbnail: decode")
+	}
+
+	dst := scaleToFit(src, maxSize)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, errors.Wrap(err, "media.Thumbnail: encode")
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleToFit downsamples the image with a box filter, keeping its aspect ratio
+func scaleToFit(src image.Image, maxSize int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= maxSize && h <= maxSize {
+		return src
+	}
+
+	dw, dh := maxSize, h*maxSize/w
+	if h > w {
+		dw, dh = w*maxSize/h, maxSize
+	}
+	dw, dh = max(dw, 1), max(dh, 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := b.Min.Y+y*h/dh, b.Min.Y+max((y+1)*h/dh, y*h/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := b.Min.X+x*w/dw, b.Min.X+max((x+1)*w/dw, x*w/dw+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(pr), g+uint64(pg), bl+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
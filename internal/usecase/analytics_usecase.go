@@ -0,0 +1,259 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// defaultAnalyticsRange is the period analyzed when the client does not send a start date
+const defaultAnalyticsRange = 6 // months
+
+// VolumeTargets is the default weekly set range per muscle group
+type VolumeTargets struct {
+	MinSets float64
+	MaxSets float64
+}
+
+// AnalyticsUsecase computes training analytics from the user's sessions
+type AnalyticsUsecase struct {
+	sessionRepo         repositories.SessionRepository
+	sessionExerciseRepo repositories.SessionExerciseRepository
+	exerciseRepo        repositories.ExerciseRepository
+	muscleGroupRepo     repositories.GrupoMuscularRepository
+	targets             VolumeTargets
+}
+
+func NewAnalyticsUsecase(sessionRepo repositories.SessionRepository, sessionExerciseRepo repositories.SessionExerciseRepository, exerciseRepo repositories.ExerciseRepository, muscleGroupRepo repositories.GrupoMuscularRepository, targets VolumeTargets) *AnalyticsUsecase {
+	return &AnalyticsUsecase{
+		sessionRepo:         sessionRepo,
+		sessionExerciseRepo: sessionExerciseRepo,
+		exerciseRepo:        exerciseRepo,
+		muscleGroupRepo:     muscleGroupRepo,
+		targets:             targets,
+	}
+}
+
+// GetExerciseProgression returns the best estimated 1RM per week or month of the user on an exercise,
+// taking the best entry of each session, with the trend slope and percentage change
+func (uc *AnalyticsUsecase) GetExerciseProgression(userID, exerciseID uint, from, to *time.Time, periodo, formula string) (*models.ProgresionEjercicio, error) {
+	if periodo == "" {
+		periodo = models.PeriodoSemana
+	}
+	if periodo != models.PeriodoSemana && periodo != models.PeriodoMes {
+		return nil, domainErrors.NewAppError(400, "INVALID_BUCKET", "bucket must be week or month", nil)
+	}
+	if formula == "" {
+		formula = models.FormulaEpley
+	}
+	if formula != models.FormulaEpley && formula != models.FormulaBrzycki {
+		return nil, domainErrors.NewAppError(400, "INVALID_FORMULA", "formula must be epley or brzycki", nil)
+	}
+	desde, hasta, err := analyticsRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	ejercicio, err := uc.exerciseRepo.GetById(exerciseID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database", err)
+	}
+	if !ejercicio.IsVisibleTo(userID) {
+		return nil, domainErrors.ErrNotFound
+	}
+
+	// Planned, in progress and abandoned sessions do not reflect the lifts actually achieved
+	entries, err := uc.sessionExerciseRepo.GetTrainedByUserAndExercise(userID, exerciseID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_SESSION_EXERCISES_FAILED", "Failed to get session exercises from database", err)
+	}
+
+	// Best estimate of each session, dated by its first entry
+	type sessionBest struct {
+		fecha time.Time
+		best  float64
+	}
+	sessions := map[uint]*sessionBest{}
+	for _, e := range entries {
+		if e.Fecha.Before(desde) || !e.Fecha.Before(hasta) {
+			continue
+		}
+		estimate := models.EstimateOneRepMaxWith(formula, e.Peso, e.Repeticiones)
+		if estimate <= 0 {
+			continue
+		}
+		s, ok := sessions[e.SesionID]
+		if !ok {
+			sessions[e.SesionID] = &sessionBest{fecha: e.Fecha, best: estimate}
+			continue
+		}
+		if e.Fecha.Before(s.fecha) {
+			s.fecha = e.Fecha
+		}
+		if estimate > s.best {
+			s.best = estimate
+		}
+	}
+
+	puntos := map[time.Time]*models.PuntoProgresion{}
+	for _, s := range sessions {
+		inicio := models.PeriodStart(periodo, s.fecha)
+		punto, ok := puntos[inicio]
+		if !ok {
+			punto = &models.PuntoProgresion{Inicio: inicio}
+			puntos[inicio] = punto
+		}
+		punto.Sesiones++
+		if s.best > punto.UnaRepMaxima {
+			punto.UnaRepMaxima = s.best
+		}
+	}
+
+	progresion := &models.ProgresionEjercicio{
+		EjercicioID: exerciseID,
+		Formula:     formula,
+		Periodo:     periodo,
+		Desde:       desde,
+		Hasta:       hasta.AddDate(0, 0, -1),
+		Puntos:      make([]models.PuntoProgresion, 0, len(puntos)),
+	}
+	for _, punto := range puntos {
+		progresion.Puntos = append(progresion.Puntos, *punto)
+	}
+	sort.Slice(progresion.Puntos, func(i, j int) bool {
+		return progresion.Puntos[i].Inicio.Before(progresion.Puntos[j].Inicio)
+	})
+	progresion.ComputeTrend()
+	return progresion, nil
+}
+
+// GetMuscleGroupVolume returns the tonnage and sets per muscle group of the week containing the given date,
+// compared with the previous week and flagged against the weekly set targets. Zero targets use the defaults.
+func (uc *AnalyticsUsecase) GetMuscleGroupVolume(userID uint, week *time.Time, targets VolumeTargets) (*models.DashboardVolumen, error) {
+	if targets.MinSets == 0 {
+		targets.MinSets = uc.targets.MinSets
+	}
+	if targets.MaxSets == 0 {
+		targets.MaxSets = uc.targets.MaxSets
+	}
+	if targets.MinSets < 0 || targets.MaxSets < targets.MinSets {
+		return nil, domainErrors.NewAppError(400, "INVALID_TARGETS", "min_sets must not be negative nor greater than max_sets", nil)
+	}
+
+	day := time.Now()
+	if week != nil {
+		day = *week
+	}
+	semana := models.PeriodStart(models.PeriodoSemana, day)
+	anterior := semana.AddDate(0, 0, -7)
+
+	actual, err := uc.sessionExerciseRepo.GetMuscleGroupLoad(userID, semana, semana.AddDate(0, 0, 7))
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_MUSCLE_GROUP_LOAD_FAILED", "Failed to get muscle group load from database", err)
+	}
+	previa, err := uc.sessionExerciseRepo.GetMuscleGroupLoad(userID, anterior, semana)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_MUSCLE_GROUP_LOAD_FAILED", "Failed to get muscle group load from database", err)
+	}
+	grupos, err := uc.muscleGroupRepo.GetAll()
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_MUSCLE_GROUPS_FAILED", "Failed to get all muscle groups from database", err)
+	}
+
+	byGroup := func(cargas []models.CargaGrupoMuscular) map[uint]models.CargaGrupoMuscular {
+		result := make(map[uint]models.CargaGrupoMuscular, len(cargas))
+		for _, c := range cargas {
+			result[c.GrupoMuscularID] = c
+		}
+		return result
+	}
+	actualPorGrupo, previaPorGrupo := byGroup(actual), byGroup(previa)
+
+	dashboard := &models.DashboardVolumen{
+		Semana:            semana,
+		SemanaAnterior:    anterior,
+		ObjetivoSeriesMin: targets.MinSets,
+		ObjetivoSeriesMax: targets.MaxSets,
+		Grupos:            make([]models.VolumenGrupoMuscular, 0, len(grupos)),
+	}
+	for _, g := range grupos {
+		cur, prev := actualPorGrupo[g.ID], previaPorGrupo[g.ID]
+		dashboard.Grupos = append(dashboard.Grupos, models.VolumenGrupoMuscular{
+			GrupoMuscularID:  g.ID,
+			Nombre:           g.Nombre,
+			Tonelaje:         cur.Tonelaje,
+			Series:           cur.Series,
+			TonelajeAnterior: prev.Tonelaje,
+			SeriesAnterior:   prev.Series,
+			CambioTonelaje:   models.PercentChange(prev.Tonelaje, cur.Tonelaje),
+			Estado:           models.LoadStatus(cur.Series, targets.MinSets, targets.MaxSets),
+		})
+	}
+	return dashboard, nil
+}
+
+// GetConsistency returns the sessions and minutes trained per day of a calendar year, or of the last
+// 365 days when no year is given, with the sessions-per-week average and the training streaks
+func (uc *AnalyticsUsecase) GetConsistency(userID uint, year int) (*models.ResumenConsistencia, error) {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	resumen := &models.ResumenConsistencia{Desde: today.AddDate(0, 0, -364), Hasta: today}
+	if year != 0 {
+		if year < 1900 || year > today.Year() {
+			return nil, domainErrors.NewAppError(400, "INVALID_YEAR", "year must be between 1900 and the current year", nil)
+		}
+		resumen.Desde = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		resumen.Hasta = time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+
+	activos, err := uc.sessionRepo.GetActivityByDay(userID, resumen.Desde, resumen.Hasta.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_ACTIVITY_FAILED", "Failed to get session activity from database", err)
+	}
+	porDia := make(map[string]models.DiaActividad, len(activos))
+	for _, d := range activos {
+		porDia[d.Fecha.Format(time.DateOnly)] = d
+	}
+
+	// Every day of the period is returned so clients can draw the heatmap without filling gaps
+	for day := resumen.Desde; !day.After(resumen.Hasta); day = day.AddDate(0, 0, 1) {
+		dia := porDia[day.Format(time.DateOnly)]
+		dia.Fecha = day
+		resumen.Dias = append(resumen.Dias, dia)
+	}
+	resumen.ComputeAverages(today)
+
+	resumen.Rachas, err = uc.sessionRepo.GetStreaks(userID, today)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_STREAKS_FAILED", "Failed to get training streaks from database", err)
+	}
+	return resumen, nil
+}
+
+// analyticsRange resolves the analyzed dates as [desde, hasta), both whole days. The end date is
+// inclusive for the client and defaults to today; the start date defaults to six months earlier.
+func analyticsRange(from, to *time.Time) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	hasta := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to != nil {
+		hasta = *to
+	}
+	hasta = hasta.AddDate(0, 0, 1)
+
+	desde := hasta.AddDate(0, -defaultAnalyticsRange, 0)
+	if from != nil {
+		desde = *from
+	}
+	if !desde.Before(hasta) {
+		return time.Time{}, time.Time{}, domainErrors.NewAppError(400, "INVALID_DATE_RANGE", "from must not be after to", nil)
+	}
+	return desde, hasta, nil
+}
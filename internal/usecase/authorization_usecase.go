@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"slices"
 
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 )
@@ -38,6 +40,30 @@ func (uc *AuthorizationUsecase) CanDeleteUsers(ctx context.Context, userRoleID u
 	return false, nil
 }
 
+// catalogManagerRoles are the roles allowed to manage the shared exercise catalog
+var catalogManagerRoles = []string{models.RoleAdmin, models.RoleDev}
+
+// isCatalogManager checks if the role can manage the shared exercise catalog
+func isCatalogManager(roleRepo repositories.RoleRepository, roleID uint) (bool, error) {
+	role, err := roleRepo.GetByID(context.Background(), roleID)
+	if err != nil {
+		return false, domainErrors.NewAppError(500, "DB_GET_ROLE_FAILED", "Failed to get role from database", err)
+	}
+	return slices.Contains(catalogManagerRoles, role.Name), nil
+}
+
+// userManagerRoles are the roles allowed to manage the accounts of other users
+var userManagerRoles = []string{models.RoleAdmin, models.RoleDev}
+
+// isUserManager checks if the role can manage the accounts of other users
+func isUserManager(roleRepo repositories.RoleRepository, roleID uint) (bool, error) {
+	role, err := roleRepo.GetByID(context.Background(), roleID)
+	if err != nil {
+		return false, domainErrors.NewAppError(500, "DB_GET_ROLE_FAILED", "Failed to get role from database", err)
+	}
+	return slices.Contains(userManagerRoles, role.Name), nil
+}
+
 // GetAllowedRolesForUserDeletion retorna los roles que pueden eliminar usuarios
 func (uc *AuthorizationUsecase) GetAllowedRolesForUserDeletion() []string {
 	return []string{models.RoleAdmin, models.RoleDev}
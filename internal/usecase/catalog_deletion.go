@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"fmt"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// checkDeleteOptions validates the requested handling of dependents and that only catalog managers request it
+func checkDeleteOptions(roleRepo repositories.RoleRepository, id, roleID uint, opts repositories.DeleteOptions) error {
+	if !opts.IsSet() {
+		return nil
+	}
+	if opts.Cascade && opts.ReassignTo != 0 {
+		return domainErrors.NewAppError(400, "INVALID_DELETE_OPTIONS", "cascade and reassign_to cannot be combined", nil)
+	}
+	if opts.ReassignTo == id {
+		return domainErrors.NewAppError(400, "INVALID_DELETE_OPTIONS", "Dependents cannot be reassigned to the deleted resource", nil)
+	}
+
+	manager, err := isCatalogManager(roleRepo, roleID)
+	if err != nil {
+		return err
+	}
+	if !manager {
+		return domainErrors.NewAppError(403, "INSUFFICIENT_PERMISSIONS", "Only admins can cascade or reassign deletions", nil)
+	}
+	return nil
+}
+
+// checkDependents rejects deleting a resource that is still referenced unless its dependents are cascaded or reassigned
+func checkDependents(resource string, dependents repositories.DependentCounts, opts repositories.DeleteOptions) error {
+	if dependents.Total() == 0 || opts.IsSet() {
+		return nil
+	}
+	message := fmt.Sprintf("The %s is still referenced; delete with cascade=true or reassign_to=<id>", resource)
+	return domainErrors.NewAppError(409, "CONFLICT", message, nil).WithDetails(dependents)
+}
+
+// checkCascade rejects cascading a deletion into exercises with user history, so admins cannot wipe the sessions
+// and goals of every user; those exercises must be reassigned instead
+func checkCascade(resource string, dependents repositories.DependentCounts, opts repositories.DeleteOptions) error {
+	if !opts.Cascade || dependents[repositories.DependentExerciseHistory] == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("The exercises of the %s have user history; reassign them with reassign_to=<id>", resource)
+	return domainErrors.NewAppError(409, "CASCADE_DELETES_HISTORY", message, nil).WithDetails(dependents)
+}
+
+// deletionError maps the error of a catalog deletion to an application error
+func deletionError(code, resource string, err error) error {
+	if errors.Is(err, domainErrors.ErrConflict) {
+		return domainErrors.NewAppError(409, "CONFLICT", fmt.Sprintf("The %s is still referenced", resource), err)
+	}
+	return domainErrors.NewAppError(500, code, fmt.Sprintf("Failed to delete %s from database", resource), err)
+}
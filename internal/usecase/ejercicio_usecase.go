@@ -1,24 +1,70 @@
 package usecase
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/Diegonr1791/GymBro/internal/media"
 	"github.com/pkg/errors"
 )
 
+// MediaLimits defines the maximum accepted size of uploaded media
+type MediaLimits struct {
+	MaxImageBytes int64
+	MaxVideoBytes int64
+}
+
+// Accepted upload content types (sniffed from the content) and their file extensions
+var (
+	imageContentTypes = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/gif": ".gif"}
+	videoContentTypes = map[string]string{"video/mp4": ".mp4", "video/webm": ".webm"}
+)
+
+// Limits for the number of suggested alternatives
+const (
+	defaultAlternativesLimit = 10
+	maxAlternativesLimit     = 50
+)
+
 type ExerciseUsecase struct {
-	exerciseRepo repositories.ExerciseRepository
+	exerciseRepo    repositories.ExerciseRepository
+	alternativeRepo repositories.ExerciseAlternativeRepository
+	roleRepo        repositories.RoleRepository
+	blobStore       repositories.BlobStore
+	mediaLimits     MediaLimits
 }
 
-func NewExerciseUsecase(exerciseRepo repositories.ExerciseRepository) *ExerciseUsecase {
-	return &ExerciseUsecase{exerciseRepo}
+func NewExerciseUsecase(exerciseRepo repositories.ExerciseRepository, alternativeRepo repositories.ExerciseAlternativeRepository, roleRepo repositories.RoleRepository, blobStore repositories.BlobStore, mediaLimits MediaLimits) *ExerciseUsecase {
+	return &ExerciseUsecase{
+		exerciseRepo:    exerciseRepo,
+		alternativeRepo: alternativeRepo,
+		roleRepo:        roleRepo,
+		blobStore:       blobStore,
+		mediaLimits:     mediaLimits,
+	}
 }
 
-func (uc *ExerciseUsecase) GetAllExercises() ([]*models.Ejercicio, error) {
-	ejercicios, err := uc.exerciseRepo.GetAll()
+// SearchExercises returns a page of the exercises matching the given filters
+func (uc *ExerciseUsecase) SearchExercises(filter repositories.ExerciseFilter, spec repositories.QuerySpec) (*repositories.Page[*models.Ejercicio], error) {
+	if filter.Equipamiento != "" && !slices.Contains(models.EquiposValidos, filter.Equipamiento) {
+		return nil, domainErrors.NewAppError(400, "INVALID_EQUIPMENT", "Equipment must be one of the supported values", nil)
+	}
+	if filter.Dificultad != "" && !slices.Contains(models.DificultadesValidas, filter.Dificultad) {
+		return nil, domainErrors.NewAppError(400, "INVALID_DIFFICULTY", "Difficulty must be one of: beginner, intermediate, advanced", nil)
+	}
+
+	ejercicios, err := uc.exerciseRepo.SearchPage(filter, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_EXERCISES_FAILED", "Failed to get all exercises from database", err)
+		return nil, listFailed(err, "DB_SEARCH_EXERCISES_FAILED", "Failed to search exercises in database")
 	}
 	return ejercicios, nil
 }
@@ -34,39 +80,444 @@ func (uc *ExerciseUsecase) GetExerciseByID(id uint) (*models.Ejercicio, error) {
 	return ejercicio, nil
 }
 
-func (uc *ExerciseUsecase) CreateExercise(exercise *models.Ejercicio) error {
+// GetExerciseForUser returns the fields and relations of an exercise requested by the projection if it is
+// visible to the user; other users' custom exercises are reported as not found
+func (uc *ExerciseUsecase) GetExerciseForUser(id, userID uint, proj repositories.Projection) (*models.Ejercicio, error) {
+	ejercicio, err := uc.exerciseRepo.GetByIDProjected(id, proj)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, listFailed(err, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database")
+	}
+	if !ejercicio.IsVisibleTo(userID) {
+		return nil, domainErrors.ErrNotFound
+	}
+	return ejercicio, nil
+}
+
+// CreateExercise adds the exercise to the shared catalog when created by a catalog manager,
+// otherwise it becomes a custom exercise owned by the user
+func (uc *ExerciseUsecase) CreateExercise(exercise *models.Ejercicio, userID, roleID uint) error {
+	if err := uc.validateExercise(exercise); err != nil {
+		return err
+	}
+	exercise.NormalizeMuscleGroups()
+
+	manager, err := isCatalogManager(uc.roleRepo, roleID)
+	if err != nil {
+		return err
+	}
+	if manager {
+		exercise.Promote()
+	} else {
+		exercise.IsCustom = true
+		exercise.UsuarioID = &userID
+	}
+
 	if err := uc.exerciseRepo.Create(exercise); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidReference) {
+			return domainErrors.NewAppError(400, "INVALID_REFERENCE", "The exercise type or a muscle group does not exist", err)
+		}
 		return domainErrors.NewAppError(500, "DB_CREATE_EXERCISE_FAILED", "Failed to create exercise in database", err)
 	}
 	return nil
 }
 
-func (uc *ExerciseUsecase) UpdateExercise(exercise *models.Ejercicio) error {
-	// Verify exercise exists before updating
-	if _, err := uc.exerciseRepo.GetById(exercise.ID); err != nil {
-		if errors.Is(err, domainErrors.ErrNotFound) {
-			return domainErrors.ErrNotFound
-		}
-		return domainErrors.NewAppError(500, "DB_UPDATE_EXERCISE_FAILED", "Failed to verify exercise existence", err)
+func (uc *ExerciseUsecase) UpdateExercise(exercise *models.Ejercicio, userID, roleID uint) error {
+	// Verify exercise exists and can be modified by the user before updating
+	existing, err := uc.getModifiable(exercise.ID, userID, roleID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.validateExercise(exercise); err != nil {
+		return err
 	}
+	exercise.NormalizeMuscleGroups()
+
+	// Ownership is only changed through promotion
+	exercise.IsCustom = existing.IsCustom
+	exercise.UsuarioID = existing.UsuarioID
+
+	// Uploaded media is only changed through the upload endpoints
+	exercise.ImagenKey = existing.ImagenKey
+	exercise.MiniaturaKey = existing.MiniaturaKey
+	exercise.VideoKey = existing.VideoKey
 
 	if err := uc.exerciseRepo.Update(exercise); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidReference) {
+			return domainErrors.NewAppError(400, "INVALID_REFERENCE", "The exercise type or a muscle group does not exist", err)
+		}
 		return domainErrors.NewAppError(500, "DB_UPDATE_EXERCISE_FAILED", "Failed to update exercise in database", err)
 	}
 	return nil
 }
 
-func (uc *ExerciseUsecase) DeleteExercise(id uint) error {
-	if err := uc.exerciseRepo.Delete(id); err != nil {
-		return domainErrors.NewAppError(500, "DB_DELETE_EXERCISE_FAILED", "Failed to delete exercise from database", err)
+// DeleteExercise deletes an exercise; exercises performed in sessions require cascading or reassigning those entries,
+// which only catalog managers and the owner of a custom exercise may request
+func (uc *ExerciseUsecase) DeleteExercise(id, userID, roleID uint, opts repositories.DeleteOptions) error {
+	exercise, err := uc.getModifiable(id, userID, roleID)
+	if err != nil {
+		return err
+	}
+	if !exercise.IsOwnedBy(userID) {
+		if err := checkDeleteOptions(uc.roleRepo, id, roleID, opts); err != nil {
+			return err
+		}
+	} else if opts.Cascade && opts.ReassignTo != 0 {
+		return domainErrors.NewAppError(400, "INVALID_DELETE_OPTIONS", "cascade and reassign_to cannot be combined", nil)
+	}
+
+	if opts.ReassignTo != 0 {
+		target, err := uc.exerciseRepo.GetById(opts.ReassignTo)
+		if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.NewAppError(500, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database", err)
+		}
+		// Reassigned entries must stay visible to everyone who performed them
+		if target == nil || target.ID == id || (exercise.IsCustom && !target.IsVisibleTo(*exercise.UsuarioID)) || (!exercise.IsCustom && target.IsCustom) {
+			return domainErrors.NewAppError(400, "INVALID_REASSIGN_TARGET", "reassign_to must be another exercise available to the same users", nil)
+		}
 	}
+
+	dependents, err := uc.exerciseRepo.CountDependents(id)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_EXERCISE_FAILED", "Failed to count exercise dependents", err)
+	}
+	if err := checkDependents("exercise", dependents, opts); err != nil {
+		return err
+	}
+
+	if err := uc.exerciseRepo.DeleteWith(id, opts); err != nil {
+		return deletionError("DB_DELETE_EXERCISE_FAILED", "exercise", err)
+	}
+
+	uc.deleteBlobs(exercise.ImagenKey, exercise.MiniaturaKey, exercise.VideoKey)
 	return nil
 }
 
-func (uc *ExerciseUsecase) GetExercisesByMuscleGroup(muscleGroupID uint) ([]*models.Ejercicio, error) {
-	ejercicios, err := uc.exerciseRepo.GetByMuscleGroup(muscleGroupID)
+// PromoteExercise moves a custom exercise into the shared catalog
+func (uc *ExerciseUsecase) PromoteExercise(id uint) (*models.Ejercicio, error) {
+	exercise, err := uc.GetExerciseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !exercise.IsCustom {
+		return nil, domainErrors.NewAppError(409, "EXERCISE_NOT_CUSTOM", "The exercise already belongs to the shared catalog", nil)
+	}
+
+	if err := uc.exerciseRepo.Promote(id); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_PROMOTE_EXERCISE_FAILED", "Failed to promote exercise in database", err)
+	}
+	exercise.Promote()
+	return exercise, nil
+}
+
+// GetAlternatives returns substitutes for an exercise: curated alternatives first, then exercises
+// sharing its main muscle group ranked by similarity
+func (uc *ExerciseUsecase) GetAlternatives(id, userID uint, limit int) ([]models.EjercicioSugerido, error) {
+	exercise, err := uc.GetExerciseForUser(id, userID, repositories.Projection{})
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultAlternativesLimit
+	}
+	limit = min(limit, maxAlternativesLimit)
+
+	curatedIDs, err := uc.alternativeRepo.GetAlternativeIDs(id)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_EXERCISE_ALTERNATIVES_FAILED", "Failed to get exercise alternatives from database", err)
+	}
+	curated, err := uc.exerciseRepo.GetByIDs(curatedIDs)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_EXERCISE_ALTERNATIVES_FAILED", "Failed to get exercise alternatives from database", err)
+	}
+
+	var candidates []*models.Ejercicio
+	if exercise.GrupoMuscularID != 0 {
+		candidates, err = uc.exerciseRepo.Search(repositories.ExerciseFilter{
+			PrimaryGrupoMuscularID: exercise.GrupoMuscularID,
+			ViewerID:               userID,
+		})
+		if err != nil {
+			return nil, domainErrors.NewAppError(500, "DB_GET_EXERCISE_ALTERNATIVES_FAILED", "Failed to search exercise alternatives in database", err)
+		}
+	}
+
+	seen := map[uint]bool{id: true}
+	suggestions := make([]models.EjercicioSugerido, 0, len(curated)+len(candidates))
+	for _, alt := range curated {
+		if seen[alt.ID] || !alt.IsVisibleTo(userID) {
+			continue
+		}
+		seen[alt.ID] = true
+		suggestions = append(suggestions, models.EjercicioSugerido{Ejercicio: alt, Puntuacion: exercise.Similarity(alt), Curada: true})
+	}
+	for _, alt := range candidates {
+		if seen[alt.ID] {
+			continue
+		}
+		seen[alt.ID] = true
+		suggestions = append(suggestions, models.EjercicioSugerido{Ejercicio: alt, Puntuacion: exercise.Similarity(alt)})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b models.EjercicioSugerido) int {
+		if a.Curada != b.Curada {
+			if a.Curada {
+				return -1
+			}
+			return 1
+		}
+		if a.Puntuacion != b.Puntuacion {
+			return b.Puntuacion - a.Puntuacion
+		}
+		return strings.Compare(a.Ejercicio.Nombre, b.Ejercicio.Nombre)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// AddAlternative curates a substitution between two shared catalog exercises.
+// It reports whether the pair was newly created.
+func (uc *ExerciseUsecase) AddAlternative(id, alternativeID uint, nota string) (*models.EjercicioAlternativa, bool, error) {
+	if id == alternativeID {
+		return nil, false, domainErrors.NewAppError(400, "INVALID_ALTERNATIVE", "An exercise cannot be an alternative of itself", nil)
+	}
+	for _, exerciseID := range []uint{id, alternativeID} {
+		exercise, err := uc.exerciseRepo.GetById(exerciseID)
+		if err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return nil, false, domainErrors.NewAppError(400, "INVALID_ALTERNATIVE", fmt.Sprintf("Exercise %d does not exist", exerciseID), nil)
+			}
+			return nil, false, domainErrors.NewAppError(500, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database", err)
+		}
+		if exercise.IsCustom {
+			return nil, false, domainErrors.NewAppError(400, "INVALID_ALTERNATIVE", "Curated alternatives must belong to the shared catalog", nil)
+		}
+	}
+
+	alternativa := models.NewEjercicioAlternativa(id, alternativeID, nota)
+	created, err := uc.alternativeRepo.CreateIfNotExists(alternativa)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_EXERCISES_BY_MUSCLE_GROUP_FAILED", "Failed to get exercises by muscle group from database", err)
+		return nil, false, domainErrors.NewAppError(500, "DB_CREATE_EXERCISE_ALTERNATIVE_FAILED", "Failed to create exercise alternative in database", err)
+	}
+	return alternativa, created, nil
+}
+
+// RemoveAlternative deletes a curated substitution
+func (uc *ExerciseUsecase) RemoveAlternative(id, alternativeID uint) error {
+	deleted, err := uc.alternativeRepo.Delete(id, alternativeID)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_EXERCISE_ALTERNATIVE_FAILED", "Failed to delete exercise alternative from database", err)
+	}
+	if !deleted {
+		return domainErrors.ErrNotFound
+	}
+	return nil
+}
+
+// getModifiable returns the exercise if the user may change it: custom exercises by their owner,
+// shared ones by catalog managers
+func (uc *ExerciseUsecase) getModifiable(id, userID, roleID uint) (*models.Ejercicio, error) {
+	exercise, err := uc.GetExerciseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if exercise.IsOwnedBy(userID) {
+		return exercise, nil
+	}
+
+	manager, err := isCatalogManager(uc.roleRepo, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if manager {
+		return exercise, nil
+	}
+	if !exercise.IsVisibleTo(userID) {
+		return nil, domainErrors.ErrNotFound
+	}
+	return nil, domainErrors.NewAppError(403, "CATALOG_READ_ONLY", "Only admins can modify the shared exercise catalog", nil)
+}
+
+// UploadExerciseImage stores an image for the exercise and generates its thumbnail
+func (uc *ExerciseUsecase) UploadExerciseImage(id, userID, roleID uint, content io.Reader, size int64) (*models.Ejercicio, error) {
+	exercise, err := uc.getModifiable(id, userID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if size > uc.mediaLimits.MaxImageBytes {
+		return nil, fileTooLargeError(uc.mediaLimits.MaxImageBytes)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, uc.mediaLimits.MaxImageBytes+1))
+	if err != nil {
+		return nil, domainErrors.NewAppError(400, "INVALID_UPLOAD", "Failed to read uploaded file", err)
+	}
+	if int64(len(data)) > uc.mediaLimits.MaxImageBytes {
+		return nil, fileTooLargeError(uc.mediaLimits.MaxImageBytes)
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := imageContentTypes[contentType]
+	if !ok {
+		return nil, domainErrors.NewAppError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Images must be JPEG, PNG or GIF", nil)
+	}
+
+	thumbnail, err := media.Thumbnail(bytes.NewReader(data), media.ThumbnailSize)
+	if err != nil {
+		return nil, domainErrors.NewAppError(400, "INVALID_IMAGE", "The uploaded image could not be processed", err)
+	}
+
+	stamp := time.Now().UnixNano()
+	imageKey := fmt.Sprintf("exercises/%d/image-%d%s", id, stamp, ext)
+	thumbKey := fmt.Sprintf("exercises/%d/thumb-%d.jpg", id, stamp)
+
+	if err := uc.blobStore.Put(imageKey, bytes.NewReader(data), contentType); err != nil {
+		return nil, domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to store uploaded image", err)
+	}
+	if err := uc.blobStore.Put(thumbKey, bytes.NewReader(thumbnail), "image/jpeg"); err != nil {
+		uc.deleteBlobs(imageKey)
+		return nil, domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to store image thumbnail", err)
+	}
+
+	oldImage, oldThumb := exercise.ImagenKey, exercise.MiniaturaKey
+	exercise.ImagenKey = imageKey
+	exercise.MiniaturaKey = thumbKey
+	if err := uc.exerciseRepo.UpdateMedia(exercise); err != nil {
+		uc.deleteBlobs(imageKey, thumbKey)
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_EXERCISE_FAILED", "Failed to update exercise media in database", err)
+	}
+
+	uc.deleteBlobs(oldImage, oldThumb)
+	return exercise, nil
+}
+
+// UploadExerciseVideo stores a short clip for the exercise
+func (uc *ExerciseUsecase) UploadExerciseVideo(id, userID, roleID uint, content io.Reader, size int64) (*models.Ejercicio, error) {
+	exercise, err := uc.getModifiable(id, userID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	if size > uc.mediaLimits.MaxVideoBytes {
+		return nil, fileTooLargeError(uc.mediaLimits.MaxVideoBytes)
+	}
+
+	// Sniff the content type from the first bytes instead of trusting the client
+	header := make([]byte, 512)
+	n, err := io.ReadFull(content, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, domainErrors.NewAppError(400, "INVALID_UPLOAD", "Failed to read uploaded file", err)
+	}
+	header = header[:n]
+
+	contentType := http.DetectContentType(header)
+	ext, ok := videoContentTypes[contentType]
+	if !ok {
+		return nil, domainErrors.NewAppError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Videos must be MP4 or WebM", nil)
+	}
+
+	// The size sent by the client may be unknown, so the stream itself is checked against the limit
+	videoKey := fmt.Sprintf("exercises/%d/video-%d%s", id, time.Now().UnixNano(), ext)
+	body := io.MultiReader(bytes.NewReader(header), &limitedReader{content, uc.mediaLimits.MaxVideoBytes - int64(n)})
+	if err := uc.blobStore.Put(videoKey, body, contentType); err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return nil, fileTooLargeError(uc.mediaLimits.MaxVideoBytes)
+		}
+		return nil, domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to store uploaded video", err)
+	}
+
+	oldVideo := exercise.VideoKey
+	exercise.VideoKey = videoKey
+	if err := uc.exerciseRepo.UpdateMedia(exercise); err != nil {
+		uc.deleteBlobs(videoKey)
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_EXERCISE_FAILED", "Failed to update exercise media in database", err)
+	}
+
+	uc.deleteBlobs(oldVideo)
+	return exercise, nil
+}
+
+// deleteBlobs removes stored media on a best-effort basis
+func (uc *ExerciseUsecase) deleteBlobs(keys ...string) {
+	deleteBlobs(uc.blobStore, keys...)
+}
+
+// errFileTooLarge aborts storing an upload that exceeds its size limit
+var errFileTooLarge = errors.New("file exceeds the size limit")
+
+// limitedReader reads up to remaining bytes and fails with errFileTooLarge, instead of truncating, when the
+// content is longer
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	// Read one byte past the limit to tell content of exactly the limit from longer content
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
+
+func fileTooLargeError(maxBytes int64) error {
+	return domainErrors.NewAppError(http.StatusRequestEntityTooLarge, "FILE_TOO_LARGE", fmt.Sprintf("File must not exceed %d MB", maxBytes>>20), nil)
+}
+
+func (uc *ExerciseUsecase) GetExercisesByMuscleGroup(muscleGroupID, userID uint, spec repositories.QuerySpec) (*repositories.Page[*models.Ejercicio], error) {
+	ejercicios, err := uc.exerciseRepo.SearchPage(repositories.ExerciseFilter{GrupoMuscularID: muscleGroupID, ViewerID: userID}, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_EXERCISES_BY_MUSCLE_GROUP_FAILED", "Failed to get exercises by muscle group from database")
 	}
 	return ejercicios, nil
 }
+
+// validateExercise validates the catalog fields of an exercise
+func (uc *ExerciseUsecase) validateExercise(exercise *models.Ejercicio) error {
+	if exercise.Nombre == "" {
+		return domainErrors.NewAppError(400, "NAME_REQUIRED", "Exercise name is required", nil)
+	}
+	if exercise.TipoEjercicioID == 0 {
+		return domainErrors.NewAppError(400, "EXERCISE_TYPE_REQUIRED", "Exercise type ID is required", nil)
+	}
+	hasPrimary := exercise.GrupoMuscularID != 0 || slices.ContainsFunc(exercise.GruposMusculares, func(g models.EjercicioGrupoMuscular) bool {
+		return g.Rol == models.RolGrupoPrimario
+	})
+	if !hasPrimary {
+		return domainErrors.NewAppError(400, "MUSCLE_GROUP_REQUIRED", "A primary muscle group is required", nil)
+	}
+	if exercise.Equipamiento != "" && !slices.Contains(models.EquiposValidos, exercise.Equipamiento) {
+		return domainErrors.NewAppError(400, "INVALID_EQUIPMENT", "Equipment must be one of the supported values", nil)
+	}
+	if exercise.Dificultad != "" && !slices.Contains(models.DificultadesValidas, exercise.Dificultad) {
+		return domainErrors.NewAppError(400, "INVALID_DIFFICULTY", "Difficulty must be one of: beginner, intermediate, advanced", nil)
+	}
+	for _, g := range exercise.GruposMusculares {
+		if g.GrupoMuscularID == 0 {
+			return domainErrors.NewAppError(400, "INVALID_MUSCLE_GROUP", "Muscle group ID is required", nil)
+		}
+		if g.Rol != models.RolGrupoPrimario && g.Rol != models.RolGrupoSecundario {
+			return domainErrors.NewAppError(400, "INVALID_MUSCLE_GROUP_ROLE", "Muscle group role must be primary or secondary", nil)
+		}
+	}
+	for _, mediaURL := range []string{exercise.ImagenURL, exercise.VideoURL} {
+		if mediaURL != "" && !isHTTPURL(mediaURL) {
+			return domainErrors.NewAppError(400, "INVALID_MEDIA_URL", "Media URLs must be absolute http(s) URLs", nil)
+		}
+	}
+	return nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.ParseRequestURI(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"strings"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// CoachUsecase manages the coaches a user authorizes to see their private data
+type CoachUsecase struct {
+	coachRepo repositories.CoachAccessRepository
+	userRepo  repositories.UsuarioRepository
+}
+
+func NewCoachUsecase(coachRepo repositories.CoachAccessRepository, userRepo repositories.UsuarioRepository) *CoachUsecase {
+	return &CoachUsecase{coachRepo, userRepo}
+}
+
+// GetCoaches lists the coaches authorized by the user
+func (uc *CoachUsecase) GetCoaches(userID uint) ([]models.AccesoEntrenador, error) {
+	accesos, err := uc.coachRepo.GetCoaches(userID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_COACHES_FAILED", "Failed to get coaches from database", err)
+	}
+	return accesos, nil
+}
+
+// GetClients lists the users that authorized the coach
+func (uc *CoachUsecase) GetClients(coachID uint) ([]models.AccesoEntrenador, error) {
+	accesos, err := uc.coachRepo.GetClients(coachID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_CLIENTS_FAILED", "Failed to get clients from database", err)
+	}
+	return accesos, nil
+}
+
+// GrantAccess authorizes the active user with the given email as a coach of the user
+func (uc *CoachUsecase) GrantAccess(userID uint, email string) (*models.AccesoEntrenador, error) {
+	email = strings.TrimSpace(email)
+	if email == "" {
+		return nil, domainErrors.ErrEmailRequired
+	}
+	coach, err := uc.userRepo.GetByEmail(email)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewAppError(404, "COACH_NOT_FOUND", "No active user has that email", nil)
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_USER_FAILED", "Failed to get user from database", err)
+	}
+	if coach.ID == userID {
+		return nil, domainErrors.NewAppError(400, "INVALID_COACH", "Users cannot be their own coach", nil)
+	}
+
+	acceso := &models.AccesoEntrenador{UsuarioID: userID, EntrenadorID: coach.ID}
+	created, err := uc.coachRepo.Grant(acceso)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GRANT_COACH_FAILED", "Failed to authorize coach in database", err)
+	}
+	if !created {
+		return nil, domainErrors.NewAppError(409, "COACH_ALREADY_AUTHORIZED", "The coach is already authorized", nil)
+	}
+	acceso.Nombre, acceso.Email = coach.Name, coach.Email
+	return acceso, nil
+}
+
+// RevokeAccess removes the access of a coach to the user's data
+func (uc *CoachUsecase) RevokeAccess(userID, coachID uint) error {
+	revoked, err := uc.coachRepo.Revoke(userID, coachID)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_REVOKE_COACH_FAILED", "Failed to revoke coach access in database", err)
+	}
+	if !revoked {
+		return domainErrors.ErrNotFound
+	}
+	return nil
+}
+
+// canView checks if the viewer is the owner of the data or one of their authorized coaches
+func canView(coachRepo repositories.CoachAccessRepository, ownerID, viewerID uint) (bool, error) {
+	if ownerID == viewerID {
+		return true, nil
+	}
+	authorized, err := coachRepo.IsAuthorized(ownerID, viewerID)
+	if err != nil {
+		return false, domainErrors.NewAppError(500, "DB_GET_COACH_ACCESS_FAILED", "Failed to verify coach access", err)
+	}
+	return authorized, nil
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"time"
+
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
@@ -8,63 +10,66 @@ import (
 )
 
 type FavoriteUsecase struct {
-	repo repositories.FavoritaRepository
-}
-
-func NewFavoriteUsecase(repo repositories.FavoritaRepository) *FavoriteUsecase {
-	return &FavoriteUsecase{repo}
+	repo       repositories.FavoritaRepository
+	rutinaRepo repositories.RutinaRepository
 }
 
-func (uc *FavoriteUsecase) GetAll() ([]models.Favorita, error) {
-	favoritas, err := uc.repo.GetAll()
-	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_FAVORITES_FAILED", "Failed to get all favorites from database", err)
+func NewFavoriteUsecase(repo repositories.FavoritaRepository, rutinaRepo repositories.RutinaRepository) *FavoriteUsecase {
+	return &FavoriteUsecase{
+		repo:       repo,
+		rutinaRepo: rutinaRepo,
 	}
-	return favoritas, nil
 }
 
-func (uc *FavoriteUsecase) GetByID(id uint) (*models.Favorita, error) {
-	favorita, err := uc.repo.GetByID(id)
+// AddFavorite marks a routine as favorite for the user. It is idempotent and
+// reports whether the favorite was newly created.
+func (uc *FavoriteUsecase) AddFavorite(userID, rutinaID uint) (*models.Favorita, bool, error) {
+	rutina, err := uc.rutinaRepo.GetByID(rutinaID)
 	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
-			return nil, domainErrors.ErrNotFound
+			return nil, false, domainErrors.ErrNotFound
 		}
-		return nil, domainErrors.NewAppError(500, "DB_GET_FAVORITE_FAILED", "Failed to get favorite from database", err)
+		return nil, false, domainErrors.NewAppError(500, "DB_GET_ROUTINE_FAILED", "Failed to get routine from database", err)
 	}
-	return favorita, nil
-}
 
-func (uc *FavoriteUsecase) Create(favorita *models.Favorita) error {
-	if err := uc.repo.Create(favorita); err != nil {
-		return domainErrors.NewAppError(500, "DB_CREATE_FAVORITE_FAILED", "Failed to create favorite in database", err)
+	// Private routines can only be favorited by their owner
+	if !rutina.IsVisibleTo(userID) {
+		return nil, false, domainErrors.ErrRoutineNotPublic
 	}
-	return nil
-}
 
-func (uc *FavoriteUsecase) Update(favorita *models.Favorita) error {
-	if _, err := uc.repo.GetByID(favorita.ID); err != nil {
-		if errors.Is(err, domainErrors.ErrNotFound) {
-			return domainErrors.ErrNotFound
-		}
-		return domainErrors.NewAppError(500, "DB_UPDATE_FAVORITE_FAILED", "Failed to verify favorite existence", err)
+	favorita := &models.Favorita{
+		UsuarioID: userID,
+		RutinaID:  rutinaID,
+		Fecha:     time.Now(),
 	}
-	if err := uc.repo.Update(favorita); err != nil {
-		return domainErrors.NewAppError(500, "DB_UPDATE_FAVORITE_FAILED", "Failed to update favorite in database", err)
+	created, err := uc.repo.CreateIfNotExists(favorita)
+	if err != nil {
+		return nil, false, domainErrors.NewAppError(500, "DB_CREATE_FAVORITE_FAILED", "Failed to create favorite in database", err)
 	}
-	return nil
+	if created {
+		return favorita, true, nil
+	}
+
+	existing, err := uc.repo.GetByUserAndRoutine(userID, rutinaID)
+	if err != nil {
+		return nil, false, domainErrors.NewAppError(500, "DB_GET_FAVORITE_FAILED", "Failed to get favorite from database", err)
+	}
+	return existing, false, nil
 }
 
-func (uc *FavoriteUsecase) Delete(id uint) error {
-	if err := uc.repo.Delete(id); err != nil {
+// RemoveFavorite unmarks a routine as favorite for the user. It is idempotent.
+func (uc *FavoriteUsecase) RemoveFavorite(userID, rutinaID uint) error {
+	if err := uc.repo.DeleteByUserAndRoutine(userID, rutinaID); err != nil {
 		return domainErrors.NewAppError(500, "DB_DELETE_FAVORITE_FAILED", "Failed to delete favorite from database", err)
 	}
 	return nil
 }
 
-func (uc *FavoriteUsecase) GetByUserID(userID uint) ([]models.Favorita, error) {
-	favoritas, err := uc.repo.GetFavoritasByUsuarioID(userID)
+// GetByUserID returns a page of the user's favorites
+func (uc *FavoriteUsecase) GetByUserID(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.Favorita], error) {
+	favoritas, err := uc.repo.GetFavoritasByUsuarioID(userID, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_FAVORITES_BY_USER_FAILED", "Failed to get favorites by user from database", err)
+		return nil, listFailed(err, "DB_GET_FAVORITES_BY_USER_FAILED", "Failed to get favorites by user from database")
 	}
 	return favoritas, nil
 }
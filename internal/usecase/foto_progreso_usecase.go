@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/Diegonr1791/GymBro/internal/media"
+	"github.com/pkg/errors"
+)
+
+// photoContentTypes are the formats accepted for progress photos and the extension of their stored file
+var photoContentTypes = map[string]string{"image/jpeg": ".jpg", "image/png": ".png"}
+
+// ProgressPhotoUsecase manages the progress photos attached to measurements. Photos are private:
+// only the owner can upload or delete them, and only the owner and their authorized coaches can see them.
+type ProgressPhotoUsecase struct {
+	photoRepo       repositories.ProgressPhotoRepository
+	measurementRepo repositories.MedicionRepository
+	coachRepo       repositories.CoachAccessRepository
+	blobStore       repositories.BlobStore
+	mediaLimits     MediaLimits
+}
+
+func NewProgressPhotoUsecase(photoRepo repositories.ProgressPhotoRepository, measurementRepo repositories.MedicionRepository, coachRepo repositories.CoachAccessRepository, blobStore repositories.BlobStore, mediaLimits MediaLimits) *ProgressPhotoUsecase {
+	return &ProgressPhotoUsecase{photoRepo, measurementRepo, coachRepo, blobStore, mediaLimits}
+}
+
+// GetPhotos returns a page of the photos of a measurement visible to the viewer
+func (uc *ProgressPhotoUsecase) GetPhotos(medicionID, viewerID uint, spec repositories.QuerySpec) (*repositories.Page[models.FotoProgreso], error) {
+	if _, err := uc.getMeasurement(medicionID, viewerID, false); err != nil {
+		return nil, err
+	}
+	fotos, err := uc.photoRepo.GetPageByMeasurement(medicionID, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_PROGRESS_PHOTOS_FAILED", "Failed to get progress photos from database")
+	}
+	return fotos, nil
+}
+
+// UploadPhoto stores a photo of the measurement for the pose, replacing the previous one. The image is
+// re-encoded without its EXIF metadata and a thumbnail is generated.
+func (uc *ProgressPhotoUsecase) UploadPhoto(medicionID, userID uint, pose string, content io.Reader, size int64) (*models.FotoProgreso, error) {
+	if !models.IsValidPose(pose) {
+		return nil, domainErrors.NewAppError(400, "INVALID_POSE", "Pose must be one of: front, side, back", nil)
+	}
+	medicion, err := uc.getMeasurement(medicionID, userID, true)
+	if err != nil {
+		return nil, err
+	}
+	if size > uc.mediaLimits.MaxImageBytes {
+		return nil, fileTooLargeError(uc.mediaLimits.MaxImageBytes)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, uc.mediaLimits.MaxImageBytes+1))
+	if err != nil {
+		return nil, domainErrors.NewAppError(400, "INVALID_UPLOAD", "Failed to read uploaded file", err)
+	}
+	if int64(len(data)) > uc.mediaLimits.MaxImageBytes {
+		return nil, fileTooLargeError(uc.mediaLimits.MaxImageBytes)
+	}
+	if _, ok := photoContentTypes[http.DetectContentType(data)]; !ok {
+		return nil, domainErrors.NewAppError(http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE", "Progress photos must be JPEG or PNG", nil)
+	}
+
+	// Location, device and timestamps in the metadata must never reach the blob store
+	clean, contentType, err := media.StripMetadata(data)
+	if err != nil {
+		if errors.Is(err, media.ErrImageTooLarge) {
+			return nil, domainErrors.NewAppError(400, "IMAGE_TOO_LARGE", fmt.Sprintf("Images must not exceed %d megapixels", media.MaxPixels/1_000_000), nil)
+		}
+		return nil, domainErrors.NewAppError(400, "INVALID_IMAGE", "The uploaded image could not be processed", err)
+	}
+	thumbnail, err := media.Thumbnail(bytes.NewReader(clean), media.ThumbnailSize)
+	if err != nil {
+		return nil, domainErrors.NewAppError(400, "INVALID_IMAGE", "The uploaded image could not be processed", err)
+	}
+
+	stamp := time.Now().UnixNano()
+	prefix := fmt.Sprintf("progress-photos/%d/%d", medicion.UsuarioID, medicion.ID)
+	imageKey := fmt.Sprintf("%s/%s-%d%s", prefix, pose, stamp, photoContentTypes[contentType])
+	thumbKey := fmt.Sprintf("%s/%s-thumb-%d.jpg", prefix, pose, stamp)
+
+	if err := uc.blobStore.Put(imageKey, bytes.NewReader(clean), contentType); err != nil {
+		return nil, domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to store uploaded photo", err)
+	}
+	if err := uc.blobStore.Put(thumbKey, bytes.NewReader(thumbnail), "image/jpeg"); err != nil {
+		deleteBlobs(uc.blobStore, imageKey)
+		return nil, domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to store photo thumbnail", err)
+	}
+
+	existentes, err := uc.photoRepo.GetByMeasurement(medicion.ID)
+	if err != nil {
+		deleteBlobs(uc.blobStore, imageKey, thumbKey)
+		return nil, domainErrors.NewAppError(500, "DB_GET_PROGRESS_PHOTOS_FAILED", "Failed to get progress photos from database", err)
+	}
+
+	foto := &models.FotoProgreso{
+		MedicionID:   medicion.ID,
+		UsuarioID:    medicion.UsuarioID,
+		Pose:         pose,
+		ContentType:  contentType,
+		ImagenKey:    imageKey,
+		MiniaturaKey: thumbKey,
+	}
+	if err := uc.photoRepo.Create(foto); err != nil {
+		deleteBlobs(uc.blobStore, imageKey, thumbKey)
+		return nil, domainErrors.NewAppError(500, "DB_CREATE_PROGRESS_PHOTO_FAILED", "Failed to create progress photo in database", err)
+	}
+
+	// A measurement keeps a single photo per pose
+	for _, anterior := range existentes {
+		if anterior.Pose != pose {
+			continue
+		}
+		if err := uc.photoRepo.Delete(anterior.ID); err != nil {
+			return nil, domainErrors.NewAppError(500, "DB_DELETE_PROGRESS_PHOTO_FAILED", "Failed to delete previous progress photo from database", err)
+		}
+		deleteBlobs(uc.blobStore, anterior.ImagenKey, anterior.MiniaturaKey)
+	}
+	return foto, nil
+}
+
+// OpenPhoto opens the image or the thumbnail of a photo visible to the viewer
+func (uc *ProgressPhotoUsecase) OpenPhoto(medicionID, photoID, viewerID uint, thumbnail bool) (io.ReadCloser, string, error) {
+	foto, err := uc.getPhoto(medicionID, photoID, viewerID, false)
+	if err != nil {
+		return nil, "", err
+	}
+	key := foto.ImagenKey
+	if thumbnail {
+		key = foto.MiniaturaKey
+	}
+
+	content, contentType, err := uc.blobStore.Get(key)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, "", appErr
+		}
+		return nil, "", domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to read stored photo", err)
+	}
+	return content, contentType, nil
+}
+
+// DeletePhoto removes a photo of the user's measurement and its stored files
+func (uc *ProgressPhotoUsecase) DeletePhoto(medicionID, photoID, userID uint) error {
+	foto, err := uc.getPhoto(medicionID, photoID, userID, true)
+	if err != nil {
+		return err
+	}
+	if err := uc.photoRepo.Delete(foto.ID); err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_PROGRESS_PHOTO_FAILED", "Failed to delete progress photo from database", err)
+	}
+	deleteBlobs(uc.blobStore, foto.ImagenKey, foto.MiniaturaKey)
+	return nil
+}
+
+// getMeasurement loads a measurement the viewer can see; modifying it requires being its owner.
+// Measurements of other users are reported as not found so their existence is not revealed.
+func (uc *ProgressPhotoUsecase) getMeasurement(medicionID, viewerID uint, modify bool) (*models.Medicion, error) {
+	medicion, err := uc.measurementRepo.GetByID(medicionID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_MEASUREMENT_FAILED", "Failed to get measurement from database", err)
+	}
+
+	allowed, err := canView(uc.coachRepo, medicion.UsuarioID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domainErrors.ErrNotFound
+	}
+	if modify && medicion.UsuarioID != viewerID {
+		return nil, domainErrors.NewAppError(403, "PROGRESS_PHOTOS_READ_ONLY", "Only the owner can modify progress photos", nil)
+	}
+	return medicion, nil
+}
+
+// getPhoto loads a photo of the measurement checking the viewer's access
+func (uc *ProgressPhotoUsecase) getPhoto(medicionID, photoID, viewerID uint, modify bool) (*models.FotoProgreso, error) {
+	if _, err := uc.getMeasurement(medicionID, viewerID, modify); err != nil {
+		return nil, err
+	}
+	foto, err := uc.photoRepo.GetByID(photoID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_PROGRESS_PHOTO_FAILED", "Failed to get progress photo from database", err)
+	}
+	if foto.MedicionID != medicionID {
+		return nil, domainErrors.ErrNotFound
+	}
+	return foto, nil
+}
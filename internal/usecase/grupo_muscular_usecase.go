@@ -8,11 +8,13 @@ import (
 )
 
 type GrupoMuscularUseCase struct {
-	repo repositories.GrupoMuscularRepository
+	repo      repositories.GrupoMuscularRepository
+	roleRepo  repositories.RoleRepository
+	blobStore repositories.BlobStore
 }
 
-func NewGrupoMuscularUseCase(repo repositories.GrupoMuscularRepository) *GrupoMuscularUseCase {
-	return &GrupoMuscularUseCase{repo}
+func NewGrupoMuscularUseCase(repo repositories.GrupoMuscularRepository, roleRepo repositories.RoleRepository, blobStore repositories.BlobStore) *GrupoMuscularUseCase {
+	return &GrupoMuscularUseCase{repo, roleRepo, blobStore}
 }
 
 func (uc *GrupoMuscularUseCase) CreateMuscleGroup(g *models.GrupoMuscular) error {
@@ -22,10 +24,10 @@ func (uc *GrupoMuscularUseCase) CreateMuscleGroup(g *models.GrupoMuscular) error
 	return nil
 }
 
-func (uc *GrupoMuscularUseCase) GetAllMuscleGroups() ([]models.GrupoMuscular, error) {
-	grupos, err := uc.repo.GetAll()
+func (uc *GrupoMuscularUseCase) GetAllMuscleGroups(spec repositories.QuerySpec) (*repositories.Page[models.GrupoMuscular], error) {
+	grupos, err := uc.repo.List(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_MUSCLE_GROUPS_FAILED", "Failed to get all muscle groups from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_MUSCLE_GROUPS_FAILED", "Failed to get all muscle groups from database")
 	}
 	return grupos, nil
 }
@@ -56,9 +58,38 @@ func (uc *GrupoMuscularUseCase) UpdateMuscleGroup(g *models.GrupoMuscular) error
 	return nil
 }
 
-func (uc *GrupoMuscularUseCase) DeleteMuscleGroup(id uint) error {
-	if err := uc.repo.Delete(id); err != nil {
-		return domainErrors.NewAppError(500, "DB_DELETE_MUSCLE_GROUP_FAILED", "Failed to delete muscle group from database", err)
+// DeleteMuscleGroup deletes a muscle group; referenced groups require an admin to cascade or reassign the dependents
+func (uc *GrupoMuscularUseCase) DeleteMuscleGroup(id, roleID uint, opts repositories.DeleteOptions) error {
+	if err := checkDeleteOptions(uc.roleRepo, id, roleID, opts); err != nil {
+		return err
 	}
+	if _, err := uc.GetMuscleGroupByID(id); err != nil {
+		return err
+	}
+	if opts.ReassignTo != 0 {
+		if _, err := uc.repo.GetByID(opts.ReassignTo); err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewAppError(400, "INVALID_REASSIGN_TARGET", "reassign_to must be an existing muscle group", nil)
+			}
+			return domainErrors.NewAppError(500, "DB_GET_MUSCLE_GROUP_FAILED", "Failed to get muscle group from database", err)
+		}
+	}
+
+	dependents, err := uc.repo.CountDependents(id)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_MUSCLE_GROUP_FAILED", "Failed to count muscle group dependents", err)
+	}
+	if err := checkDependents("muscle group", dependents, opts); err != nil {
+		return err
+	}
+	if err := checkCascade("muscle group", dependents, opts); err != nil {
+		return err
+	}
+
+	mediaKeys, err := uc.repo.DeleteWith(id, opts)
+	if err != nil {
+		return deletionError("DB_DELETE_MUSCLE_GROUP_FAILED", "muscle group", err)
+	}
+	deleteBlobs(uc.blobStore, mediaKeys...)
 	return nil
 }
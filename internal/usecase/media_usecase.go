@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"io"
+	"log/slog"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// MediaUsecase sirve los archivos almacenados en el blob store
+type MediaUsecase struct {
+	blobStore repositories.BlobStore
+}
+
+func NewMediaUsecase(blobStore repositories.BlobStore) *MediaUsecase {
+	return &MediaUsecase{blobStore}
+}
+
+// Open abre un archivo almacenado y devuelve su content type
+func (uc *MediaUsecase) Open(key string) (io.ReadCloser, string, error) {
+	content, contentType, err := uc.blobStore.Get(key)
+	if err != nil {
+		var appErr *domainErrors.AppError
+		if errors.As(err, &appErr) {
+			return nil, "", appErr
+		}
+		return nil, "", domainErrors.NewAppError(500, "BLOB_STORE_FAILED", "Failed to read stored media", err)
+	}
+	return content, contentType, nil
+}
+
+// deleteBlobs elimina archivos almacenados sin interrumpir la operación si alguno falla
+func deleteBlobs(blobStore repositories.BlobStore, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := blobStore.Delete(key); err != nil {
+			slog.Warn("Failed to delete stored media", "key", key, "error", err)
+		}
+	}
+}
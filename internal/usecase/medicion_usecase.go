@@ -1,24 +1,38 @@
 package usecase
 
 import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
 	"github.com/pkg/errors"
 )
 
+// MeasurementUsecase manages the body measurements. Values are stored per metric; the legacy weight,
+// body fat and muscle fields are kept in sync with their standard metrics so older clients keep working.
 type MeasurementUsecase struct {
-	repo repositories.MedicionRepository
+	repo       repositories.MedicionRepository
+	metricRepo repositories.MeasurementMetricRepository
+	userRepo   repositories.UsuarioRepository
+	photoRepo  repositories.ProgressPhotoRepository
+	blobStore  repositories.BlobStore
 }
 
-func NewMeasurementUsecase(repo repositories.MedicionRepository) *MeasurementUsecase {
-	return &MeasurementUsecase{repo}
+func NewMeasurementUsecase(repo repositories.MedicionRepository, metricRepo repositories.MeasurementMetricRepository, userRepo repositories.UsuarioRepository, photoRepo repositories.ProgressPhotoRepository, blobStore repositories.BlobStore) *MeasurementUsecase {
+	return &MeasurementUsecase{repo, metricRepo, userRepo, photoRepo, blobStore}
 }
 
-func (uc *MeasurementUsecase) GetAll() ([]models.Medicion, error) {
-	mediciones, err := uc.repo.GetAll()
+func (uc *MeasurementUsecase) GetAll(spec repositories.QuerySpec) (*repositories.Page[models.Medicion], error) {
+	mediciones, err := uc.repo.GetAll(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_MEASUREMENTS_FAILED", "Failed to get all measurements from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_MEASUREMENTS_FAILED", "Failed to get all measurements from database")
+	}
+	if err := uc.prepare(mediciones.Items); err != nil {
+		return nil, err
 	}
 	return mediciones, nil
 }
@@ -31,40 +45,422 @@ func (uc *MeasurementUsecase) GetByID(id uint) (*models.Medicion, error) {
 		}
 		return nil, domainErrors.NewAppError(500, "DB_GET_MEASUREMENT_FAILED", "Failed to get measurement from database", err)
 	}
-	return medicion, nil
+	mediciones := []models.Medicion{*medicion}
+	if err := uc.prepare(mediciones); err != nil {
+		return nil, err
+	}
+	return &mediciones[0], nil
 }
 
 func (uc *MeasurementUsecase) Create(medicion *models.Medicion) error {
+	metricas, err := uc.syncValues(medicion)
+	if err != nil {
+		return err
+	}
 	if err := uc.repo.Create(medicion); err != nil {
 		return domainErrors.NewAppError(500, "DB_CREATE_MEASUREMENT_FAILED", "Failed to create measurement in database", err)
 	}
+	attachMetrics(medicion, metricas)
+	medicion.ComputeComposition(uc.heightOf(medicion.UsuarioID))
 	return nil
 }
 
 func (uc *MeasurementUsecase) Update(medicion *models.Medicion) error {
-	if _, err := uc.repo.GetByID(medicion.ID); err != nil {
+	existing, err := uc.repo.GetByID(medicion.ID)
+	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
 			return domainErrors.ErrNotFound
 		}
 		return domainErrors.NewAppError(500, "DB_UPDATE_MEASUREMENT_FAILED", "Failed to verify measurement existence", err)
 	}
+	// The owner never changes
+	medicion.UsuarioID = existing.UsuarioID
+
+	metricas, err := uc.syncValues(medicion)
+	if err != nil {
+		return err
+	}
 	if err := uc.repo.Update(medicion); err != nil {
 		return domainErrors.NewAppError(500, "DB_UPDATE_MEASUREMENT_FAILED", "Failed to update measurement in database", err)
 	}
+	attachMetrics(medicion, metricas)
+	medicion.ComputeComposition(uc.heightOf(medicion.UsuarioID))
 	return nil
 }
 
+// Patch applies a JSON merge patch to a measurement. A patched legacy field replaces the value of its
+// standard metric unless the patch also sets the values, which are replaced as a whole.
+func (uc *MeasurementUsecase) Patch(id uint, patch MergePatch) (*models.Medicion, error) {
+	medicion, err := uc.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_MEASUREMENT_FAILED", "Failed to verify measurement existence", err)
+	}
+	previous := *medicion
+
+	changed, err := applyMergePatch(medicion, patch, "fecha", "peso_corporal", "grasa_corporal", "musculo", "valores")
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return uc.GetByID(id)
+	}
+
+	// The standard metrics share their key with the legacy field, which is also its column
+	if !patch.Has("valores") {
+		for _, f := range legacyFields(medicion) {
+			if patch.Has(f.clave) {
+				medicion.Valores = slices.DeleteFunc(medicion.Valores, func(v models.ValorMedicion) bool {
+					return v.Metrica != nil && v.Metrica.Clave == f.clave
+				})
+			}
+		}
+	}
+	metricas, err := uc.syncValues(medicion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Syncing the values can change the legacy fields, so they are compared after it
+	var columns []string
+	if slices.Contains(changed, "fecha") {
+		columns = append(columns, "fecha")
+	}
+	valuesChanged := patch.Has("valores")
+	before := legacyFields(&previous)
+	for i, f := range legacyFields(medicion) {
+		if *f.value != *before[i].value {
+			columns = append(columns, f.clave)
+		}
+		valuesChanged = valuesChanged || patch.Has(f.clave)
+	}
+	if valuesChanged {
+		columns = append(columns, "valores")
+	}
+
+	if err := uc.repo.Patch(medicion, columns); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_MEASUREMENT_FAILED", "Failed to update measurement in database", err)
+	}
+	attachMetrics(medicion, metricas)
+	medicion.ComputeComposition(uc.heightOf(medicion.UsuarioID))
+	return medicion, nil
+}
+
+// Delete removes the measurement with its values and progress photos
 func (uc *MeasurementUsecase) Delete(id uint) error {
+	fotos, err := uc.photoRepo.GetByMeasurement(id)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_GET_PROGRESS_PHOTOS_FAILED", "Failed to get progress photos from database", err)
+	}
 	if err := uc.repo.Delete(id); err != nil {
 		return domainErrors.NewAppError(500, "DB_DELETE_MEASUREMENT_FAILED", "Failed to delete measurement from database", err)
 	}
+	for _, foto := range fotos {
+		deleteBlobs(uc.blobStore, foto.ImagenKey, foto.MiniaturaKey)
+	}
 	return nil
 }
 
-func (uc *MeasurementUsecase) GetByUserID(userID uint) ([]models.Medicion, error) {
-	mediciones, err := uc.repo.GetMesurementsByUserID(userID)
+func (uc *MeasurementUsecase) GetByUserID(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.Medicion], error) {
+	mediciones, err := uc.repo.GetPageByUser(userID, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_MEASUREMENTS_BY_USER_FAILED", "Failed to get measurements by user from database", err)
+		return nil, listFailed(err, "DB_GET_MEASUREMENTS_BY_USER_FAILED", "Failed to get measurements by user from database")
+	}
+	if err := uc.prepare(mediciones.Items); err != nil {
+		return nil, err
 	}
 	return mediciones, nil
 }
+
+// GetSummary summarizes the user's body composition between two dates, both inclusive, with the weight
+// smoothed by a moving average and the weekly trend of every metric. Dates default to the last six months.
+func (uc *MeasurementUsecase) GetSummary(userID uint, from, to *time.Time) (*models.ResumenMediciones, error) {
+	desde, hasta, err := analyticsRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Earlier measurements warm up the moving average of the first days
+	mediciones, err := uc.repo.GetByUserAndRange(userID, desde.AddDate(0, 0, -models.VentanaMediaMovil), hasta)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_MEASUREMENTS_BY_USER_FAILED", "Failed to get measurements by user from database", err)
+	}
+	return models.SummarizeMeasurements(mediciones, desde, hasta, uc.heightOf(userID)), nil
+}
+
+// GetMetrics returns a page of the standard metrics and the user's custom ones
+func (uc *MeasurementUsecase) GetMetrics(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.MetricaMedicion], error) {
+	if _, err := uc.standardMetrics(); err != nil {
+		return nil, err
+	}
+	metricas, err := uc.metricRepo.GetPageAvailable(userID, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_METRICS_FAILED", "Failed to get measurement metrics from database")
+	}
+	return metricas, nil
+}
+
+// GetAllMetrics returns every metric available to the user, the standard ones first
+func (uc *MeasurementUsecase) GetAllMetrics(userID uint) ([]models.MetricaMedicion, error) {
+	if _, err := uc.standardMetrics(); err != nil {
+		return nil, err
+	}
+	metricas, err := uc.metricRepo.GetAvailable(userID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_METRICS_FAILED", "Failed to get measurement metrics from database", err)
+	}
+	return metricas, nil
+}
+
+// CreateMetric creates a custom metric for the user. The key defaults to the name in snake case.
+func (uc *MeasurementUsecase) CreateMetric(userID uint, metrica *models.MetricaMedicion) error {
+	metrica.Nombre = strings.TrimSpace(metrica.Nombre)
+	metrica.Unidad = strings.TrimSpace(metrica.Unidad)
+	if metrica.Nombre == "" || len(metrica.Nombre) > 100 {
+		return domainErrors.NewAppError(400, "METRIC_NAME_REQUIRED", "Metric name is required and must not exceed 100 characters", nil)
+	}
+	if metrica.Unidad == "" || len(metrica.Unidad) > 16 {
+		return domainErrors.NewAppError(400, "METRIC_UNIT_REQUIRED", "Metric unit is required and must not exceed 16 characters", nil)
+	}
+	// Values are stored in canonical units, so imperial units define the metric in their metric counterpart
+	switch metrica.Unidad {
+	case models.UnidadLb:
+		metrica.Unidad = models.UnidadKg
+	case models.UnidadIn:
+		metrica.Unidad = models.UnidadCm
+	}
+	if metrica.Clave == "" {
+		metrica.Clave = metrica.Nombre
+	}
+	metrica.Clave = metricKey(metrica.Clave)
+	if metrica.Clave == "" {
+		return domainErrors.NewAppError(400, "INVALID_METRIC_KEY", "Metric key must contain letters or numbers", nil)
+	}
+
+	if _, err := uc.standardMetrics(); err != nil {
+		return err
+	}
+	_, err := uc.metricRepo.GetByKey(userID, metrica.Clave)
+	if err == nil {
+		return domainErrors.NewAppError(409, "METRIC_KEY_TAKEN", "A metric with the same key already exists", nil)
+	}
+	if !errors.Is(err, domainErrors.ErrNotFound) {
+		return domainErrors.NewAppError(500, "DB_GET_METRIC_FAILED", "Failed to get measurement metric from database", err)
+	}
+
+	metrica.ID = 0
+	metrica.UsuarioID = &userID
+	if err := uc.metricRepo.Create(metrica); err != nil {
+		return domainErrors.NewAppError(500, "DB_CREATE_METRIC_FAILED", "Failed to create measurement metric in database", err)
+	}
+	return nil
+}
+
+// DeleteMetric deletes a custom metric of the user along with its recorded values
+func (uc *MeasurementUsecase) DeleteMetric(userID, id uint) error {
+	metrica, err := uc.metricRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.ErrNotFound
+		}
+		return domainErrors.NewAppError(500, "DB_GET_METRIC_FAILED", "Failed to get measurement metric from database", err)
+	}
+	if metrica.IsStandard() {
+		return domainErrors.NewAppError(400, "STANDARD_METRIC_NOT_DELETABLE", "Standard metrics cannot be deleted", nil)
+	}
+	if !metrica.IsVisibleTo(userID) {
+		return domainErrors.ErrNotFound
+	}
+	if err := uc.metricRepo.Delete(id); err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_METRIC_FAILED", "Failed to delete measurement metric from database", err)
+	}
+	return nil
+}
+
+// legacyField is a field of Medicion that predates the metric values
+type legacyField struct {
+	clave string
+	value *float32
+}
+
+func legacyFields(m *models.Medicion) []legacyField {
+	return []legacyField{
+		{models.MetricaPesoCorporal, &m.PesoCorporal},
+		{models.MetricaGrasaCorporal, &m.GrasaCorporal},
+		{models.MetricaMusculo, &m.Musculo},
+	}
+}
+
+// syncValues resolves the metric of every value and keeps the legacy fields in sync with their standard
+// metrics: explicit values win, and legacy fields without a value become one. It returns the resolved metrics by ID.
+func (uc *MeasurementUsecase) syncValues(m *models.Medicion) (map[uint]*models.MetricaMedicion, error) {
+	standard, err := uc.standardMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	metricas := map[uint]*models.MetricaMedicion{}
+	byKey := map[string]int{}
+	for i := range m.Valores {
+		v := &m.Valores[i]
+		if v.Valor < 0 {
+			return nil, domainErrors.NewAppError(400, "INVALID_METRIC_VALUE", "Metric values must not be negative", nil)
+		}
+		metrica, err := uc.resolveMetric(m.UsuarioID, v, standard)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := metricas[metrica.ID]; ok {
+			return nil, domainErrors.NewAppError(400, "DUPLICATE_METRIC_VALUE", "Each metric can only have one value per measurement", nil)
+		}
+		metricas[metrica.ID] = metrica
+		byKey[metrica.Clave] = i
+		// The definition is attached again after saving so it is not written through the association
+		v.MetricaID, v.Clave, v.Metrica = metrica.ID, metrica.Clave, nil
+	}
+
+	for _, f := range legacyFields(m) {
+		if i, ok := byKey[f.clave]; ok {
+			*f.value = float32(m.Valores[i].Valor)
+			continue
+		}
+		if *f.value == 0 {
+			continue
+		}
+		metrica := standard[f.clave]
+		metricas[metrica.ID] = metrica
+		m.Valores = append(m.Valores, models.ValorMedicion{MetricaID: metrica.ID, Clave: metrica.Clave, Valor: float64(*f.value)})
+	}
+	return metricas, nil
+}
+
+// resolveMetric finds the metric a value references by ID or key, which must be visible to the user
+func (uc *MeasurementUsecase) resolveMetric(userID uint, v *models.ValorMedicion, standard map[string]*models.MetricaMedicion) (*models.MetricaMedicion, error) {
+	invalid := domainErrors.NewAppError(400, "INVALID_METRIC", "Measurement value references an unknown metric", nil)
+	var (
+		metrica *models.MetricaMedicion
+		err     error
+	)
+	switch {
+	case v.MetricaID != 0:
+		metrica, err = uc.metricRepo.GetByID(v.MetricaID)
+	case standard[v.Clave] != nil:
+		return standard[v.Clave], nil
+	case v.Clave != "":
+		metrica, err = uc.metricRepo.GetByKey(userID, v.Clave)
+	default:
+		return nil, invalid
+	}
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, invalid
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_METRIC_FAILED", "Failed to get measurement metric from database", err)
+	}
+	if !metrica.IsVisibleTo(userID) {
+		return nil, invalid
+	}
+	return metrica, nil
+}
+
+// standardMetrics returns the standard metrics by key, creating the missing ones
+func (uc *MeasurementUsecase) standardMetrics() (map[string]*models.MetricaMedicion, error) {
+	existentes, err := uc.metricRepo.GetStandard()
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_METRICS_FAILED", "Failed to get measurement metrics from database", err)
+	}
+	metricas := make(map[string]*models.MetricaMedicion, len(models.MetricasEstandar))
+	for i := range existentes {
+		metricas[existentes[i].Clave] = &existentes[i]
+	}
+	for _, estandar := range models.MetricasEstandar {
+		if _, ok := metricas[estandar.Clave]; ok {
+			continue
+		}
+		metrica := estandar
+		if err := uc.metricRepo.Create(&metrica); err != nil {
+			return nil, domainErrors.NewAppError(500, "DB_CREATE_METRIC_FAILED", "Failed to create measurement metric in database", err)
+		}
+		metricas[metrica.Clave] = &metrica
+	}
+	return metricas, nil
+}
+
+// attachMetrics sets the definitions of the values after saving the measurement
+func attachMetrics(m *models.Medicion, metricas map[uint]*models.MetricaMedicion) {
+	for i := range m.Valores {
+		m.Valores[i].Metrica = metricas[m.Valores[i].MetricaID]
+	}
+}
+
+// prepare completes the measurements read from the database: legacy fields of entries recorded before
+// the metric values are exposed as values, and the body composition is computed with the user's height
+func (uc *MeasurementUsecase) prepare(mediciones []models.Medicion) error {
+	standard, err := uc.standardMetrics()
+	if err != nil {
+		return err
+	}
+	heights := map[uint]float64{}
+	for i := range mediciones {
+		m := &mediciones[i]
+		present := map[string]bool{}
+		for j := range m.Valores {
+			if m.Valores[j].Metrica != nil {
+				m.Valores[j].Clave = m.Valores[j].Metrica.Clave
+				present[m.Valores[j].Clave] = true
+			}
+		}
+		for _, f := range legacyFields(m) {
+			if *f.value == 0 || present[f.clave] {
+				continue
+			}
+			metrica := standard[f.clave]
+			m.Valores = append(m.Valores, models.ValorMedicion{
+				MedicionID: m.ID,
+				MetricaID:  metrica.ID,
+				Clave:      metrica.Clave,
+				Valor:      float64(*f.value),
+				Metrica:    metrica,
+			})
+		}
+
+		altura, ok := heights[m.UsuarioID]
+		if !ok {
+			altura = uc.heightOf(m.UsuarioID)
+			heights[m.UsuarioID] = altura
+		}
+		m.ComputeComposition(altura)
+	}
+	return nil
+}
+
+// metricKey turns a name into a snake case key
+func metricKey(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore && b.Len() > 0 {
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+	key := []rune(strings.TrimSuffix(b.String(), "_"))
+	if len(key) > 64 {
+		key = key[:64]
+	}
+	return strings.TrimSuffix(string(key), "_")
+}
+
+// heightOf returns the height on the user's profile; unknown users or heights return zero
+func (uc *MeasurementUsecase) heightOf(userID uint) float64 {
+	usuario, err := uc.userRepo.GetByID(userID)
+	if err != nil {
+		return 0
+	}
+	return usuario.AlturaCm
+}
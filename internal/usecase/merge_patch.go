@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+)
+
+// MergePatch is an RFC 7396 JSON merge patch: members set to a value replace the current value,
+// members set to null clear it and absent members are left untouched. Arrays are replaced as a whole.
+type MergePatch map[string]json.RawMessage
+
+// ParseMergePatch parses a merge patch document, which must be a JSON object
+func ParseMergePatch(data []byte) (MergePatch, error) {
+	var patch MergePatch
+	if err := json.Unmarshal(data, &patch); err != nil || patch == nil {
+		return nil, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_MERGE_PATCH", "The body must be a JSON merge patch object", err)
+	}
+	return patch, nil
+}
+
+// Has reports whether the patch sets or clears the member
+func (p MergePatch) Has(field string) bool {
+	_, ok := p[field]
+	return ok
+}
+
+// Clears reports whether the patch sets the member to null
+func (p MergePatch) Clears(field string) bool {
+	raw, ok := p[field]
+	return ok && bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
+
+// applyMergePatch applies the patch to target, a pointer to a model, and returns the JSON names of the
+// fields whose value changed in the order of patchable. Members outside patchable are rejected.
+func applyMergePatch(target any, patch MergePatch, patchable ...string) ([]string, error) {
+	var readonly []domainErrors.FieldError
+	for field := range patch {
+		if !slices.Contains(patchable, field) {
+			readonly = append(readonly, domainErrors.FieldError{Field: field, Rule: "readonly", Message: field + " cannot be changed"})
+		}
+	}
+	if len(readonly) > 0 {
+		sort.Slice(readonly, func(i, j int) bool { return readonly[i].Field < readonly[j].Field })
+		return nil, domainErrors.ErrValidation.WithDetails(readonly)
+	}
+
+	v := reflect.ValueOf(target).Elem()
+	fields := jsonFields(v.Type())
+	var changed []string
+	for _, name := range patchable {
+		if !patch.Has(name) {
+			continue
+		}
+		field := v.FieldByIndex(fields[name])
+		value := reflect.New(field.Type())
+		if !patch.Clears(name) {
+			if err := json.Unmarshal(patch[name], value.Interface()); err != nil {
+				return nil, domainErrors.NewAppError(http.StatusBadRequest, "INVALID_JSON", "Invalid JSON body", err)
+			}
+		}
+		if !sameValue(field.Interface(), value.Elem().Interface()) {
+			field.Set(value.Elem())
+			changed = append(changed, name)
+		}
+	}
+	return changed, nil
+}
+
+// jsonFields maps the JSON names of the fields of a struct type to their index
+func jsonFields(t reflect.Type) map[string][]int {
+	fields := make(map[string][]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = f.Index
+	}
+	return fields
+}
+
+// sameValue compares two field values; times are equal when they denote the same instant
+func sameValue(a, b any) bool {
+	if ta, ok := a.(time.Time); ok {
+		return ta.Equal(b.(time.Time))
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"log/slog"
+	"math"
+	"sort"
+	"time"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// goalTrendWeeks is the period used to fit the trend of a goal
+const goalTrendWeeks = 8
+
+// GoalUsecase manages the user's goals and tracks them against measurements and sets
+type GoalUsecase struct {
+	goalRepo            repositories.GoalRepository
+	measurementRepo     repositories.MedicionRepository
+	sessionExerciseRepo repositories.SessionExerciseRepository
+	exerciseRepo        repositories.ExerciseRepository
+}
+
+func NewGoalUsecase(goalRepo repositories.GoalRepository, measurementRepo repositories.MedicionRepository, sessionExerciseRepo repositories.SessionExerciseRepository, exerciseRepo repositories.ExerciseRepository) *GoalUsecase {
+	return &GoalUsecase{goalRepo, measurementRepo, sessionExerciseRepo, exerciseRepo}
+}
+
+// GetGoals returns a page of the user's goals with their progress
+func (uc *GoalUsecase) GetGoals(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.Meta], error) {
+	metas, err := uc.goalRepo.GetByUser(userID, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_GOALS_FAILED", "Failed to get goals from database")
+	}
+	for i := range metas.Items {
+		if err := uc.evaluate(&metas.Items[i]); err != nil {
+			return nil, err
+		}
+	}
+	return metas, nil
+}
+
+// GetGoal returns a goal of the user with its progress
+func (uc *GoalUsecase) GetGoal(userID, id uint) (*models.Meta, error) {
+	meta, err := uc.getOwned(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.evaluate(meta); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// CreateGoal sets a goal for the user, taking the current value as its starting point
+func (uc *GoalUsecase) CreateGoal(userID uint, meta *models.Meta) error {
+	switch meta.Tipo {
+	case models.MetaPesoCorporal, models.MetaGrasaCorporal:
+		meta.EjercicioID = nil
+	case models.MetaUnaRepMaxima:
+		if meta.EjercicioID == nil {
+			return domainErrors.NewAppError(400, "GOAL_EXERCISE_REQUIRED", "An exercise is required for one-rep max goals", nil)
+		}
+		ejercicio, err := uc.exerciseRepo.GetById(*meta.EjercicioID)
+		if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.NewAppError(500, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database", err)
+		}
+		if err != nil || !ejercicio.IsVisibleTo(userID) {
+			return domainErrors.ErrInvalidReference
+		}
+	default:
+		return domainErrors.NewAppError(400, "INVALID_GOAL_TYPE", "Goal type must be one of: body_weight, body_fat, exercise_1rm", nil)
+	}
+	if meta.Objetivo <= 0 || (meta.Tipo == models.MetaGrasaCorporal && meta.Objetivo >= 100) {
+		return domainErrors.NewAppError(400, "INVALID_GOAL_TARGET", "Goal target must be a positive value", nil)
+	}
+	if meta.FechaLimite != nil && !meta.FechaLimite.After(time.Now()) {
+		return domainErrors.NewAppError(400, "INVALID_GOAL_DEADLINE", "Goal deadline must be in the future", nil)
+	}
+
+	meta.ID = 0
+	meta.UsuarioID = userID
+	actual, _, err := uc.currentValue(meta)
+	if err != nil {
+		return err
+	}
+	meta.ValorInicial = actual
+	if err := uc.goalRepo.Create(meta); err != nil {
+		return domainErrors.NewAppError(500, "DB_CREATE_GOAL_FAILED", "Failed to create goal in database", err)
+	}
+	return uc.evaluate(meta)
+}
+
+// DeleteGoal deletes a goal of the user
+func (uc *GoalUsecase) DeleteGoal(userID, id uint) error {
+	if _, err := uc.getOwned(userID, id); err != nil {
+		return err
+	}
+	if err := uc.goalRepo.Delete(id); err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_GOAL_FAILED", "Failed to delete goal from database", err)
+	}
+	return nil
+}
+
+func (uc *GoalUsecase) getOwned(userID, id uint) (*models.Meta, error) {
+	meta, err := uc.goalRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_GOAL_FAILED", "Failed to get goal from database", err)
+	}
+	// Goals of other users are reported as missing
+	if meta.UsuarioID != userID {
+		return nil, domainErrors.ErrNotFound
+	}
+	return meta, nil
+}
+
+// evaluate computes the progress of the goal. Goals set before there was any data take the first
+// value available as their starting point.
+func (uc *GoalUsecase) evaluate(meta *models.Meta) error {
+	actual, recientes, err := uc.currentValue(meta)
+	if err != nil {
+		return err
+	}
+	if meta.ValorInicial == nil && actual != nil {
+		meta.ValorInicial = actual
+		if err := uc.goalRepo.Update(meta); err != nil {
+			slog.Warn("failed to store goal starting value", "goalID", meta.ID, "error", err)
+		}
+	}
+	meta.Evaluate(actual, recientes, time.Now().UTC())
+	return nil
+}
+
+// currentValue returns the current value of the goal metric and its points over the trend period
+func (uc *GoalUsecase) currentValue(meta *models.Meta) (*float64, []models.PuntoMeta, error) {
+	now := time.Now().UTC()
+	desde := now.AddDate(0, 0, -7*goalTrendWeeks)
+
+	if meta.Tipo == models.MetaUnaRepMaxima {
+		if meta.EjercicioID == nil {
+			return nil, nil, nil
+		}
+		entries, err := uc.sessionExerciseRepo.GetByUserAndExercise(meta.UsuarioID, *meta.EjercicioID)
+		if err != nil {
+			return nil, nil, domainErrors.NewAppError(500, "DB_GET_SESSION_EXERCISES_FAILED", "Failed to get session exercises from database", err)
+		}
+		// The current value is the best estimate ever; the trend uses the best estimate of each recent session
+		var actual *float64
+		var recientes []models.PuntoMeta
+		sesiones := map[uint]int{}
+		for _, e := range entries {
+			estimate := models.EstimateOneRepMax(e.Peso, e.Repeticiones)
+			if estimate <= 0 {
+				continue
+			}
+			if actual == nil || estimate > *actual {
+				best := estimate
+				actual = &best
+			}
+			if e.Fecha.Before(desde) {
+				continue
+			}
+			if i, ok := sesiones[e.SesionID]; ok {
+				recientes[i].Valor = max(recientes[i].Valor, estimate)
+				continue
+			}
+			sesiones[e.SesionID] = len(recientes)
+			recientes = append(recientes, models.PuntoMeta{Fecha: e.Fecha, Valor: estimate})
+		}
+		return actual, recientes, nil
+	}
+
+	mediciones, err := uc.measurementRepo.GetMesurementsByUserID(meta.UsuarioID)
+	if err != nil {
+		return nil, nil, domainErrors.NewAppError(500, "DB_GET_MEASUREMENTS_BY_USER_FAILED", "Failed to get measurements by user from database", err)
+	}
+	var actual *models.Medicion
+	var recientes []models.PuntoMeta
+	for i := range mediciones {
+		m := &mediciones[i]
+		valor := goalMeasurement(meta.Tipo, m)
+		if valor <= 0 {
+			continue
+		}
+		if actual == nil || m.Fecha.After(actual.Fecha) {
+			actual = m
+		}
+		if !m.Fecha.Before(desde) {
+			recientes = append(recientes, models.PuntoMeta{Fecha: m.Fecha, Valor: valor})
+		}
+	}
+	if actual == nil {
+		return nil, nil, nil
+	}
+	sort.Slice(recientes, func(i, j int) bool { return recientes[i].Fecha.Before(recientes[j].Fecha) })
+	valor := goalMeasurement(meta.Tipo, actual)
+	return &valor, recientes, nil
+}
+
+// goalMeasurement returns the value of the measurement a body goal tracks
+func goalMeasurement(tipo string, m *models.Medicion) float64 {
+	valor := m.PesoCorporal
+	if tipo == models.MetaGrasaCorporal {
+		valor = m.GrasaCorporal
+	}
+	return math.Round(float64(valor)*100) / 100
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// listFailed converts the error of a read driven by a query spec or projection: one the repository cannot apply is a client error
+func listFailed(err error, code, message string) error {
+	var invalid *repositories.InvalidQueryError
+	if errors.As(err, &invalid) {
+		return domainErrors.NewAppError(400, "INVALID_QUERY", invalid.Error(), nil)
+	}
+	return domainErrors.NewAppError(500, code, message, err)
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/pkg/errors"
+)
+
+// PersonalRecordUsecase exposes the personal records computed from the user's session exercises
+type PersonalRecordUsecase struct {
+	recordRepo   repositories.PersonalRecordRepository
+	exerciseRepo repositories.ExerciseRepository
+}
+
+func NewPersonalRecordUsecase(recordRepo repositories.PersonalRecordRepository, exerciseRepo repositories.ExerciseRepository) *PersonalRecordUsecase {
+	return &PersonalRecordUsecase{recordRepo, exerciseRepo}
+}
+
+// GetCurrentRecords returns a page of the standing personal records of the user across every exercise
+func (uc *PersonalRecordUsecase) GetCurrentRecords(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.RecordPersonal], error) {
+	records, err := uc.recordRepo.GetCurrent(userID, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_RECORDS_FAILED", "Failed to get personal records from database")
+	}
+	return records, nil
+}
+
+// GetRecordHistory returns a page of the records the user set on an exercise, oldest first by default
+func (uc *PersonalRecordUsecase) GetRecordHistory(userID, exerciseID uint, spec repositories.QuerySpec) (*repositories.Page[models.RecordPersonal], error) {
+	ejercicio, err := uc.exerciseRepo.GetById(exerciseID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database", err)
+	}
+	if !ejercicio.IsVisibleTo(userID) {
+		return nil, domainErrors.ErrNotFound
+	}
+
+	records, err := uc.recordRepo.GetHistory(userID, exerciseID, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_RECORDS_FAILED", "Failed to get personal records from database")
+	}
+	return records, nil
+}
@@ -65,14 +65,22 @@ func (uc *RoleUseCase) GetRoleByName(ctx context.Context, name string) (*models.
 	return role, nil
 }
 
-// GetAllRoles retrieves all active roles
-func (uc *RoleUseCase) GetAllRoles(ctx context.Context) ([]models.Role, error) {
-	return uc.roleRepo.GetAll(ctx)
+// GetAllRoles retrieves a page of active roles
+func (uc *RoleUseCase) GetAllRoles(ctx context.Context, spec repositories.QuerySpec) (*repositories.Page[models.Role], error) {
+	roles, err := uc.roleRepo.GetAll(ctx, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_ALL_ROLES_FAILED", "Failed to get roles from database")
+	}
+	return roles, nil
 }
 
-// GetAllRolesWithDeleted retrieves all roles including deleted ones
-func (uc *RoleUseCase) GetAllRolesWithDeleted(ctx context.Context) ([]models.Role, error) {
-	return uc.roleRepo.GetAllWithDeleted(ctx)
+// GetAllRolesWithDeleted retrieves a page of roles including deleted ones
+func (uc *RoleUseCase) GetAllRolesWithDeleted(ctx context.Context, spec repositories.QuerySpec) (*repositories.Page[models.Role], error) {
+	roles, err := uc.roleRepo.GetAllWithDeleted(ctx, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_ALL_ROLES_FAILED", "Failed to get roles from database")
+	}
+	return roles, nil
 }
 
 // UpdateRole updates an existing role
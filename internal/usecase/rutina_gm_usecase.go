@@ -8,24 +8,33 @@ import (
 )
 
 type RoutineMuscleGroupUsecase struct {
-	repo repositories.RutinaGrupoMuscularRepository
+	repo     repositories.RutinaGrupoMuscularRepository
+	versions *routineVersioner
 }
 
-func NewRoutineMuscleGroupUsecase(repo repositories.RutinaGrupoMuscularRepository) *RoutineMuscleGroupUsecase {
-	return &RoutineMuscleGroupUsecase{repo}
+func NewRoutineMuscleGroupUsecase(repo repositories.RutinaGrupoMuscularRepository, rutinaRepo repositories.RutinaRepository, versionRepo repositories.RutinaVersionRepository) *RoutineMuscleGroupUsecase {
+	return &RoutineMuscleGroupUsecase{
+		repo:     repo,
+		versions: newRoutineVersioner(rutinaRepo, repo, versionRepo),
+	}
 }
 
 func (uc *RoutineMuscleGroupUsecase) Create(rutinaGM *models.RutinaGrupoMuscular) error {
 	if err := uc.repo.Create(rutinaGM); err != nil {
+		if errors.Is(err, domainErrors.ErrInvalidReference) {
+			return domainErrors.NewAppError(400, "INVALID_REFERENCE", "The muscle group does not exist", err)
+		}
 		return domainErrors.NewAppError(500, "DB_CREATE_ROUTINE_MUSCLE_GROUP_FAILED", "Failed to create routine muscle group in database", err)
 	}
-	return nil
+
+	// Muscle group changes produce a new immutable version of the routine
+	return uc.versions.refresh(rutinaGM.RutinaID)
 }
 
-func (uc *RoutineMuscleGroupUsecase) GetAll() ([]models.RutinaGrupoMuscular, error) {
-	rutinasGM, err := uc.repo.GetAll()
+func (uc *RoutineMuscleGroupUsecase) GetAll(spec repositories.QuerySpec) (*repositories.Page[models.RutinaGrupoMuscular], error) {
+	rutinasGM, err := uc.repo.GetAll(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_ROUTINE_MUSCLE_GROUPS_FAILED", "Failed to get all routine muscle groups from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_ROUTINE_MUSCLE_GROUPS_FAILED", "Failed to get all routine muscle groups from database")
 	}
 	return rutinasGM, nil
 }
@@ -42,7 +51,8 @@ func (uc *RoutineMuscleGroupUsecase) GetByID(id uint) (*models.RutinaGrupoMuscul
 }
 
 func (uc *RoutineMuscleGroupUsecase) Update(rutinaGM *models.RutinaGrupoMuscular) error {
-	if _, err := uc.repo.GetByID(rutinaGM.ID); err != nil {
+	existing, err := uc.repo.GetByID(rutinaGM.ID)
+	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
 			return domainErrors.ErrNotFound
 		}
@@ -51,13 +61,30 @@ func (uc *RoutineMuscleGroupUsecase) Update(rutinaGM *models.RutinaGrupoMuscular
 	if err := uc.repo.Update(rutinaGM); err != nil {
 		return domainErrors.NewAppError(500, "DB_UPDATE_ROUTINE_MUSCLE_GROUP_FAILED", "Failed to update routine muscle group in database", err)
 	}
-	return nil
+
+	// A link moved to another routine changes the content of both routines
+	if existing.RutinaID != rutinaGM.RutinaID {
+		if err := uc.versions.refresh(existing.RutinaID); err != nil {
+			return err
+		}
+	}
+	return uc.versions.refresh(rutinaGM.RutinaID)
 }
 
 func (uc *RoutineMuscleGroupUsecase) Delete(id uint) error {
+	// Look up the link first so the version of its routine can be bumped
+	existing, err := uc.repo.GetByID(id)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return domainErrors.NewAppError(500, "DB_GET_ROUTINE_MUSCLE_GROUP_FAILED", "Failed to get routine muscle group from database", err)
+	}
+
 	if err := uc.repo.Delete(id); err != nil {
 		return domainErrors.NewAppError(500, "DB_DELETE_ROUTINE_MUSCLE_GROUP_FAILED", "Failed to delete routine muscle group from database", err)
 	}
+
+	if existing != nil {
+		return uc.versions.refresh(existing.RutinaID)
+	}
 	return nil
 }
 
@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"strings"
+	"time"
+
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
@@ -8,21 +11,85 @@ import (
 )
 
 type RutinaUsecase struct {
-	repo repositories.RutinaRepository
+	repo         repositories.RutinaRepository
+	rutinaGMRepo repositories.RutinaGrupoMuscularRepository
+	favoritaRepo repositories.FavoritaRepository
+	versions     *routineVersioner
+}
+
+func NewRutinaUsecase(repo repositories.RutinaRepository, rutinaGMRepo repositories.RutinaGrupoMuscularRepository, versionRepo repositories.RutinaVersionRepository, favoritaRepo repositories.FavoritaRepository) *RutinaUsecase {
+	return &RutinaUsecase{
+		repo:         repo,
+		rutinaGMRepo: rutinaGMRepo,
+		favoritaRepo: favoritaRepo,
+		versions:     newRoutineVersioner(repo, rutinaGMRepo, versionRepo),
+	}
 }
 
-func NewRutinaUsecase(repo repositories.RutinaRepository) *RutinaUsecase {
-	return &RutinaUsecase{repo}
+// GetRoutinesByUser returns a page of the routines owned by a user
+func (uc *RutinaUsecase) GetRoutinesByUser(userID uint, spec repositories.QuerySpec) (*repositories.Page[models.Rutina], error) {
+	rutinas, err := uc.repo.GetByUserID(userID, spec)
+	if err != nil {
+		return nil, listFailed(err, "DB_GET_ROUTINES_BY_USER_FAILED", "Failed to get routines by user from database")
+	}
+	if err := uc.withFavorites(rutinas.Items, userID); err != nil {
+		return nil, err
+	}
+	return rutinas, nil
 }
 
-func (uc *RutinaUsecase) GetAllRoutines() ([]models.Rutina, error) {
-	rutinas, err := uc.repo.GetAll()
+// SearchPublicRoutines reads a page of the public routine catalog as seen by viewerID
+func (uc *RutinaUsecase) SearchPublicRoutines(filter repositories.PublicRoutineFilter, spec repositories.QuerySpec, viewerID uint) (*repositories.Page[models.Rutina], error) {
+	rutinas, err := uc.repo.SearchPublic(filter, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_ROUTINES_FAILED", "Failed to get all routines from database", err)
+		return nil, listFailed(err, "DB_SEARCH_PUBLIC_ROUTINES_FAILED", "Failed to search public routines in database")
+	}
+	if err := uc.withFavorites(rutinas.Items, viewerID); err != nil {
+		return nil, err
 	}
 	return rutinas, nil
 }
 
+// GetRoutineForUser returns the fields and relations of a routine requested by the projection, with its
+// favorite information as seen by viewerID. Private routines are only returned to their owner.
+func (uc *RutinaUsecase) GetRoutineForUser(id, viewerID uint, proj repositories.Projection) (*models.Rutina, error) {
+	// The projection may leave out the visibility columns, so they are checked on the full routine
+	if _, err := uc.getVisibleRoutine(id, viewerID); err != nil {
+		return nil, err
+	}
+
+	rutina, err := uc.repo.GetByIDProjected(id, proj)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, listFailed(err, "DB_GET_ROUTINE_FAILED", "Failed to get routine from database")
+	}
+	rutinas := []models.Rutina{*rutina}
+	if err := uc.withFavorites(rutinas, viewerID); err != nil {
+		return nil, err
+	}
+	return &rutinas[0], nil
+}
+
+// withFavorites fills is_favorited for the given routines; favorite_count is read with the routine
+func (uc *RutinaUsecase) withFavorites(rutinas []models.Rutina, viewerID uint) error {
+	ids := make([]uint, 0, len(rutinas))
+	for _, r := range rutinas {
+		ids = append(ids, r.ID)
+	}
+
+	favorited, err := uc.favoritaRepo.GetFavoritedRoutineIDs(viewerID, ids)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_GET_FAVORITES_BY_USER_FAILED", "Failed to get favorites by user from database", err)
+	}
+
+	for i := range rutinas {
+		rutinas[i].IsFavorited = favorited[rutinas[i].ID]
+	}
+	return nil
+}
+
 func (uc *RutinaUsecase) GetRoutineByID(id uint) (*models.Rutina, error) {
 	rutina, err := uc.repo.GetByID(id)
 	if err != nil {
@@ -35,30 +102,262 @@ func (uc *RutinaUsecase) GetRoutineByID(id uint) (*models.Rutina, error) {
 }
 
 func (uc *RutinaUsecase) CreateRoutine(rutina *models.Rutina) error {
+	// Creation date, attribution and versioning are managed by the system
+	rutina.FechaCreacion = time.Now()
+	rutina.OrigenRutinaID = nil
+	rutina.OrigenUsuarioID = nil
+	rutina.OrigenVersionID = nil
+	rutina.VersionActual = 0
+
 	if err := uc.repo.Create(rutina); err != nil {
 		return domainErrors.NewAppError(500, "DB_CREATE_ROUTINE_FAILED", "Failed to create routine in database", err)
 	}
+
+	if _, err := uc.versions.ensureCurrent(rutina); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (uc *RutinaUsecase) UpdateRoutine(rutina *models.Rutina) error {
-	// Verify routine exists before updating
-	if _, err := uc.repo.GetByID(rutina.ID); err != nil {
-		if errors.Is(err, domainErrors.ErrNotFound) {
-			return domainErrors.ErrNotFound
-		}
-		return domainErrors.NewAppError(500, "DB_UPDATE_ROUTINE_FAILED", "Failed to verify routine existence", err)
+// UpdateRoutine replaces the editable fields of a routine owned by userID
+func (uc *RutinaUsecase) UpdateRoutine(rutina *models.Rutina, userID uint) error {
+	existing, err := uc.getOwnedRoutine(rutina.ID, userID)
+	if err != nil {
+		return err
 	}
 
+	// The owner and creation date never change
+	rutina.UsuarioID = existing.UsuarioID
+	rutina.FechaCreacion = existing.FechaCreacion
+	rutina.OrigenRutinaID = existing.OrigenRutinaID
+	rutina.OrigenUsuarioID = existing.OrigenUsuarioID
+	rutina.OrigenVersionID = existing.OrigenVersionID
+	rutina.VersionActual = existing.VersionActual
+
 	if err := uc.repo.Update(rutina); err != nil {
 		return domainErrors.NewAppError(500, "DB_UPDATE_ROUTINE_FAILED", "Failed to update routine in database", err)
 	}
+
+	// Content changes produce a new immutable version
+	if _, err := uc.versions.ensureCurrent(rutina); err != nil {
+		return err
+	}
 	return nil
 }
 
-func (uc *RutinaUsecase) DeleteRoutine(id uint) error {
+// PatchRoutine applies a JSON merge patch to the editable fields of a routine owned by userID
+func (uc *RutinaUsecase) PatchRoutine(id uint, patch MergePatch, userID uint) (*models.Rutina, error) {
+	rutina, err := uc.getOwnedRoutine(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changed, err := applyMergePatch(rutina, patch, "nombre", "objetivo", "publica")
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return rutina, nil
+	}
+	if strings.TrimSpace(rutina.Nombre) == "" {
+		return nil, domainErrors.NewAppError(400, "ROUTINE_NAME_REQUIRED", "Routine name is required", nil)
+	}
+
+	if err := uc.repo.Patch(rutina, changed); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_ROUTINE_FAILED", "Failed to update routine in database", err)
+	}
+
+	// Content changes produce a new immutable version
+	if _, err := uc.versions.ensureCurrent(rutina); err != nil {
+		return nil, err
+	}
+	return rutina, nil
+}
+
+// DeleteRoutine deletes a routine owned by userID
+func (uc *RutinaUsecase) DeleteRoutine(id, userID uint) error {
+	if _, err := uc.getOwnedRoutine(id, userID); err != nil {
+		return err
+	}
 	if err := uc.repo.Delete(id); err != nil {
 		return domainErrors.NewAppError(500, "DB_DELETE_ROUTINE_FAILED", "Failed to delete routine from database", err)
 	}
 	return nil
 }
+
+// CloneRoutine deep-copies a public (or own) routine with its muscle groups into the caller's account
+func (uc *RutinaUsecase) CloneRoutine(id uint, userID uint) (*models.Rutina, error) {
+	source, err := uc.GetRoutineByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !source.IsVisibleTo(userID) {
+		return nil, domainErrors.ErrRoutineNotPublic
+	}
+
+	// Attribute the copy to the exact version it was cloned from
+	sourceVersion, err := uc.versions.ensureCurrent(source)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := &models.Rutina{
+		Nombre:          source.Nombre,
+		Objetivo:        source.Objetivo,
+		FechaCreacion:   time.Now(),
+		Publica:         false,
+		UsuarioID:       userID,
+		OrigenRutinaID:  &source.ID,
+		OrigenUsuarioID: &source.UsuarioID,
+		OrigenVersionID: &sourceVersion.ID,
+	}
+
+	if err := uc.repo.CreateWithMuscleGroups(clone, sourceVersion.GrupoMuscularIDs); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_CLONE_ROUTINE_FAILED", "Failed to clone routine in database", err)
+	}
+
+	if _, err := uc.versions.ensureCurrent(clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// GetRoutineVersions returns the version history of a routine visible to viewerID
+func (uc *RutinaUsecase) GetRoutineVersions(id, viewerID uint) ([]models.RutinaVersion, error) {
+	if _, err := uc.getVisibleRoutine(id, viewerID); err != nil {
+		return nil, err
+	}
+
+	versions, err := uc.versions.versionRepo.GetByRutinaID(id)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_ROUTINE_VERSIONS_FAILED", "Failed to get routine versions from database", err)
+	}
+	return versions, nil
+}
+
+// GetRoutineVersion returns a specific version of a routine visible to viewerID
+func (uc *RutinaUsecase) GetRoutineVersion(id uint, version int, viewerID uint) (*models.RutinaVersion, error) {
+	if _, err := uc.getVisibleRoutine(id, viewerID); err != nil {
+		return nil, err
+	}
+
+	v, err := uc.versions.versionRepo.GetByVersion(id, version)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_ROUTINE_VERSION_FAILED", "Failed to get routine version from database", err)
+	}
+	return v, nil
+}
+
+// getVisibleRoutine returns the routine when it is public or owned by viewerID
+func (uc *RutinaUsecase) getVisibleRoutine(id, viewerID uint) (*models.Rutina, error) {
+	rutina, err := uc.GetRoutineByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !rutina.IsVisibleTo(viewerID) {
+		return nil, domainErrors.ErrRoutineNotPublic
+	}
+	return rutina, nil
+}
+
+// getOwnedRoutine returns the routine when it belongs to userID
+func (uc *RutinaUsecase) getOwnedRoutine(id, userID uint) (*models.Rutina, error) {
+	rutina, err := uc.GetRoutineByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rutina.UsuarioID != userID {
+		return nil, domainErrors.ErrRoutineNotOwned
+	}
+	return rutina, nil
+}
+
+// routineVersioner keeps immutable snapshots of routine content
+type routineVersioner struct {
+	rutinaRepo   repositories.RutinaRepository
+	rutinaGMRepo repositories.RutinaGrupoMuscularRepository
+	versionRepo  repositories.RutinaVersionRepository
+}
+
+func newRoutineVersioner(rutinaRepo repositories.RutinaRepository, rutinaGMRepo repositories.RutinaGrupoMuscularRepository, versionRepo repositories.RutinaVersionRepository) *routineVersioner {
+	return &routineVersioner{
+		rutinaRepo:   rutinaRepo,
+		rutinaGMRepo: rutinaGMRepo,
+		versionRepo:  versionRepo,
+	}
+}
+
+// ensureCurrent returns the version matching the routine's current content,
+// creating a new one when the content changed since the latest snapshot
+func (v *routineVersioner) ensureCurrent(rutina *models.Rutina) (*models.RutinaVersion, error) {
+	grupos, err := v.rutinaGMRepo.GetMusclesGroupByRutine(rutina.ID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_ROUTINE_MUSCLE_GROUPS_FAILED", "Failed to get routine muscle groups from database", err)
+	}
+	grupoIDs := make([]uint, 0, len(grupos.GruposMusculares))
+	for _, g := range grupos.GruposMusculares {
+		grupoIDs = append(grupoIDs, g.ID)
+	}
+
+	latest, err := v.versionRepo.GetLatest(rutina.ID)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return nil, domainErrors.NewAppError(500, "DB_GET_ROUTINE_VERSION_FAILED", "Failed to get latest routine version from database", err)
+	}
+	if latest != nil && latest.SameContent(rutina.Nombre, rutina.Objetivo, grupoIDs) {
+		return latest, nil
+	}
+
+	next := 1
+	if latest != nil {
+		next = latest.Version + 1
+	}
+	version := &models.RutinaVersion{
+		RutinaID:         rutina.ID,
+		Version:          next,
+		Nombre:           rutina.Nombre,
+		Objetivo:         rutina.Objetivo,
+		GrupoMuscularIDs: grupoIDs,
+		CreadaEn:         time.Now(),
+	}
+	if err := v.versionRepo.Create(version); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_CREATE_ROUTINE_VERSION_FAILED", "Failed to create routine version in database", err)
+	}
+
+	rutina.VersionActual = next
+	if err := v.rutinaRepo.Patch(rutina, []string{"version_actual"}); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_ROUTINE_FAILED", "Failed to update routine version in database", err)
+	}
+	return version, nil
+}
+
+// refresh snapshots the routine with the given ID after its muscle groups changed
+func (v *routineVersioner) refresh(rutinaID uint) error {
+	rutina, err := v.rutinaRepo.GetByID(rutinaID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil
+		}
+		return domainErrors.NewAppError(500, "DB_GET_ROUTINE_FAILED", "Failed to get routine from database", err)
+	}
+	_, err = v.ensureCurrent(rutina)
+	return err
+}
+
+// resolve returns the current version of the routine with the given ID, which must be visible to userID
+func (v *routineVersioner) resolve(rutinaID, userID uint) (*models.RutinaVersion, error) {
+	rutina, err := v.rutinaRepo.GetByID(rutinaID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewAppError(400, "INVALID_ROUTINE", "The specified routine does not exist", nil)
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_ROUTINE_FAILED", "Failed to get routine from database", err)
+	}
+	if !rutina.IsVisibleTo(userID) {
+		return nil, domainErrors.ErrRoutineNotPublic
+	}
+	return v.ensureCurrent(rutina)
+}
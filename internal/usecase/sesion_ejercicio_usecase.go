@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"log/slog"
+	"slices"
 	"time"
 
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
@@ -11,23 +13,45 @@ import (
 
 type SessionExerciseUsecase struct {
 	sessionExerciseRepo repositories.SessionExerciseRepository
+	sessionRepo         repositories.SessionRepository
+	exerciseRepo        repositories.ExerciseRepository
+	recordRepo          repositories.PersonalRecordRepository
 }
 
-func NewSessionExerciseUsecase(sessionExerciseRepo repositories.SessionExerciseRepository) *SessionExerciseUsecase {
-	return &SessionExerciseUsecase{sessionExerciseRepo}
+func NewSessionExerciseUsecase(sessionExerciseRepo repositories.SessionExerciseRepository, sessionRepo repositories.SessionRepository, exerciseRepo repositories.ExerciseRepository, recordRepo repositories.PersonalRecordRepository) *SessionExerciseUsecase {
+	return &SessionExerciseUsecase{
+		sessionExerciseRepo: sessionExerciseRepo,
+		sessionRepo:         sessionRepo,
+		exerciseRepo:        exerciseRepo,
+		recordRepo:          recordRepo,
+	}
 }
 
 func (uc *SessionExerciseUsecase) CreateSessionExercise(sessionExercise *models.SesionEjercicio) error {
+	// Completed or abandoned sessions cannot receive new exercises
+	sesion, err := uc.getSession(sessionExercise.SesionID)
+	if err != nil {
+		return err
+	}
+	if sesion.IsClosed() {
+		return domainErrors.ErrSessionClosed
+	}
+	if err := uc.checkExercise(sessionExercise.EjercicioID, sesion.UsuarioID); err != nil {
+		return err
+	}
+	sessionExercise.InheritDate(sesion)
+
 	if err := uc.sessionExerciseRepo.Create(sessionExercise); err != nil {
 		return domainErrors.NewAppError(500, "DB_CREATE_SESSION_EXERCISE_FAILED", "Failed to create session exercise in database", err)
 	}
+	sessionExercise.NuevosRecords = uc.refreshRecords(sesion.UsuarioID, sessionExercise.EjercicioID, sessionExercise.ID)
 	return nil
 }
 
-func (uc *SessionExerciseUsecase) GetAllSessionExercises() ([]*models.SesionEjercicio, error) {
-	sesionesEjercicios, err := uc.sessionExerciseRepo.GetAll()
+func (uc *SessionExerciseUsecase) GetAllSessionExercises(spec repositories.QuerySpec) (*repositories.Page[*models.SesionEjercicio], error) {
+	sesionesEjercicios, err := uc.sessionExerciseRepo.GetAll(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_SESSION_EXERCISES_FAILED", "Failed to get all session exercises from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_SESSION_EXERCISES_FAILED", "Failed to get all session exercises from database")
 	}
 	return sesionesEjercicios, nil
 }
@@ -45,30 +69,203 @@ func (uc *SessionExerciseUsecase) GetSessionExerciseByID(id uint) (*models.Sesio
 
 func (uc *SessionExerciseUsecase) UpdateSessionExercise(sessionExercise *models.SesionEjercicio) error {
 	// Verify session exercise exists before updating
-	if _, err := uc.sessionExerciseRepo.GetById(sessionExercise.ID); err != nil {
+	existing, err := uc.sessionExerciseRepo.GetById(sessionExercise.ID)
+	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
 			return domainErrors.ErrNotFound
 		}
 		return domainErrors.NewAppError(500, "DB_UPDATE_SESSION_EXERCISE_FAILED", "Failed to verify session exercise existence", err)
 	}
 
+	// Swaps are only recorded through SwapExercise
+	sessionExercise.EjercicioOriginalID = existing.EjercicioOriginalID
+
+	sesion, err := uc.getSession(sessionExercise.SesionID)
+	if err != nil {
+		return err
+	}
+	// Entries of completed or abandoned sessions are locked, including moves from or into them
+	if sesion.IsClosed() {
+		return domainErrors.ErrSessionClosed
+	}
+	previousOwnerID := sesion.UsuarioID
+	if sessionExercise.SesionID != existing.SesionID {
+		previous, err := uc.getSession(existing.SesionID)
+		if err != nil {
+			return err
+		}
+		if previous.IsClosed() {
+			return domainErrors.ErrSessionClosed
+		}
+		previousOwnerID = previous.UsuarioID
+	}
+	if err := uc.checkExercise(sessionExercise.EjercicioID, sesion.UsuarioID); err != nil {
+		return err
+	}
+	sessionExercise.InheritDate(sesion)
+
 	if err := uc.sessionExerciseRepo.Update(sessionExercise); err != nil {
 		return domainErrors.NewAppError(500, "DB_UPDATE_SESSION_EXERCISE_FAILED", "Failed to update session exercise in database", err)
 	}
+	if existing.EjercicioID != sessionExercise.EjercicioID || previousOwnerID != sesion.UsuarioID {
+		uc.refreshRecords(previousOwnerID, existing.EjercicioID, 0)
+	}
+	sessionExercise.NuevosRecords = uc.refreshRecords(sesion.UsuarioID, sessionExercise.EjercicioID, sessionExercise.ID)
 	return nil
 }
 
+// PatchSessionExercise applies a JSON merge patch to a session exercise and recalculates the owner's records.
+// The entry cannot be moved to another session.
+func (uc *SessionExerciseUsecase) PatchSessionExercise(id uint, patch MergePatch) (*models.SesionEjercicio, error) {
+	sessionExercise, err := uc.GetSessionExerciseByID(id)
+	if err != nil {
+		return nil, err
+	}
+	sesion, err := uc.getSession(sessionExercise.SesionID)
+	if err != nil {
+		return nil, err
+	}
+	if sesion.IsClosed() {
+		return nil, domainErrors.ErrSessionClosed
+	}
+
+	previousExerciseID := sessionExercise.EjercicioID
+	changed, err := applyMergePatch(sessionExercise, patch, "ejercicio_id", "fecha", "series", "repeticiones",
+		"orden", "peso", "observacion")
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return sessionExercise, nil
+	}
+
+	if slices.Contains(changed, "ejercicio_id") {
+		if err := uc.checkExercise(sessionExercise.EjercicioID, sesion.UsuarioID); err != nil {
+			return nil, err
+		}
+	}
+	// Clearing the date falls back to the date of the session
+	sessionExercise.InheritDate(sesion)
+
+	if err := uc.sessionExerciseRepo.Patch(sessionExercise, changed); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_SESSION_EXERCISE_FAILED", "Failed to update session exercise in database", err)
+	}
+	if previousExerciseID != sessionExercise.EjercicioID {
+		uc.refreshRecords(sesion.UsuarioID, previousExerciseID, 0)
+	}
+	sessionExercise.NuevosRecords = uc.refreshRecords(sesion.UsuarioID, sessionExercise.EjercicioID, sessionExercise.ID)
+	return sessionExercise, nil
+}
+
+// SwapExercise replaces the exercise of a session exercise while the session is in progress
+func (uc *SessionExerciseUsecase) SwapExercise(id, ejercicioID uint) (*models.SesionEjercicio, error) {
+	sessionExercise, err := uc.GetSessionExerciseByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sesion, err := uc.getSession(sessionExercise.SesionID)
+	if err != nil {
+		return nil, err
+	}
+	if sesion.Estado != models.SesionEnCurso {
+		return nil, domainErrors.ErrSessionNotInProgress
+	}
+	if err := uc.checkExercise(ejercicioID, sesion.UsuarioID); err != nil {
+		return nil, err
+	}
+
+	previousExerciseID := sessionExercise.EjercicioID
+	sessionExercise.Swap(ejercicioID)
+	if err := uc.sessionExerciseRepo.Update(sessionExercise); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_SESSION_EXERCISE_FAILED", "Failed to update session exercise in database", err)
+	}
+	uc.refreshRecords(sesion.UsuarioID, previousExerciseID, 0)
+	sessionExercise.NuevosRecords = uc.refreshRecords(sesion.UsuarioID, sessionExercise.EjercicioID, sessionExercise.ID)
+	return sessionExercise, nil
+}
+
 func (uc *SessionExerciseUsecase) DeleteSessionExercise(id uint) error {
+	// Look up the entry first so the session lock applies and the owner's records can be recalculated
+	existing, err := uc.sessionExerciseRepo.GetById(id)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return domainErrors.NewAppError(500, "DB_GET_SESSION_EXERCISE_FAILED", "Failed to get session exercise from database", err)
+	}
+	var sesion *models.Sesion
+	if existing != nil {
+		if sesion, err = uc.getSession(existing.SesionID); err != nil {
+			return err
+		}
+		if sesion.IsClosed() {
+			return domainErrors.ErrSessionClosed
+		}
+	}
+
 	if err := uc.sessionExerciseRepo.Delete(id); err != nil {
 		return domainErrors.NewAppError(500, "DB_DELETE_SESSION_EXERCISE_FAILED", "Failed to delete session exercise from database", err)
 	}
+
+	if sesion != nil {
+		uc.refreshRecords(sesion.UsuarioID, existing.EjercicioID, 0)
+	}
 	return nil
 }
 
-func (uc *SessionExerciseUsecase) GetSessionExercisesBySessionID(sessionID uint, fechaDesde, fechaHasta time.Time) ([]*models.SesionEjercicio, error) {
-	sesionesEjercicios, err := uc.sessionExerciseRepo.GetBySessionID(sessionID, fechaDesde, fechaHasta)
+func (uc *SessionExerciseUsecase) GetSessionExercisesBySessionID(sessionID uint, fechaDesde, fechaHasta time.Time, spec repositories.QuerySpec) (*repositories.Page[*models.SesionEjercicio], error) {
+	sesionesEjercicios, err := uc.sessionExerciseRepo.GetBySessionID(sessionID, fechaDesde, fechaHasta, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_SESSION_EXERCISES_BY_SESSION_FAILED", "Failed to get session exercises by session from database", err)
+		return nil, listFailed(err, "DB_GET_SESSION_EXERCISES_BY_SESSION_FAILED", "Failed to get session exercises by session from database")
 	}
 	return sesionesEjercicios, nil
 }
+
+func (uc *SessionExerciseUsecase) getSession(sessionID uint) (*models.Sesion, error) {
+	sesion, err := uc.sessionRepo.GetById(sessionID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.NewAppError(400, "INVALID_SESSION", "The specified session does not exist", nil)
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_SESSION_FAILED", "Failed to get session from database", err)
+	}
+	return sesion, nil
+}
+
+// checkExercise verifies the exercise exists and is available to the session owner
+func (uc *SessionExerciseUsecase) checkExercise(exerciseID, userID uint) error {
+	ejercicio, err := uc.exerciseRepo.GetById(exerciseID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.NewAppError(400, "INVALID_EXERCISE", "The specified exercise does not exist", nil)
+		}
+		return domainErrors.NewAppError(500, "DB_GET_EXERCISE_FAILED", "Failed to get exercise from database", err)
+	}
+	if !ejercicio.IsVisibleTo(userID) {
+		return domainErrors.NewAppError(400, "INVALID_EXERCISE", "The specified exercise does not exist", nil)
+	}
+	return nil
+}
+
+// refreshRecords recalculates the personal records of a user on an exercise and returns the ones set
+// by the given entry that are still standing, since an entry dated before later sessions may set records
+// that were already beaten. The entry is already saved, so failures are logged instead of returned.
+func (uc *SessionExerciseUsecase) refreshRecords(userID, exerciseID, sessionExerciseID uint) []models.RecordPersonal {
+	entries, err := uc.sessionExerciseRepo.GetByUserAndExercise(userID, exerciseID)
+	if err != nil {
+		slog.Warn("Failed to load entries for personal records", "user_id", userID, "exercise_id", exerciseID, "error", err)
+		return nil
+	}
+
+	records := models.ComputePersonalRecords(userID, entries)
+	if err := uc.recordRepo.ReplaceForExercise(userID, exerciseID, records); err != nil {
+		slog.Warn("Failed to save personal records", "user_id", userID, "exercise_id", exerciseID, "error", err)
+		return nil
+	}
+
+	var nuevos []models.RecordPersonal
+	for _, r := range models.CurrentPersonalRecords(records) {
+		if sessionExerciseID != 0 && r.SesionEjercicioID == sessionExerciseID {
+			nuevos = append(nuevos, r)
+		}
+	}
+	return nuevos
+}
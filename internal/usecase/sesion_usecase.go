@@ -11,45 +11,89 @@ import (
 
 type SessionUsecase struct {
 	sesionRepo repositories.SessionRepository
+	versions   *routineVersioner
 }
 
-func NewSessionUsecase(sesionRepo repositories.SessionRepository) *SessionUsecase {
-	return &SessionUsecase{sesionRepo}
+func NewSessionUsecase(sesionRepo repositories.SessionRepository, rutinaRepo repositories.RutinaRepository, rutinaGMRepo repositories.RutinaGrupoMuscularRepository, versionRepo repositories.RutinaVersionRepository) *SessionUsecase {
+	return &SessionUsecase{
+		sesionRepo: sesionRepo,
+		versions:   newRoutineVersioner(rutinaRepo, rutinaGMRepo, versionRepo),
+	}
 }
 
 func (uc *SessionUsecase) CreateSession(sesion *models.Sesion) error {
+	// New sessions always start planned; lifecycle fields are driven by the endpoints
+	sesion.Estado = models.SesionPlanificada
+	sesion.IniciadaEn = nil
+	sesion.PausadaEn = nil
+	sesion.FinalizadaEn = nil
+	sesion.PausaTotalSeg = 0
+
+	// Pin the routine version the session is performed with
+	sesion.RutinaVersionID = nil
+	if sesion.RutinaID != nil {
+		version, err := uc.versions.resolve(*sesion.RutinaID, sesion.UsuarioID)
+		if err != nil {
+			return err
+		}
+		sesion.RutinaVersionID = &version.ID
+	}
+
 	if err := uc.sesionRepo.Create(sesion); err != nil {
 		return domainErrors.NewAppError(500, "DB_CREATE_SESSION_FAILED", "Failed to create session in database", err)
 	}
 	return nil
 }
 
-func (uc *SessionUsecase) GetAllSessions() ([]*models.Sesion, error) {
-	sesiones, err := uc.sesionRepo.GetAll()
+func (uc *SessionUsecase) GetAllSessions(spec repositories.QuerySpec) (*repositories.Page[*models.Sesion], error) {
+	sesiones, err := uc.sesionRepo.GetAll(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_SESSIONS_FAILED", "Failed to get all sessions from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_SESSIONS_FAILED", "Failed to get all sessions from database")
 	}
 	return sesiones, nil
 }
 
-func (uc *SessionUsecase) GetSessionByID(id uint) (*models.Sesion, error) {
-	sesion, err := uc.sesionRepo.GetById(id)
+// GetSessionByID returns the fields and relations of a session requested by the projection
+func (uc *SessionUsecase) GetSessionByID(id uint, proj repositories.Projection) (*models.Sesion, error) {
+	sesion, err := uc.sesionRepo.GetByIDProjected(id, proj)
 	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
 			return nil, domainErrors.ErrNotFound
 		}
-		return nil, domainErrors.NewAppError(500, "DB_GET_SESSION_FAILED", "Failed to get session from database", err)
+		return nil, listFailed(err, "DB_GET_SESSION_FAILED", "Failed to get session from database")
 	}
 	return sesion, nil
 }
 
-func (uc *SessionUsecase) UpdateSession(sesion *models.Sesion) error {
-	// Verify session exists before updating
-	if _, err := uc.sesionRepo.GetById(sesion.ID); err != nil {
-		if errors.Is(err, domainErrors.ErrNotFound) {
-			return domainErrors.ErrNotFound
+// UpdateSession replaces the editable fields of a session of the user
+func (uc *SessionUsecase) UpdateSession(sesion *models.Sesion, userID uint) error {
+	existing, err := uc.getOwned(sesion.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	// The owner never changes; lifecycle state can only be changed through the lifecycle endpoints
+	sesion.UsuarioID = existing.UsuarioID
+	sesion.Estado = existing.Estado
+	sesion.IniciadaEn = existing.IniciadaEn
+	sesion.PausadaEn = existing.PausadaEn
+	sesion.FinalizadaEn = existing.FinalizadaEn
+	sesion.PausaTotalSeg = existing.PausaTotalSeg
+	if existing.IsTracked() {
+		sesion.DuracionMin = existing.DuracionMin
+	}
+
+	// Keep the pinned routine version unless the routine itself changed
+	sesion.RutinaVersionID = existing.RutinaVersionID
+	if !sameRoutine(sesion.RutinaID, existing.RutinaID) {
+		sesion.RutinaVersionID = nil
+		if sesion.RutinaID != nil {
+			version, err := uc.versions.resolve(*sesion.RutinaID, sesion.UsuarioID)
+			if err != nil {
+				return err
+			}
+			sesion.RutinaVersionID = &version.ID
 		}
-		return domainErrors.NewAppError(500, "DB_UPDATE_SESSION_FAILED", "Failed to verify session existence", err)
 	}
 
 	if err := uc.sesionRepo.Update(sesion); err != nil {
@@ -58,25 +102,133 @@ func (uc *SessionUsecase) UpdateSession(sesion *models.Sesion) error {
 	return nil
 }
 
-func (uc *SessionUsecase) DeleteSession(id uint) error {
+// PatchSession applies a JSON merge patch to the editable fields of a session of the user. The duration
+// of a tracked session is derived from its lifecycle and cannot be patched.
+func (uc *SessionUsecase) PatchSession(id uint, patch MergePatch, userID uint) (*models.Sesion, error) {
+	sesion, err := uc.getOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	patchable := []string{"fecha", "comentarios", "rutina_id"}
+	if !sesion.IsTracked() {
+		patchable = append(patchable, "duracion_min")
+	}
+	previousRoutineID := sesion.RutinaID
+	changed, err := applyMergePatch(sesion, patch, patchable...)
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return sesion, nil
+	}
+
+	// A different routine pins its current version
+	if !sameRoutine(sesion.RutinaID, previousRoutineID) {
+		sesion.RutinaVersionID = nil
+		if sesion.RutinaID != nil {
+			version, err := uc.versions.resolve(*sesion.RutinaID, sesion.UsuarioID)
+			if err != nil {
+				return nil, err
+			}
+			sesion.RutinaVersionID = &version.ID
+		}
+		changed = append(changed, "rutina_version_id")
+	}
+
+	if err := uc.sesionRepo.Patch(sesion, changed); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_SESSION_FAILED", "Failed to update session in database", err)
+	}
+	return sesion, nil
+}
+
+// DeleteSession deletes a session of the user
+func (uc *SessionUsecase) DeleteSession(id, userID uint) error {
+	if _, err := uc.getOwned(id, userID); err != nil {
+		return err
+	}
 	if err := uc.sesionRepo.Delete(id); err != nil {
 		return domainErrors.NewAppError(500, "DB_DELETE_SESSION_FAILED", "Failed to delete session from database", err)
 	}
 	return nil
 }
 
-func (uc *SessionUsecase) GetSessionsByUserID(userID uint) ([]*models.Sesion, error) {
-	sesiones, err := uc.sesionRepo.GetByUserID(userID)
+func (uc *SessionUsecase) GetSessionsByUserID(userID uint, spec repositories.QuerySpec) (*repositories.Page[*models.Sesion], error) {
+	sesiones, err := uc.sesionRepo.GetByUserID(userID, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_SESSIONS_BY_USER_FAILED", "Failed to get sessions by user from database", err)
+		return nil, listFailed(err, "DB_GET_SESSIONS_BY_USER_FAILED", "Failed to get sessions by user from database")
 	}
 	return sesiones, nil
 }
 
-func (uc *SessionUsecase) GetSessionsByDateRange(startDate, endDate time.Time) ([]*models.Sesion, error) {
-	sesiones, err := uc.sesionRepo.GetByDateRange(startDate, endDate)
+func (uc *SessionUsecase) GetSessionsByDateRange(startDate, endDate time.Time, spec repositories.QuerySpec) (*repositories.Page[*models.Sesion], error) {
+	sesiones, err := uc.sesionRepo.GetByDateRange(startDate, endDate, spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_SESSIONS_BY_DATE_RANGE_FAILED", "Failed to get sessions by date range from database", err)
+		return nil, listFailed(err, "DB_GET_SESSIONS_BY_DATE_RANGE_FAILED", "Failed to get sessions by date range from database")
 	}
 	return sesiones, nil
 }
+
+// StartSession starts a planned session
+func (uc *SessionUsecase) StartSession(id, userID uint) (*models.Sesion, error) {
+	return uc.transition(id, userID, (*models.Sesion).Start)
+}
+
+// PauseSession pauses an in-progress session
+func (uc *SessionUsecase) PauseSession(id, userID uint) (*models.Sesion, error) {
+	return uc.transition(id, userID, (*models.Sesion).Pause)
+}
+
+// ResumeSession resumes a paused session
+func (uc *SessionUsecase) ResumeSession(id, userID uint) (*models.Sesion, error) {
+	return uc.transition(id, userID, (*models.Sesion).Resume)
+}
+
+// FinishSession completes an in-progress session and computes its duration
+func (uc *SessionUsecase) FinishSession(id, userID uint) (*models.Sesion, error) {
+	return uc.transition(id, userID, (*models.Sesion).Finish)
+}
+
+// AbandonSession marks a session as abandoned
+func (uc *SessionUsecase) AbandonSession(id, userID uint) (*models.Sesion, error) {
+	return uc.transition(id, userID, (*models.Sesion).Abandon)
+}
+
+// transition loads a session of the user, applies a lifecycle change and persists it
+func (uc *SessionUsecase) transition(id, userID uint, apply func(*models.Sesion, time.Time) error) (*models.Sesion, error) {
+	sesion, err := uc.getOwned(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(sesion, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.sesionRepo.Update(sesion); err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_UPDATE_SESSION_FAILED", "Failed to update session state in database", err)
+	}
+	return sesion, nil
+}
+
+// getOwned returns a session of the user; sessions of other users are reported as missing
+func (uc *SessionUsecase) getOwned(id, userID uint) (*models.Sesion, error) {
+	sesion, err := uc.sesionRepo.GetById(id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_SESSION_FAILED", "Failed to get session from database", err)
+	}
+	if sesion.UsuarioID != userID {
+		return nil, domainErrors.ErrNotFound
+	}
+	return sesion, nil
+}
+
+func sameRoutine(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
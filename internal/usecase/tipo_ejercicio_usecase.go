@@ -9,16 +9,18 @@ import (
 
 type TypeExerciseUsecase struct {
 	typeExerciseRepo repositories.TypeExerciseRepository
+	roleRepo         repositories.RoleRepository
+	blobStore        repositories.BlobStore
 }
 
-func NewTypeExerciseUsecase(typeExerciseRepo repositories.TypeExerciseRepository) *TypeExerciseUsecase {
-	return &TypeExerciseUsecase{typeExerciseRepo}
+func NewTypeExerciseUsecase(typeExerciseRepo repositories.TypeExerciseRepository, roleRepo repositories.RoleRepository, blobStore repositories.BlobStore) *TypeExerciseUsecase {
+	return &TypeExerciseUsecase{typeExerciseRepo, roleRepo, blobStore}
 }
 
-func (uc *TypeExerciseUsecase) GetAllExerciseTypes() ([]*models.TipoEjercicio, error) {
-	tiposEjercicio, err := uc.typeExerciseRepo.GetAll()
+func (uc *TypeExerciseUsecase) GetAllExerciseTypes(spec repositories.QuerySpec) (*repositories.Page[*models.TipoEjercicio], error) {
+	tiposEjercicio, err := uc.typeExerciseRepo.GetAll(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_EXERCISE_TYPES_FAILED", "Failed to get all exercise types from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_EXERCISE_TYPES_FAILED", "Failed to get all exercise types from database")
 	}
 	return tiposEjercicio, nil
 }
@@ -56,9 +58,38 @@ func (uc *TypeExerciseUsecase) UpdateExerciseType(tipoEjercicio *models.TipoEjer
 	return nil
 }
 
-func (uc *TypeExerciseUsecase) DeleteExerciseType(id uint) error {
-	if err := uc.typeExerciseRepo.Delete(id); err != nil {
-		return domainErrors.NewAppError(500, "DB_DELETE_EXERCISE_TYPE_FAILED", "Failed to delete exercise type from database", err)
+// DeleteExerciseType deletes an exercise type; types with exercises require an admin to cascade or reassign them
+func (uc *TypeExerciseUsecase) DeleteExerciseType(id, roleID uint, opts repositories.DeleteOptions) error {
+	if err := checkDeleteOptions(uc.roleRepo, id, roleID, opts); err != nil {
+		return err
 	}
+	if _, err := uc.GetExerciseTypeByID(id); err != nil {
+		return err
+	}
+	if opts.ReassignTo != 0 {
+		if _, err := uc.typeExerciseRepo.GetById(opts.ReassignTo); err != nil {
+			if errors.Is(err, domainErrors.ErrNotFound) {
+				return domainErrors.NewAppError(400, "INVALID_REASSIGN_TARGET", "reassign_to must be an existing exercise type", nil)
+			}
+			return domainErrors.NewAppError(500, "DB_GET_EXERCISE_TYPE_FAILED", "Failed to get exercise type from database", err)
+		}
+	}
+
+	dependents, err := uc.typeExerciseRepo.CountDependents(id)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_EXERCISE_TYPE_FAILED", "Failed to count exercise type dependents", err)
+	}
+	if err := checkDependents("exercise type", dependents, opts); err != nil {
+		return err
+	}
+	if err := checkCascade("exercise type", dependents, opts); err != nil {
+		return err
+	}
+
+	mediaKeys, err := uc.typeExerciseRepo.DeleteWith(id, opts)
+	if err != nil {
+		return deletionError("DB_DELETE_EXERCISE_TYPE_FAILED", "exercise type", err)
+	}
+	deleteBlobs(uc.blobStore, mediaKeys...)
 	return nil
 }
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"strings"
+
+	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
+	models "github.com/Diegonr1791/GymBro/internal/domain/models"
+	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/Diegonr1791/GymBro/internal/i18n"
+	"github.com/pkg/errors"
+)
+
+// TranslationUsecase manages the localized names of the catalog and applies them to responses
+type TranslationUsecase struct {
+	translationRepo  repositories.TranslationRepository
+	exerciseRepo     repositories.ExerciseRepository
+	muscleGroupRepo  repositories.GrupoMuscularRepository
+	exerciseTypeRepo repositories.TypeExerciseRepository
+}
+
+func NewTranslationUsecase(translationRepo repositories.TranslationRepository, exerciseRepo repositories.ExerciseRepository, muscleGroupRepo repositories.GrupoMuscularRepository, exerciseTypeRepo repositories.TypeExerciseRepository) *TranslationUsecase {
+	return &TranslationUsecase{translationRepo, exerciseRepo, muscleGroupRepo, exerciseTypeRepo}
+}
+
+// GetTranslations lists the translations of a catalog entity
+func (uc *TranslationUsecase) GetTranslations(entidad string, entidadID uint) ([]models.Traduccion, error) {
+	if err := uc.checkEntity(entidad, entidadID); err != nil {
+		return nil, err
+	}
+	traducciones, err := uc.translationRepo.GetByEntity(entidad, entidadID)
+	if err != nil {
+		return nil, domainErrors.NewAppError(500, "DB_GET_TRANSLATIONS_FAILED", "Failed to get translations from database", err)
+	}
+	return traducciones, nil
+}
+
+// UpsertTranslation creates or replaces the translation of a catalog entity for a locale
+func (uc *TranslationUsecase) UpsertTranslation(t *models.Traduccion) error {
+	locale, ok := i18n.Normalize(t.Locale)
+	if !ok {
+		return domainErrors.NewAppError(400, "INVALID_LANGUAGE", "Language must be one of: es, en, pt", nil)
+	}
+	if locale == i18n.DefaultLocale {
+		return domainErrors.NewAppError(400, "DEFAULT_LOCALE_TRANSLATION", "The default language is stored in the entity itself", nil)
+	}
+	t.Locale = locale
+
+	t.Nombre = strings.TrimSpace(t.Nombre)
+	if t.Nombre == "" {
+		return domainErrors.NewAppError(400, "TRANSLATION_NAME_REQUIRED", "Translated name is required", nil)
+	}
+
+	if err := uc.checkEntity(t.Entidad, t.EntidadID); err != nil {
+		return err
+	}
+	if err := uc.translationRepo.Upsert(t); err != nil {
+		return domainErrors.NewAppError(500, "DB_UPSERT_TRANSLATION_FAILED", "Failed to save translation in database", err)
+	}
+	return nil
+}
+
+// DeleteTranslation removes the translation of a catalog entity for a locale
+func (uc *TranslationUsecase) DeleteTranslation(entidad string, entidadID uint, locale string) error {
+	normalized, ok := i18n.Normalize(locale)
+	if !ok {
+		return domainErrors.NewAppError(400, "INVALID_LANGUAGE", "Language must be one of: es, en, pt", nil)
+	}
+	deleted, err := uc.translationRepo.Delete(entidad, entidadID, normalized)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_DELETE_TRANSLATION_FAILED", "Failed to delete translation from database", err)
+	}
+	if !deleted {
+		return domainErrors.ErrNotFound
+	}
+	return nil
+}
+
+// LocalizeExercises replaces the texts of the exercises with their translations; missing ones keep the default locale
+func (uc *TranslationUsecase) LocalizeExercises(locale string, ejercicios ...*models.Ejercicio) error {
+	if !needsTranslation(locale) || len(ejercicios) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(ejercicios))
+	for _, e := range ejercicios {
+		ids = append(ids, e.ID)
+	}
+	traducciones, err := uc.translationRepo.GetForEntities(models.EntidadEjercicio, ids, locale)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_GET_TRANSLATIONS_FAILED", "Failed to get translations from database", err)
+	}
+	for _, e := range ejercicios {
+		t, ok := traducciones[e.ID]
+		if !ok {
+			continue
+		}
+		e.Nombre = t.Nombre
+		if t.Descripcion != "" {
+			e.Descripcion = t.Descripcion
+		}
+		if t.Instrucciones != "" {
+			e.Instrucciones = t.Instrucciones
+		}
+	}
+	return nil
+}
+
+// LocalizeMuscleGroups replaces the names of the muscle groups with their translations
+func (uc *TranslationUsecase) LocalizeMuscleGroups(locale string, grupos ...*models.GrupoMuscular) error {
+	if !needsTranslation(locale) || len(grupos) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(grupos))
+	for _, g := range grupos {
+		ids = append(ids, g.ID)
+	}
+	traducciones, err := uc.translationRepo.GetForEntities(models.EntidadGrupoMuscular, ids, locale)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_GET_TRANSLATIONS_FAILED", "Failed to get translations from database", err)
+	}
+	for _, g := range grupos {
+		if t, ok := traducciones[g.ID]; ok {
+			g.Nombre = t.Nombre
+		}
+	}
+	return nil
+}
+
+// LocalizeExerciseTypes replaces the names of the exercise types with their translations
+func (uc *TranslationUsecase) LocalizeExerciseTypes(locale string, tipos ...*models.TipoEjercicio) error {
+	if !needsTranslation(locale) || len(tipos) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(tipos))
+	for _, t := range tipos {
+		ids = append(ids, t.ID)
+	}
+	traducciones, err := uc.translationRepo.GetForEntities(models.EntidadTipoEjercicio, ids, locale)
+	if err != nil {
+		return domainErrors.NewAppError(500, "DB_GET_TRANSLATIONS_FAILED", "Failed to get translations from database", err)
+	}
+	for _, t := range tipos {
+		if tr, ok := traducciones[t.ID]; ok {
+			t.Nombre = tr.Nombre
+		}
+	}
+	return nil
+}
+
+// checkEntity ensures the translated catalog entity exists
+func (uc *TranslationUsecase) checkEntity(entidad string, entidadID uint) error {
+	var err error
+	switch entidad {
+	case models.EntidadEjercicio:
+		var ejercicio *models.Ejercicio
+		if ejercicio, err = uc.exerciseRepo.GetById(entidadID); err == nil && ejercicio.IsCustom {
+			return domainErrors.NewAppError(400, "EXERCISE_NOT_SHARED", "Only shared catalog exercises can be translated", nil)
+		}
+	case models.EntidadGrupoMuscular:
+		_, err = uc.muscleGroupRepo.GetByID(entidadID)
+	case models.EntidadTipoEjercicio:
+		_, err = uc.exerciseTypeRepo.GetById(entidadID)
+	default:
+		return domainErrors.NewAppError(400, "INVALID_ENTITY", "Entity cannot be translated", nil)
+	}
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return domainErrors.ErrNotFound
+		}
+		return domainErrors.NewAppError(500, "DB_GET_ENTITY_FAILED", "Failed to get entity from database", err)
+	}
+	return nil
+}
+
+// needsTranslation checks if the locale differs from the one stored in the catalog
+func needsTranslation(locale string) bool {
+	return locale != "" && locale != i18n.DefaultLocale
+}
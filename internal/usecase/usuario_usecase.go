@@ -2,13 +2,17 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	domainErrors "github.com/Diegonr1791/GymBro/internal/domain/errors"
 	models "github.com/Diegonr1791/GymBro/internal/domain/models"
 	repositories "github.com/Diegonr1791/GymBro/internal/domain/repositories"
+	"github.com/Diegonr1791/GymBro/internal/i18n"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -121,25 +125,37 @@ func (uc *UsuarioUsecase) validateUser(u *models.User, isUpdate bool) error {
 				return err
 			}
 		}
+	}
 
-		if u.Password != "" {
-			if err := uc.validatePassword(u.Password); err != nil {
-				return err
-			}
+	return uc.validatePreferences(u)
+}
+
+// validatePreferences validates the language, height and units of a user, normalizing the language
+func (uc *UsuarioUsecase) validatePreferences(u *models.User) error {
+	if u.Idioma != "" {
+		locale, ok := i18n.Normalize(u.Idioma)
+		if !ok {
+			return domainErrors.NewAppError(400, "INVALID_LANGUAGE", "Language must be one of: es, en, pt", nil)
 		}
+		u.Idioma = locale
+	}
+
+	if u.AlturaCm < 0 || u.AlturaCm > 300 {
+		return domainErrors.NewAppError(400, "INVALID_HEIGHT", "Height must be between 0 and 300 cm", nil)
+	}
+	if u.UnidadPeso != "" && u.UnidadPeso != models.UnidadKg && u.UnidadPeso != models.UnidadLb {
+		return domainErrors.NewAppError(400, "INVALID_UNIT", "Weight unit must be kg or lb", nil)
+	}
+	if u.UnidadLongitud != "" && u.UnidadLongitud != models.UnidadCm && u.UnidadLongitud != models.UnidadIn {
+		return domainErrors.NewAppError(400, "INVALID_UNIT", "Length unit must be cm or in", nil)
 	}
 
 	return nil
 }
 
-func (uc *UsuarioUsecase) CreateUsuario(u *models.User) error {
-	// Validate user data
-	if err := uc.validateUser(u, false); err != nil {
-		return err
-	}
-
-	// Validate that the role exists and is active
-	role, err := uc.roleRepo.GetByID(context.Background(), u.RoleID)
+// validateRole checks that the role exists and is active
+func (uc *UsuarioUsecase) validateRole(roleID uint) error {
+	role, err := uc.roleRepo.GetByID(context.Background(), roleID)
 	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
 			return domainErrors.NewAppError(400, "INVALID_ROLE", "The specified role does not exist", nil)
@@ -150,6 +166,35 @@ func (uc *UsuarioUsecase) CreateUsuario(u *models.User) error {
 	if role.IsSoftDeleted() {
 		return domainErrors.NewAppError(400, "INVALID_ROLE", "The specified role is not available", nil)
 	}
+	return nil
+}
+
+// checkEmailAvailable checks that no other user, deleted or not, has the email
+func (uc *UsuarioUsecase) checkEmailAvailable(email string) error {
+	emailUser, err := uc.repo.GetByEmailIncludingDeleted(email)
+	if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
+		return domainErrors.NewAppError(500, "DB_CHECK_EMAIL_FAILED", "Failed to check email existence", err)
+	}
+
+	if emailUser != nil {
+		if emailUser.IsSoftDeleted() {
+			return domainErrors.NewAppError(409, "EMAIL_SOFT_DELETED", "Email belongs to a deleted user", nil)
+		}
+		return domainErrors.ErrEmailAlreadyExists
+	}
+	return nil
+}
+
+func (uc *UsuarioUsecase) CreateUsuario(u *models.User) error {
+	// Validate user data
+	if err := uc.validateUser(u, false); err != nil {
+		return err
+	}
+
+	// Validate that the role exists and is active
+	if err := uc.validateRole(u.RoleID); err != nil {
+		return err
+	}
 
 	// Check if email already exists
 	existingUser, err := uc.repo.GetByEmailIncludingDeleted(u.Email)
@@ -209,109 +254,209 @@ func (uc *UsuarioUsecase) GetUsuarioByEmail(email string) (*models.User, error)
 	return user, nil
 }
 
-func (uc *UsuarioUsecase) GetAllUsuarios() ([]models.User, error) {
-	users, err := uc.repo.GetAll()
+func (uc *UsuarioUsecase) GetAllUsuarios(spec repositories.QuerySpec) (*repositories.Page[models.User], error) {
+	users, err := uc.repo.GetAll(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_USERS_FAILED", "Failed to get all users from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_USERS_FAILED", "Failed to get all users from database")
 	}
 	return users, nil
 }
 
-func (uc *UsuarioUsecase) GetAllUsuariosIncludingDeleted() ([]models.User, error) {
-	users, err := uc.repo.GetAllIncludingDeleted()
+func (uc *UsuarioUsecase) GetAllUsuariosIncludingDeleted(spec repositories.QuerySpec) (*repositories.Page[models.User], error) {
+	users, err := uc.repo.GetAllIncludingDeleted(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_ALL_USERS_FAILED", "Failed to get all users from database", err)
+		return nil, listFailed(err, "DB_GET_ALL_USERS_FAILED", "Failed to get all users from database")
 	}
 	return users, nil
 }
 
-func (uc *UsuarioUsecase) GetDeletedUsuarios() ([]models.User, error) {
-	users, err := uc.repo.GetDeletedUsers()
+func (uc *UsuarioUsecase) GetDeletedUsuarios(spec repositories.QuerySpec) (*repositories.Page[models.User], error) {
+	users, err := uc.repo.GetDeletedUsers(spec)
 	if err != nil {
-		return nil, domainErrors.NewAppError(500, "DB_GET_DELETED_USERS_FAILED", "Failed to get deleted users from database", err)
+		return nil, listFailed(err, "DB_GET_DELETED_USERS_FAILED", "Failed to get deleted users from database")
 	}
 	return users, nil
 }
 
-func (uc *UsuarioUsecase) UpdateUsuario(u *models.User) error {
+// authorizeUpdate checks that the actor updates their own account or manages users, and reports whether
+// they manage users
+func (uc *UsuarioUsecase) authorizeUpdate(id, actorID, actorRoleID uint) (bool, error) {
+	manager, err := isUserManager(uc.roleRepo, actorRoleID)
+	if err != nil {
+		return false, err
+	}
+	if !manager && id != actorID {
+		return false, domainErrors.NewAppError(403, "INSUFFICIENT_PERMISSIONS", "Users can only update their own account", nil)
+	}
+	return manager, nil
+}
+
+// UpdateUsuario updates the profile of a user on behalf of the actor and returns the saved user. Empty
+// fields of u and a nil isActive keep the current values. Only user managers can update other accounts or
+// change the role and the account status; the password is kept.
+func (uc *UsuarioUsecase) UpdateUsuario(u *models.User, isActive *bool, actorID, actorRoleID uint) (*models.User, error) {
+	manager, err := uc.authorizeUpdate(u.ID, actorID, actorRoleID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if user exists
 	existingUser, err := uc.repo.GetByID(u.ID)
 	if err != nil {
 		if errors.Is(err, domainErrors.ErrNotFound) {
-			return domainErrors.ErrNotFound
+			return nil, domainErrors.ErrNotFound
 		}
-		return domainErrors.NewAppError(500, "DB_GET_USER_FAILED", "Failed to get user for update", err)
+		return nil, domainErrors.NewAppError(500, "DB_GET_USER_FAILED", "Failed to get user for update", err)
 	}
 
 	// Validate user data
 	if err := uc.validateUser(u, true); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validate that the role exists and is active if a new role is provided
-	if u.RoleID != 0 && u.RoleID != existingUser.RoleID {
-		role, err := uc.roleRepo.GetByID(context.Background(), u.RoleID)
-		if err != nil {
-			if errors.Is(err, domainErrors.ErrNotFound) {
-				return domainErrors.NewAppError(400, "INVALID_ROLE", "The specified role does not exist", nil)
-			}
-			return domainErrors.NewAppError(500, "DB_CHECK_ROLE_FAILED", "Failed to validate role", err)
-		}
-
-		if role.IsSoftDeleted() {
-			return domainErrors.NewAppError(400, "INVALID_ROLE", "The specified role is not available", nil)
+	if manager && u.RoleID != 0 && u.RoleID != existingUser.RoleID {
+		if err := uc.validateRole(u.RoleID); err != nil {
+			return nil, err
 		}
 	}
 
 	// Check email uniqueness if email is being updated
 	if u.Email != "" && u.Email != existingUser.Email {
-		emailUser, err := uc.repo.GetByEmailIncludingDeleted(u.Email)
-		if err != nil && !errors.Is(err, domainErrors.ErrNotFound) {
-			return domainErrors.NewAppError(500, "DB_CHECK_EMAIL_FAILED", "Failed to check email existence", err)
+		if err := uc.checkEmailAvailable(u.Email); err != nil {
+			return nil, err
 		}
+	}
 
-		if emailUser != nil {
-			if emailUser.IsSoftDeleted() {
-				return domainErrors.NewAppError(409, "EMAIL_SOFT_DELETED", "Email belongs to a deleted user", nil)
-			}
-			return domainErrors.ErrEmailAlreadyExists
+	// Copy the provided fields onto the stored user, so the password, the creation date and the
+	// deletion flag are kept
+	if u.Name != "" {
+		existingUser.Name = u.Name
+	}
+	if u.Email != "" {
+		existingUser.Email = u.Email
+	}
+	if u.Idioma != "" {
+		existingUser.Idioma = u.Idioma
+	}
+	if u.AlturaCm != 0 {
+		existingUser.AlturaCm = u.AlturaCm
+	}
+	if u.UnidadPeso != "" {
+		existingUser.UnidadPeso = u.UnidadPeso
+	}
+	if u.UnidadLongitud != "" {
+		existingUser.UnidadLongitud = u.UnidadLongitud
+	}
+	if manager {
+		if u.RoleID != 0 {
+			existingUser.RoleID = u.RoleID
+		}
+		if isActive != nil {
+			existingUser.IsActive = *isActive
 		}
 	}
+	existingUser.UpdatedAt = time.Now()
 
-	// Hash password if provided
-	if u.Password != "" {
-		hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
-		if err != nil {
-			return domainErrors.NewAppError(500, "HASH_PASSWORD_FAILED", "Failed to process password during update", err)
+	if err := uc.repo.Update(existingUser); err != nil {
+		if errors.Is(err, domainErrors.ErrConflict) {
+			return nil, domainErrors.ErrEmailAlreadyExists
 		}
-		u.Password = string(hash)
-	} else {
-		// Keep existing password if not provided
-		u.Password = existingUser.Password
+		return nil, domainErrors.NewAppError(500, "USER_UPDATE_FAILED", "Failed to update user account", err)
 	}
+	return existingUser, nil
+}
 
-	// Preserve existing values if not provided
-	if u.Name == "" {
-		u.Name = existingUser.Name
+// PatchUsuario applies a JSON merge patch to the user on behalf of the actor. Only the changed fields are
+// validated and written. Only user managers can patch other accounts or change the role and the account
+// status, and a new password requires the current one in the current_password member.
+func (uc *UsuarioUsecase) PatchUsuario(id uint, patch MergePatch, actorID, actorRoleID uint) (*models.User, error) {
+	manager, err := uc.authorizeUpdate(id, actorID, actorRoleID)
+	if err != nil {
+		return nil, err
 	}
-	if u.Email == "" {
-		u.Email = existingUser.Email
+
+	u, err := uc.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrNotFound) {
+			return nil, domainErrors.ErrNotFound
+		}
+		return nil, domainErrors.NewAppError(500, "DB_GET_USER_FAILED", "Failed to get user for update", err)
+	}
+	currentHash := u.Password
+
+	// The current password proves the password change and is not a field of the user
+	var currentPassword string
+	if raw, ok := patch["current_password"]; ok {
+		patch = maps.Clone(patch)
+		delete(patch, "current_password")
+		if err := json.Unmarshal(raw, &currentPassword); err != nil {
+			return nil, domainErrors.NewAppError(400, "INVALID_JSON", "Invalid JSON body", err)
+		}
 	}
-	if u.RoleID == 0 {
-		u.RoleID = existingUser.RoleID
+
+	changed, err := applyMergePatch(u, patch, "name", "email", "password", "role_id", "idioma", "altura_cm",
+		"unidad_peso", "unidad_longitud", "is_active")
+	if err != nil {
+		return nil, err
+	}
+	if len(changed) == 0 {
+		return u, nil
 	}
 
-	if err := uc.repo.Update(u); err != nil {
-		if errors.Is(err, domainErrors.ErrConflict) {
-			return domainErrors.ErrEmailAlreadyExists
+	if !manager && (slices.Contains(changed, "role_id") || slices.Contains(changed, "is_active")) {
+		return nil, domainErrors.NewAppError(403, "INSUFFICIENT_PERMISSIONS", "Only admins can change the role or the account status", nil)
+	}
+	if slices.Contains(changed, "password") {
+		if currentPassword == "" {
+			return nil, domainErrors.NewAppError(400, "CURRENT_PASSWORD_REQUIRED", "The current password is required to change the password", nil)
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(currentHash), []byte(currentPassword)); err != nil {
+			return nil, domainErrors.NewAppError(403, "INVALID_CURRENT_PASSWORD", "The current password is incorrect", nil)
 		}
-		return domainErrors.NewAppError(500, "USER_UPDATE_FAILED", "Failed to update user account", err)
 	}
 
-	//Default values
-	u.UpdatedAt = time.Now()
+	for _, field := range changed {
+		switch field {
+		case "name":
+			err = uc.validateName(u.Name)
+		case "email":
+			if err = uc.validateEmail(u.Email); err == nil {
+				err = uc.checkEmailAvailable(u.Email)
+			}
+		case "password":
+			err = uc.validatePassword(u.Password)
+		case "role_id":
+			if u.RoleID == 0 {
+				err = domainErrors.NewAppError(400, "ROLE_REQUIRED", "Role is required", nil)
+			} else {
+				err = uc.validateRole(u.RoleID)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.validatePreferences(u); err != nil {
+		return nil, err
+	}
 
-	return nil
+	if slices.Contains(changed, "password") {
+		hash, err := bcrypt.GenerateFromPassword([]byte(u.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, domainErrors.NewAppError(500, "HASH_PASSWORD_FAILED", "Failed to process password during update", err)
+		}
+		u.Password = string(hash)
+	}
+
+	u.UpdatedAt = time.Now()
+	if err := uc.repo.Patch(u, append(changed, "updated_at")); err != nil {
+		if errors.Is(err, domainErrors.ErrConflict) {
+			return nil, domainErrors.ErrEmailAlreadyExists
+		}
+		return nil, domainErrors.NewAppError(500, "USER_UPDATE_FAILED", "Failed to update user account", err)
+	}
+	return u, nil
 }
 
 func (uc *UsuarioUsecase) DeleteUsuario(id uint) error {
@@ -400,3 +545,20 @@ func (uc *UsuarioUsecase) Login(email, password string) (*models.User, error) {
 
 	return usuario, nil
 }
+
+// GetPreferences returns the user's preferred locale, or an empty string when not set, and the
+// preferred units, defaulting to kg and cm. Both are read with a single lookup.
+func (uc *UsuarioUsecase) GetPreferences(userID uint) (string, models.Unidades) {
+	unidades := models.UnidadesSI
+	usuario, err := uc.repo.GetByID(userID)
+	if err != nil {
+		return "", unidades
+	}
+	if usuario.UnidadPeso != "" {
+		unidades.Peso = usuario.UnidadPeso
+	}
+	if usuario.UnidadLongitud != "" {
+		unidades.Longitud = usuario.UnidadLongitud
+	}
+	return usuario.Idioma, unidades
+}